@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/exit"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// NewDiffCmd creates a new diff command
+func NewDiffCmd(f factory.Factory, globalFlags *flags.GlobalFlags, rawConfig *RawConfig) *cobra.Command {
+	cmd := &RunPipelineCmd{
+		GlobalFlags:             globalFlags,
+		Pipeline:                "diff",
+		SkipPushLocalKubernetes: true,
+		RenderWriter:            os.Stdout,
+	}
+
+	var pipeline *latest.Pipeline
+	if rawConfig != nil && rawConfig.Config != nil && rawConfig.Config.Pipelines != nil {
+		pipeline = rawConfig.Config.Pipelines["diff"]
+	}
+	diffCmd := &cobra.Command{
+		Use:   "diff [deployment]",
+		Short: "Shows what a deploy would change in the cluster",
+		Long: `
+#######################################################
+#################### devspace diff ####################
+#######################################################
+Compares the deployments with the live state in the
+cluster and prints a unified diff per resource. Helm
+deployments are compared with the manifest of their
+release and kubectl deployments with the live objects.
+Exits with code 1 if there are changes:
+
+devspace diff
+devspace diff my-deployment
+#######################################################`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			err := cmd.Run(cobraCmd, args, f, "diffCommand")
+			if status, ok := interp.IsExitStatus(errors.Cause(err)); ok && status != 0 {
+				return &exit.ReturnCodeError{
+					ExitCode: int(status),
+				}
+			}
+
+			return err
+		},
+	}
+	cmd.AddPipelineFlags(f, diffCmd, pipeline)
+	return diffCmd
+}
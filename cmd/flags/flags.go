@@ -2,9 +2,16 @@ package flags
 
 import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	flag "github.com/spf13/pflag"
 )
 
+// List of values that output-format can take
+const (
+	OutputFormatText  = "text"
+	OutputFormatJSONL = "jsonl"
+)
+
 // GlobalFlags is the flags that contains the global flags
 type GlobalFlags struct {
 	Silent                   bool
@@ -12,6 +19,7 @@ type GlobalFlags struct {
 	NoColors                 bool
 	Debug                    bool
 	DisableProfileActivation bool
+	FrozenLockfile           bool
 	SwitchContext            bool
 	InactivityTimeout        int
 	KubeConfig               string
@@ -19,6 +27,9 @@ type GlobalFlags struct {
 	Namespace                string
 	KubeContext              string
 	ConfigPath               string
+	OutputFormat             string
+	Trace                    string
+	TraceFormat              string
 	Profiles                 []string
 	Vars                     []string
 
@@ -33,6 +44,7 @@ func (gf *GlobalFlags) ToConfigOptions() *loader.ConfigOptions {
 		OverrideName:             gf.OverrideName,
 		Profiles:                 profiles,
 		DisableProfileActivation: gf.DisableProfileActivation,
+		FrozenLockfile:           gf.FrozenLockfile,
 		Vars:                     gf.Vars,
 	}
 }
@@ -49,9 +61,13 @@ func SetGlobalFlags(flags *flag.FlagSet) *GlobalFlags {
 	flags.BoolVar(&globalFlags.NoColors, "no-colors", false, "Do not show color highlighting in log output. This avoids invisible output with different terminal background colors")
 	flags.BoolVar(&globalFlags.Debug, "debug", false, "Prints the stack trace if an error occurs")
 	flags.BoolVar(&globalFlags.Silent, "silent", false, "Run in silent mode and prevents any devspace log output except panics & fatals")
+	flags.StringVar(&globalFlags.Trace, "trace", "", "If set, writes a trace of the pipeline steps to the given file")
+	flags.StringVar(&globalFlags.TraceFormat, "trace-format", tracing.FormatChrome, "The format of the trace file. Either chrome (chrome://tracing or ui.perfetto.dev) or otlp (OTLP-JSON)")
+	flags.StringVar(&globalFlags.OutputFormat, "output-format", OutputFormatText, "The output format to use. Either text or jsonl. If jsonl, typed events are written as json lines to stdout and logs are written to stderr")
 
 	flags.StringSliceVarP(&globalFlags.Profiles, "profile", "p", []string{}, "The DevSpace profiles to apply. Multiple profiles are applied in the order they are specified")
 	flags.BoolVar(&globalFlags.DisableProfileActivation, "disable-profile-activation", false, "If true will ignore all profile activations")
+	flags.BoolVar(&globalFlags.FrozenLockfile, "frozen-lockfile", false, "If true, fails if the devspace.lock is missing or out of date instead of updating it")
 	flags.BoolVarP(&globalFlags.SwitchContext, "switch-context", "s", false, "Switches and uses the last kube context and namespace that was used to deploy the DevSpace project")
 	flags.StringVarP(&globalFlags.Namespace, "namespace", "n", "", "The kubernetes namespace to use")
 	flags.StringVar(&globalFlags.KubeContext, "kube-context", "", "The kubernetes context to use")
@@ -63,6 +63,7 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 		"NAME",
 		"TYPE",
 		"DEPLOY",
+		"CLUSTER",
 		"STATUS",
 	}
 
@@ -107,6 +108,13 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 		for _, deployConfig := range ctx.Config().Config().Deployments {
 			var deployClient deployer.Interface
 
+			// use the cluster the deployment targets
+			ctx, err := ctx.WithKubeContext(deployConfig.KubeConfig, deployConfig.KubeContext)
+			if err != nil {
+				logger.Warnf("Unable to connect to the cluster of deployment %s: %v", deployConfig.Name, err)
+				continue
+			}
+
 			// Delete kubectl engine
 			if deployConfig.Kubectl != nil {
 				deployClient, err = deployKubectl.New(ctx, deployConfig)
@@ -115,7 +123,7 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 					continue
 				}
 			} else if deployConfig.Helm != nil {
-				helmClient, err := helm.NewClient(logger)
+				helmClient, err := helm.NewClientForEngine(deployConfig.Helm.Engine, logger)
 				if err != nil {
 					logger.Warnf("Unable to create helm deploy config for %s: %v", deployConfig.Name, err)
 					continue
@@ -141,6 +149,7 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 				status.Name,
 				status.Type,
 				status.Target,
+				ctx.KubeClient().CurrentContext(),
 				status.Status,
 			})
 		}
@@ -80,10 +80,18 @@ func (cmd *portsCmd) RunListPort(f factory.Factory, cobraCmd *cobra.Command, arg
 		}
 		// Transform values into string arrays
 		for _, value := range dev.Ports {
+			port := value.Port
+			if value.LocalSocket != "" {
+				port = value.LocalSocket
+			}
+			if value.Target != "" {
+				port += " -> " + value.Target
+			}
+
 			portForwards = append(portForwards, []string{
 				dev.ImageSelector,
 				selector,
-				value.Port,
+				port,
 			})
 		}
 	}
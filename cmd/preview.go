@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/preview"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewPreviewCmd creates a new cobra command for the preview sub command
+func NewPreviewCmd(f factory.Factory, globalFlags *flags.GlobalFlags, rawConfig *RawConfig) *cobra.Command {
+	previewCmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Manages preview environments",
+		Long: `
+#######################################################
+################## devspace preview ###################
+#######################################################
+Preview environments are namespaces with a limited
+lifetime, e.g. one namespace per pull request:
+
+devspace preview create --ttl 72h
+devspace preview list
+devspace preview delete preview-pr-42
+devspace preview gc
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	previewCmd.AddCommand(newPreviewCreateCmd(f, globalFlags, rawConfig))
+	previewCmd.AddCommand(newPreviewListCmd(f, globalFlags))
+	previewCmd.AddCommand(newPreviewDeleteCmd(f, globalFlags))
+	previewCmd.AddCommand(newPreviewGCCmd(f, globalFlags))
+	return previewCmd
+}
+
+// PreviewCreateCmd holds the preview create cmd flags
+type PreviewCreateCmd struct {
+	*RunPipelineCmd
+
+	NameTemplate string
+	Owner        string
+	TTL          time.Duration
+	CreateOnly   bool
+}
+
+func newPreviewCreateCmd(f factory.Factory, globalFlags *flags.GlobalFlags, rawConfig *RawConfig) *cobra.Command {
+	cmd := &PreviewCreateCmd{
+		RunPipelineCmd: &RunPipelineCmd{
+			GlobalFlags:             globalFlags,
+			SkipPushLocalKubernetes: true,
+			Pipeline:                "deploy",
+		},
+		Owner: previewOwner(),
+		TTL:   72 * time.Hour,
+	}
+
+	var pipeline *latest.Pipeline
+	if rawConfig != nil && rawConfig.Config != nil && rawConfig.Config.Pipelines != nil {
+		pipeline = rawConfig.Config.Pipelines["deploy"]
+	}
+	createCmd := &cobra.Command{
+		Use:   "create [name]",
+		Short: "Creates a preview environment and deploys the project into it",
+		Long: `
+#######################################################
+############### devspace preview create ###############
+#######################################################
+Creates a labeled namespace for a preview environment
+and runs the deploy pipeline in it. Without a name, the
+name is built from --name-template, which can use
+${DEVSPACE_GIT_BRANCH}, ${DEVSPACE_GIT_COMMIT} and
+${DEVSPACE_GIT_PULL_REQUEST}:
+
+devspace preview create
+devspace preview create --name-template 'pr-${DEVSPACE_GIT_PULL_REQUEST}'
+devspace preview create my-preview --ttl 24h
+#######################################################
+	`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f, cobraCmd, args)
+		},
+	}
+
+	createCmd.Flags().StringVar(&cmd.NameTemplate, "name-template", "", "The template of the preview name. Defaults to preview-pr-${DEVSPACE_GIT_PULL_REQUEST} in pull requests and preview-${DEVSPACE_GIT_BRANCH} otherwise")
+	createCmd.Flags().StringVar(&cmd.Owner, "owner", cmd.Owner, "The owner of the preview environment")
+	createCmd.Flags().DurationVar(&cmd.TTL, "ttl", cmd.TTL, "The duration after which the preview environment expires (0 to never expire)")
+	createCmd.Flags().BoolVar(&cmd.CreateOnly, "create-only", false, "If enabled will only create the namespace and not run the deploy pipeline")
+	cmd.AddPipelineFlags(f, createCmd, pipeline)
+	return createCmd
+}
+
+// Run executes the preview create command logic
+func (cmd *PreviewCreateCmd) Run(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
+	logger := f.GetLog()
+
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	} else {
+		configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+		if err != nil {
+			return err
+		}
+		_, err = configLoader.SetDevSpaceRoot(logger)
+		if err != nil {
+			return err
+		}
+
+		vars := preview.Variables(context.Background(), ".")
+		template := cmd.NameTemplate
+		if template == "" {
+			template = preview.DefaultNameTemplate
+			if vars["DEVSPACE_GIT_PULL_REQUEST"] != "" {
+				template = preview.PullRequestNameTemplate
+			}
+		}
+
+		name, err = preview.Name(template, vars)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, "")
+	if err != nil {
+		return errors.Wrap(err, "new kube client")
+	}
+
+	created, err := preview.Create(context.Background(), client.KubeClient(), preview.CreateOptions{
+		Name:  name,
+		Owner: cmd.Owner,
+		TTL:   cmd.TTL,
+	})
+	if err != nil {
+		return err
+	}
+	if created.TTL > 0 {
+		logger.Donef("Created preview environment %s (expires %s)", created.Name, created.ExpiresAt().Local().Format(time.RFC1123))
+	} else {
+		logger.Donef("Created preview environment %s", created.Name)
+	}
+	if cmd.CreateOnly {
+		return nil
+	}
+
+	// run the deploy pipeline in the preview namespace
+	globalFlags := *cmd.GlobalFlags
+	globalFlags.Namespace = created.Name
+	globalFlags.NoWarn = true
+	cmd.RunPipelineCmd.GlobalFlags = &globalFlags
+	return cmd.RunPipelineCmd.Run(cobraCmd, nil, f, "deployCommand")
+}
+
+// previewOwner returns the user that runs the command, preferring the CI user
+func previewOwner() string {
+	for _, env := range []string{"GITHUB_ACTOR", "GITLAB_USER_LOGIN", "USER", "USERNAME"} {
+		if value := os.Getenv(env); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// PreviewListCmd holds the preview list cmd flags
+type PreviewListCmd struct {
+	*flags.GlobalFlags
+}
+
+func newPreviewListCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &PreviewListCmd{GlobalFlags: globalFlags}
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists all preview environments",
+		Long: `
+#######################################################
+################ devspace preview list ################
+#######################################################
+Lists all preview environments in the current context
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f)
+		},
+	}
+}
+
+// Run executes the preview list command logic
+func (cmd *PreviewListCmd) Run(f factory.Factory) error {
+	logger := f.GetLog()
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, "")
+	if err != nil {
+		return errors.Wrap(err, "new kube client")
+	}
+
+	previews, err := preview.List(context.Background(), client.KubeClient())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	rows := [][]string{}
+	for _, p := range previews {
+		expires := "never"
+		if !p.ExpiresAt().IsZero() {
+			expires = p.ExpiresAt().Local().Format(time.RFC3339)
+		}
+		status := "Active"
+		if p.Expired(now) {
+			status = "Expired"
+		}
+
+		rows = append(rows, []string{
+			p.Name,
+			p.Owner,
+			p.Created.Local().Format(time.RFC3339),
+			expires,
+			status,
+		})
+	}
+
+	log.PrintTable(logger, []string{"Name", "Owner", "Created", "Expires", "Status"}, rows)
+	return nil
+}
+
+// PreviewDeleteCmd holds the preview delete cmd flags
+type PreviewDeleteCmd struct {
+	*flags.GlobalFlags
+}
+
+func newPreviewDeleteCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &PreviewDeleteCmd{GlobalFlags: globalFlags}
+	return &cobra.Command{
+		Use:   "delete name...",
+		Short: "Deletes preview environments",
+		Long: `
+#######################################################
+############### devspace preview delete ###############
+#######################################################
+Deletes the namespaces and caches of preview environments:
+
+devspace preview delete preview-pr-42
+#######################################################
+	`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f, args)
+		},
+	}
+}
+
+// Run executes the preview delete command logic
+func (cmd *PreviewDeleteCmd) Run(f factory.Factory, args []string) error {
+	logger := f.GetLog()
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, "")
+	if err != nil {
+		return errors.Wrap(err, "new kube client")
+	}
+
+	for _, name := range args {
+		err = preview.Delete(context.Background(), client.KubeClient(), name)
+		if err != nil {
+			return errors.Wrapf(err, "delete preview %s", name)
+		}
+
+		logger.Donef("Deleted preview environment %s", name)
+	}
+
+	return nil
+}
+
+// PreviewGCCmd holds the preview gc cmd flags
+type PreviewGCCmd struct {
+	*flags.GlobalFlags
+
+	DryRun bool
+}
+
+func newPreviewGCCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &PreviewGCCmd{GlobalFlags: globalFlags}
+	gcCmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Deletes expired preview environments",
+		Long: `
+#######################################################
+################# devspace preview gc #################
+#######################################################
+Deletes all preview environments whose ttl has expired
+together with their caches
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f)
+		},
+	}
+
+	gcCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "If enabled will only print the expired preview environments")
+	return gcCmd
+}
+
+// Run executes the preview gc command logic
+func (cmd *PreviewGCCmd) Run(f factory.Factory) error {
+	logger := f.GetLog()
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, "")
+	if err != nil {
+		return errors.Wrap(err, "new kube client")
+	}
+
+	deleted, err := preview.GC(context.Background(), client.KubeClient(), time.Now(), cmd.DryRun)
+	if len(deleted) == 0 && err == nil {
+		logger.Info("No expired preview environments found")
+		return nil
+	} else if len(deleted) > 0 {
+		if cmd.DryRun {
+			logger.Infof("Expired preview environments: %s", strings.Join(deleted, ", "))
+		} else {
+			logger.Donef("Deleted expired preview environments: %s", strings.Join(deleted, ", "))
+		}
+	}
+
+	return err
+}
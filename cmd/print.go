@@ -136,6 +136,8 @@ func (cmd *PrintCmd) Run(f factory.Factory) error {
 		}
 	}
 
+	// secret values are redacted from the printed config
+	bsConfig = []byte(logger.Mask(string(bsConfig)))
 	if cmd.Out != nil {
 		_, err := cmd.Out.Write(bsConfig)
 		if err != nil {
@@ -139,5 +139,35 @@ func ResetPodsRecursive(ctx devspacecontext.Context, dependencies, force bool) i
 		}
 	}
 
+	// reset the dev pods that target another cluster
+	if ctx.Config().Config() == nil {
+		return resetted
+	}
+	for _, devPod := range ctx.Config().Config().Dev {
+		if devPod.KubeConfig == "" && devPod.KubeContext == "" {
+			continue
+		}
+
+		clusterCtx, err := ctx.WithKubeContext(devPod.KubeConfig, devPod.KubeContext)
+		if err != nil {
+			ctx.Log().Warnf("Error resetting dev %s: %v", devPod.Name, err)
+			continue
+		} else if clusterCtx.KubeClient() == ctx.KubeClient() {
+			continue
+		}
+
+		replacePodCache, ok := clusterCtx.Config().RemoteCache().GetDevPod(devPod.Name)
+		if !ok {
+			continue
+		}
+
+		deleted, err := podReplacer.RevertReplacePod(clusterCtx, &replacePodCache, &deploy.PurgeOptions{ForcePurge: force})
+		if err != nil {
+			ctx.Log().Warnf("Error resetting replaced pod: %v", err)
+		} else if deleted {
+			resetted++
+		}
+	}
+
 	return resetted
 }
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/spf13/cobra"
+)
+
+// NewRollbackCmd creates a new rollback command
+func NewRollbackCmd(f factory.Factory, globalFlags *flags.GlobalFlags, rawConfig *RawConfig) *cobra.Command {
+	cmd := &RunPipelineCmd{
+		GlobalFlags:             globalFlags,
+		Pipeline:                "rollback",
+		SkipPushLocalKubernetes: true,
+	}
+
+	var pipeline *latest.Pipeline
+	if rawConfig != nil && rawConfig.Config != nil && rawConfig.Config.Pipelines != nil {
+		pipeline = rawConfig.Config.Pipelines["rollback"]
+	}
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback [deployment]",
+		Short: "Rolls back deployments to their previous revision",
+		Long: `
+#######################################################
+################## devspace rollback ##################
+#######################################################
+Rolls back the deployments to the state before their
+last deploy. Helm releases are rolled back to their
+previous revision and kubectl deployments apply their
+previously applied manifests again:
+
+devspace rollback
+devspace rollback my-deployment
+#######################################################`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd, args, f, "rollbackCommand")
+		},
+	}
+	cmd.AddPipelineFlags(f, rollbackCmd, pipeline)
+	return rollbackCmd
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/expression"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/env"
+	"github.com/loft-sh/devspace/pkg/devspace/events"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
 
@@ -60,6 +61,15 @@ func NewRootCmd(f factory.Factory) *cobra.Command {
 				return nil
 			}
 
+			switch globalFlags.OutputFormat {
+			case "", flags.OutputFormatText:
+			case flags.OutputFormatJSONL:
+				log.UseErrorStreamOnly()
+				events.SetEmitter(events.NewJSONLEmitter(os.Stdout))
+			default:
+				return errors.Errorf("unsupported output format %s, please use either %s or %s", globalFlags.OutputFormat, flags.OutputFormatText, flags.OutputFormatJSONL)
+			}
+
 			log := f.GetLog()
 			if globalFlags.Silent {
 				log.SetLevel(logrus.FatalLevel)
@@ -156,6 +166,7 @@ func Execute() {
 		// Check if return code error
 		retCode, ok := errors.Cause(err).(*exit.ReturnCodeError)
 		if ok {
+			log.Flush()
 			os.Exit(retCode.ExitCode)
 		}
 
@@ -288,6 +299,7 @@ Additional run commands:
 	rootCmd.AddCommand(NewAttachCmd(f, globalFlags))
 	rootCmd.AddCommand(NewPrintCmd(f, globalFlags))
 	rootCmd.AddCommand(NewRunPipelineCmd(f, globalFlags, rawConfig))
+	rootCmd.AddCommand(NewPreviewCmd(f, globalFlags, rawConfig))
 	rootCmd.AddCommand(NewCompletionCmd())
 	rootCmd.AddCommand(NewVersionCmd())
 
@@ -296,6 +308,8 @@ Additional run commands:
 	rootCmd.AddCommand(NewDeployCmd(f, globalFlags, rawConfig))
 	rootCmd.AddCommand(NewBuildCmd(f, globalFlags, rawConfig))
 	rootCmd.AddCommand(NewPurgeCmd(f, globalFlags, rawConfig))
+	rootCmd.AddCommand(NewRollbackCmd(f, globalFlags, rawConfig))
+	rootCmd.AddCommand(NewDiffCmd(f, globalFlags, rawConfig))
 
 	// Add plugin commands
 	if rawConfig != nil && rawConfig.OriginalRawConfig != nil {
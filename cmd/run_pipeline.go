@@ -23,6 +23,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/interrupt"
@@ -249,6 +250,15 @@ func (cmd *RunPipelineCmd) Run(cobraCmd *cobra.Command, args []string, f factory
 	if cobraCmd != nil {
 		cmd.Ctx = values.WithCommandFlags(cmd.Ctx, cobraCmd.Flags())
 	}
+
+	// record the steps of this run
+	if cmd.TraceFormat != "" && cmd.TraceFormat != tracing.FormatChrome && cmd.TraceFormat != tracing.FormatOTLP {
+		return fmt.Errorf("unsupported trace format %s, please use either %s or %s", cmd.TraceFormat, tracing.FormatChrome, tracing.FormatOTLP)
+	}
+	recorder := tracing.NewRecorder()
+	tracing.SetRecorder(recorder)
+	defer cmd.finishTrace(recorder)
+
 	options := cmd.BuildOptions(cmd.ToConfigOptions())
 	ctx, err := initialize(cmd.Ctx, f, options, cmd.Log)
 	if err != nil {
@@ -260,6 +270,26 @@ func (cmd *RunPipelineCmd) Run(cobraCmd *cobra.Command, args []string, f factory
 	})
 }
 
+// finishTrace prints the slowest steps of the run and writes the trace file if requested
+func (cmd *RunPipelineCmd) finishTrace(recorder *tracing.Recorder) {
+	tracing.SetRecorder(nil)
+	if len(recorder.Slowest(1)) > 0 {
+		cmd.Log.WriteString(logrus.InfoLevel, "\n")
+		cmd.Log.Info("Slowest steps:")
+		recorder.PrintSummary(cmd.Log, 10)
+	}
+
+	if cmd.Trace != "" {
+		err := recorder.WriteFile(cmd.Trace, cmd.TraceFormat)
+		if err != nil {
+			cmd.Log.Warnf("Error writing trace to %s: %v", cmd.Trace, err)
+			return
+		}
+
+		cmd.Log.Donef("Wrote trace to %s", cmd.Trace)
+	}
+}
+
 type CommandOptions struct {
 	flags.GlobalFlags
 	types.Options
@@ -103,7 +103,7 @@ func (cmd *varCmd) RunSetVar(f factory.Factory, cobraCmd *cobra.Command, args []
 		// try to find it in definitions
 		for _, def := range variableParser.Definitions {
 			if def.Name == splitted[0] {
-				if def.Command != "" || len(def.Commands) > 0 || def.Source == latest.VariableSourceCommand || def.Source == latest.VariableSourceEnv || def.Source == latest.VariableSourceNone {
+				if def.Command != "" || len(def.Commands) > 0 || def.Secret != nil || def.Source == latest.VariableSourceCommand || def.Source == latest.VariableSourceEnv || def.Source == latest.VariableSourceSecret || def.Source == latest.VariableSourceNone {
 					return errors.Errorf("cannot set variable %s, because variable is not loaded from cache. Please change variable type to cache it", def.Name)
 				}
 			}
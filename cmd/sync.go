@@ -103,6 +103,7 @@ devspace sync --path=.:/app --pod=my-pod --container=my-container
 	syncCmd.Flags().BoolVar(&cmd.Wait, "wait", true, "Wait for the pod(s) to start if they are not running")
 	syncCmd.Flags().BoolVar(&cmd.Polling, "polling", false, "If polling should be used to detect file changes in the container")
 
+	syncCmd.AddCommand(newSyncStatusCmd(f, globalFlags))
 	return syncCmd
 }
 
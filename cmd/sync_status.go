@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/registry"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/services/sync"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+type syncStatusCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+}
+
+func newSyncStatusCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &syncStatusCmd{GlobalFlags: globalFlags}
+
+	syncStatusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows the status of the syncs of a running devspace dev",
+		Long: `
+#######################################################
+############### devspace sync status ##################
+#######################################################
+Shows the statistics of the syncs that are started by
+a running devspace dev of the current project
+
+Examples:
+devspace sync status
+devspace sync status -o json
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f)
+		}}
+
+	syncStatusCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The output format of the command. Can be either empty or json")
+	return syncStatusCmd
+}
+
+// Run executes the sync status command logic
+func (cmd *syncStatusCmd) Run(f factory.Factory) error {
+	logger := f.GetLog()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "create kube client")
+	}
+
+	localCache, err := configLoader.LoadLocalCache()
+	if err != nil {
+		return err
+	}
+
+	// If the current kube context or namespace is different from old,
+	// show warnings and reset kube client if necessary
+	client, err = kubectl.CheckKubeContext(client, localCache, cmd.NoWarn, cmd.SwitchContext, false, logger)
+	if err != nil {
+		return err
+	}
+
+	conf, err := configLoader.LoadWithCache(context.Background(), localCache, client, cmd.ToConfigOptions(), logger)
+	if err != nil {
+		return err
+	}
+
+	// find the running devspace dev through the dependency registry
+	name := conf.Config().Name
+	interProcess := registry.NewInterProcessCommunicator()
+	instance, err := registry.FindInstance(context.Background(), client, name, interProcess)
+	if err != nil {
+		return errors.Wrap(err, "find running devspace dev")
+	} else if instance == nil {
+		return errors.Errorf("couldn't find a running devspace dev for project %s in namespace %s", name, client.Namespace())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	statuses, err := interProcess.SyncStatus(ctx, instance.Server, &registry.PingPayload{RunID: instance.RunID})
+	if err != nil {
+		return errors.Wrap(err, "retrieve sync status")
+	}
+
+	switch cmd.Output {
+	case "":
+		if len(statuses) == 0 {
+			logger.Info("No syncs are running")
+			return nil
+		}
+
+		values := make([][]string, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, syncStatusRow(status))
+		}
+
+		log.PrintTable(logger, []string{
+			"Name",
+			"Path (Local:Container)",
+			"Pod",
+			"Initial Sync",
+			"Pending (Up/Down)",
+			"Uploads",
+			"Downloads",
+			"Deletes",
+			"Retries",
+			"Transferred (Up/Down)",
+			"Last Activity",
+			"Last Error",
+		}, values)
+	case "json":
+		out, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	default:
+		return errors.Errorf("unsupported output format %s, please use either json or leave empty", cmd.Output)
+	}
+
+	return nil
+}
+
+func syncStatusRow(status *sync.Status) []string {
+	stats := status.Stats
+	initialSync := "Running"
+	if stats.InitialSyncCompleted {
+		initialSync = "Completed"
+	}
+
+	lastActivity := "-"
+	if activity := stats.LastActivity(); activity != nil {
+		lastActivity = duration.HumanDuration(time.Since(*activity)) + " ago"
+	}
+
+	lastError := "-"
+	if stats.LastError != "" {
+		lastError = stats.LastError
+		if stats.LastErrorTime != nil {
+			lastError = fmt.Sprintf("%s (%s ago)", lastError, duration.HumanDuration(time.Since(*stats.LastErrorTime)))
+		}
+	}
+
+	return []string{
+		status.Name,
+		status.SyncPath,
+		status.Pod,
+		initialSync,
+		fmt.Sprintf("%d/%d", stats.PendingUploads, stats.PendingDownloads),
+		strconv.FormatInt(stats.Uploads, 10),
+		strconv.FormatInt(stats.Downloads, 10),
+		strconv.FormatInt(stats.Deletes, 10),
+		strconv.FormatInt(stats.Retries, 10),
+		fmt.Sprintf("%0.2f KB/%0.2f KB", float64(stats.BytesUploaded)/1024.0, float64(stats.BytesDownloaded)/1024.0),
+		lastActivity,
+		lastError,
+	}
+}
@@ -0,0 +1,100 @@
+package update
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type dependenciesCmd struct {
+	*flags.GlobalFlags
+}
+
+func newDependenciesCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &dependenciesCmd{GlobalFlags: globalFlags}
+	dependenciesCmd := &cobra.Command{
+		Use:   "dependencies",
+		Short: "Updates the locked revisions of remote sources",
+		Long: `
+#######################################################
+########### devspace update dependencies ##############
+#######################################################
+Resolves all remote imports, dependencies and profile
+parents again and writes their revisions to the
+devspace.lock
+
+devspace update dependencies
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f)
+		}}
+
+	return dependenciesCmd
+}
+
+// Run executes the command logic
+func (cmd *dependenciesCmd) Run(f factory.Factory) error {
+	log := f.GetLog()
+	configOptions := cmd.ToConfigOptions()
+	configOptions.FrozenLockfile = false
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(log)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	// create kubectl client
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+	if err != nil {
+		log.Warnf("Unable to create new kubectl client: %v", err)
+	}
+
+	// resolve every remote source again
+	lock, err := lockfile.Load(filepath.Join(filepath.Dir(configLoader.ConfigPath()), lockfile.FileName), lockfile.Options{Update: true})
+	if err != nil {
+		return err
+	}
+	lockCtx := lockfile.WithLockfile(context.Background(), lock)
+
+	// load config
+	config, err := configLoader.Load(lockCtx, client, configOptions, log)
+	if err != nil {
+		return err
+	}
+
+	// resolve dependencies
+	ctx := devspacecontext.NewContext(lockCtx, config.Variables(), log).
+		WithConfig(config).
+		WithKubeClient(client)
+	_, err = f.NewDependencyManager(ctx, configOptions).ResolveAll(ctx, dependency.ResolveOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolve dependencies")
+	}
+
+	// remove sources that are not used anymore
+	lock.Prune()
+	err = lock.Save()
+	if err != nil {
+		return err
+	}
+
+	log.Donef("Successfully updated %s", lockfile.FileName)
+	return nil
+}
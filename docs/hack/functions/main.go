@@ -237,6 +237,14 @@ var Functions = []Function{
 		Flags:       commands.BuildImagesOptions{},
 		Group:       groupImages,
 	},
+	{
+		Name:        "verify_images",
+		Description: `Verifies the signatures of all images passed as arguments`,
+		Args:        `[image-1] [image-2] ...`,
+		Handler:     commands.VerifyImages,
+		Flags:       commands.VerifyImagesOptions{},
+		Group:       groupImages,
+	},
 	{
 		Name:        "ensure_pull_secrets",
 		Description: `Creates pull secrets for all images passed as arguments`,
@@ -270,6 +278,22 @@ var Functions = []Function{
 		Flags:       commands.PurgeDeploymentsOptions{},
 		Group:       groupDeployments,
 	},
+	{
+		Name:        "rollback_deployments",
+		Description: `Rolls back all deployments passed as arguments to the state before their last deploy`,
+		Args:        `[deployment-1] [deployment-2] ...`,
+		Handler:     commands.RollbackDeployments,
+		Flags:       commands.RollbackDeploymentsOptions{},
+		Group:       groupDeployments,
+	},
+	{
+		Name:        "diff_deployments",
+		Description: `Prints a diff between all deployments passed as arguments and the live state in the cluster. Exits with status 1 if there are changes`,
+		Args:        `[deployment-1] [deployment-2] ...`,
+		Handler:     commands.DiffDeployments,
+		Flags:       commands.DiffDeploymentsOptions{},
+		Group:       groupDeployments,
+	},
 	{
 		Name:        "start_dev",
 		Description: `Starts all dev modes passed as arguments`,
@@ -151,6 +151,7 @@ module.exports = {
                 'configuration/dev/modifications/persistence',
                 'configuration/dev/modifications/resources',
                 'configuration/dev/modifications/patches',
+                'configuration/dev/modifications/intercept',
               ],
             },
           ],
@@ -163,6 +164,8 @@ module.exports = {
           items: [
             'configuration/dependencies/git-repository',
             'configuration/dependencies/local-folder',
+            'configuration/dependencies/oci-and-url',
+            'configuration/dependencies/lockfile',
           ],
         },
         {
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/devspace/helper/intercept"
+	"github.com/spf13/cobra"
+)
+
+// InterceptCmd holds the intercept cmd flags
+type InterceptCmd struct {
+	Listen   string
+	Original string
+	Target   string
+	Headers  []string
+
+	RedirectPort int
+}
+
+// NewInterceptCmd creates a new intercept command
+func NewInterceptCmd() *cobra.Command {
+	cmd := &InterceptCmd{}
+	interceptCmd := &cobra.Command{
+		Use:   "intercept",
+		Short: "Starts a proxy that routes requests with matching headers to a different target",
+		Args:  cobra.NoArgs,
+		RunE:  cmd.Run,
+	}
+
+	interceptCmd.Flags().StringVar(&cmd.Listen, "listen", fmt.Sprintf(":%d", intercept.ProxyPort), "The address the proxy listens on")
+	interceptCmd.Flags().StringVar(&cmd.Original, "original", "", "The address of the original application")
+	interceptCmd.Flags().StringVar(&cmd.Target, "target", fmt.Sprintf("localhost:%d", intercept.TunnelPort), "The address matching requests are sent to")
+	interceptCmd.Flags().StringArrayVar(&cmd.Headers, "header", []string{}, "A header in the form name=value that a request needs to match")
+	interceptCmd.Flags().IntVar(&cmd.RedirectPort, "redirect-port", 0, "If set, incoming traffic of this port is redirected to the proxy while it is running")
+	return interceptCmd
+}
+
+// Run runs the command logic
+func (cmd *InterceptCmd) Run(cobraCmd *cobra.Command, args []string) error {
+	headers, err := intercept.ParseHeaders(cmd.Headers)
+	if err != nil {
+		return err
+	}
+
+	return intercept.Start(cmd.Listen, intercept.Options{
+		Original: cmd.Original,
+		Target:   cmd.Target,
+		Headers:  headers,
+
+		RedirectPort: cmd.RedirectPort,
+	})
+}
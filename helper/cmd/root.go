@@ -39,6 +39,7 @@ func BuildRoot() *cobra.Command {
 	rootCmd.AddCommand(NewRestartCmd())
 	rootCmd.AddCommand(NewVersionCmd())
 	rootCmd.AddCommand(NewTunnelCmd())
+	rootCmd.AddCommand(NewInterceptCmd())
 	rootCmd.AddCommand(NewSSHCmd())
 	rootCmd.AddCommand(sync.NewSyncCmd())
 	rootCmd.AddCommand(proxycommands.NewProxyCommands())
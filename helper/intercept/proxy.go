@@ -0,0 +1,257 @@
+package intercept
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/loft-sh/devspace/helper/util/stderrlog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProxyPort is the port the intercept proxy listens on within the pod. Traffic to the
+	// intercepted port is redirected to this port.
+	ProxyPort = 15080
+
+	// TunnelPort is the port within the pod where the reverse tunnel to the local process listens
+	TunnelPort = 15081
+)
+
+// Options holds the options of the intercept proxy
+type Options struct {
+	// Original is the address of the original application, e.g. localhost:8080
+	Original string
+
+	// Target is the address requests that match the headers are sent to
+	Target string
+
+	// Headers are the headers a request needs to match to be sent to the target
+	Headers map[string]string
+
+	// RedirectPort is the port whose incoming traffic is redirected to the proxy via iptables
+	// as soon as the proxy listens. The redirect is removed again when the proxy stops or
+	// receives SIGINT or SIGTERM.
+	RedirectPort int
+}
+
+// NewProxy creates a new http handler that sends requests that match all headers to the
+// target and all other requests to the original application
+func NewProxy(options Options) (http.Handler, error) {
+	if len(options.Headers) == 0 {
+		return nil, fmt.Errorf("at least one header is required")
+	}
+
+	original, err := newReverseProxy(options.Original)
+	if err != nil {
+		return nil, errors.Wrap(err, "original")
+	}
+
+	target, err := newReverseProxy(options.Target)
+	if err != nil {
+		return nil, errors.Wrap(err, "target")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if Matches(r, options.Headers) {
+			stderrlog.Debugf("intercept %s %s", r.Method, r.URL.Path)
+			target.ServeHTTP(w, r)
+			return
+		}
+
+		original.ServeHTTP(w, r)
+	}), nil
+}
+
+// Matches returns true if the request has all of the given headers. Header names are
+// case-insensitive, values need to match exactly.
+func Matches(r *http.Request, headers map[string]string) bool {
+	for name, value := range headers {
+		found := false
+		for _, v := range r.Header.Values(name) {
+			if v == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseHeaders parses headers in the form name=value or name: value
+func ParseHeaders(headers []string) (map[string]string, error) {
+	ret := map[string]string{}
+	for _, header := range headers {
+		idx := strings.IndexAny(header, "=:")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid header %s, expected name=value", header)
+		}
+
+		ret[strings.TrimSpace(header[:idx])] = strings.TrimSpace(header[idx+1:])
+	}
+
+	return ret, nil
+}
+
+// Start starts the intercept proxy on the given address
+func Start(listen string, options Options) error {
+	handler, err := NewProxy(options)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return errors.Wrap(err, "listen")
+	}
+
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	// only redirect the traffic once the proxy is able to accept it
+	if options.RedirectPort > 0 {
+		proxyPort := listener.Addr().(*net.TCPAddr).Port
+		err = addRedirect(options.RedirectPort, proxyPort)
+		if err != nil {
+			_ = listener.Close()
+			return err
+		}
+		defer func() {
+			err := clearRedirects(options.RedirectPort, 0)
+			if err != nil {
+				stderrlog.Errorf("error removing redirect of port %d: %v", options.RedirectPort, err)
+			}
+		}()
+
+		// the redirect needs to be removed when the container is stopped, otherwise
+		// the traffic of the port would go nowhere
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(signals)
+		go func() {
+			if _, ok := <-signals; ok {
+				_ = server.Close()
+			}
+		}()
+	}
+
+	err = server.Serve(listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+
+	return err
+}
+
+// iptables runs iptables with the given arguments, can be exchanged for testing
+var iptables = func(args ...string) (string, error) {
+	out, err := exec.Command("iptables", args...).CombinedOutput()
+	if err != nil {
+		return "", errors.Errorf("iptables %s: %s %v", strings.Join(args, " "), strings.TrimSpace(string(out)), err)
+	}
+
+	return string(out), nil
+}
+
+// addRedirect redirects incoming tcp traffic of the given port to the proxy port. Rules of
+// a previous proxy that was killed before it could clean up are removed or reused.
+func addRedirect(port, proxyPort int) error {
+	err := clearRedirects(port, proxyPort)
+	if err != nil {
+		return errors.Wrap(err, "remove stale redirects")
+	}
+
+	rule := redirectRule(port, proxyPort)
+	_, err = iptables(append([]string{"-t", "nat", "-C"}, rule...)...)
+	if err == nil {
+		return nil
+	}
+
+	_, err = iptables(append([]string{"-t", "nat", "-A"}, rule...)...)
+	return err
+}
+
+// clearRedirects removes all rules that redirect incoming tcp traffic of the given port,
+// except the ones to the given proxy port if it is not 0
+func clearRedirects(port, keepProxyPort int) error {
+	out, err := iptables("-t", "nat", "-S", "PREROUTING")
+	if err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "-A" {
+			continue
+		}
+
+		toPort, ok := redirectsTo(fields, port)
+		if !ok || (keepProxyPort != 0 && toPort == strconv.Itoa(keepProxyPort)) {
+			continue
+		}
+
+		_, err = iptables(append([]string{"-t", "nat", "-D"}, fields[1:]...)...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func redirectRule(port, proxyPort int) []string {
+	return []string{"PREROUTING", "-p", "tcp", "--dport", strconv.Itoa(port), "-j", "REDIRECT", "--to-ports", strconv.Itoa(proxyPort)}
+}
+
+// redirectsTo returns the port that a rule printed by iptables -S redirects the given port to
+func redirectsTo(fields []string, port int) (string, bool) {
+	dport, redirect, toPort := false, false, ""
+	for i := 0; i+1 < len(fields); i++ {
+		switch fields[i] {
+		case "--dport":
+			dport = fields[i+1] == strconv.Itoa(port)
+		case "-j":
+			redirect = fields[i+1] == "REDIRECT"
+		case "--to-ports":
+			toPort = fields[i+1]
+		}
+	}
+
+	return toPort, dport && redirect
+}
+
+func newReverseProxy(address string) (*httputil.ReverseProxy, error) {
+	if address == "" {
+		return nil, fmt.Errorf("address is missing")
+	}
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+
+	target, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		stderrlog.Errorf("error proxying request to %s: %v", target.Host, err)
+		w.WriteHeader(http.StatusBadGateway)
+	}
+	return proxy, nil
+}
@@ -0,0 +1,154 @@
+package intercept
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newBackend(name string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(name))
+	}))
+}
+
+func TestProxy(t *testing.T) {
+	original := newBackend("original")
+	defer original.Close()
+	target := newBackend("target")
+	defer target.Close()
+
+	handler, err := NewProxy(Options{
+		Original: original.Listener.Addr().String(),
+		Target:   target.URL,
+		Headers:  map[string]string{"x-dev-user": "alice"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxy := httptest.NewServer(handler)
+	defer proxy.Close()
+
+	testCases := []struct {
+		headers  map[string]string
+		expected string
+	}{
+		{
+			expected: "original",
+		},
+		{
+			headers:  map[string]string{"X-Dev-User": "alice"},
+			expected: "target",
+		},
+		{
+			headers:  map[string]string{"x-dev-user": "bob"},
+			expected: "original",
+		},
+	}
+
+	for i, testCase := range testCases {
+		req, err := http.NewRequest(http.MethodGet, proxy.URL+"/test", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, value := range testCase.headers {
+			req.Header.Set(name, value)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		} else if string(body) != testCase.expected {
+			t.Fatalf("test case %d: expected %s, got %s", i, testCase.expected, string(body))
+		}
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders([]string{"x-dev-user=alice", "x-team: a=b"})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(headers) != 2 || headers["x-dev-user"] != "alice" || headers["x-team"] != "a=b" {
+		t.Fatalf("unexpected headers %v", headers)
+	}
+
+	_, err = ParseHeaders([]string{"invalid"})
+	if err == nil {
+		t.Fatal("expected error for invalid header")
+	}
+}
+
+// fakeIptables keeps the rules of the nat PREROUTING chain in memory
+type fakeIptables struct {
+	rules []string
+}
+
+func (f *fakeIptables) run(args ...string) (string, error) {
+	if len(args) < 3 || args[0] != "-t" || args[1] != "nat" {
+		return "", fmt.Errorf("unexpected arguments %v", args)
+	}
+
+	rule := "-A " + strings.Join(args[3:], " ")
+	switch args[2] {
+	case "-S":
+		return strings.Join(append([]string{"-P PREROUTING ACCEPT"}, f.rules...), "\n") + "\n", nil
+	case "-A":
+		f.rules = append(f.rules, rule)
+		return "", nil
+	}
+
+	for i, r := range f.rules {
+		if r == rule {
+			if args[2] == "-D" {
+				f.rules = append(f.rules[:i], f.rules[i+1:]...)
+			}
+			return "", nil
+		}
+	}
+
+	return "", fmt.Errorf("rule %s does not exist", rule)
+}
+
+func TestRedirect(t *testing.T) {
+	fake := &fakeIptables{
+		rules: []string{
+			"-A PREROUTING -p tcp -m tcp --dport 8080 -j REDIRECT --to-ports 15090",
+			"-A PREROUTING -p tcp -m tcp --dport 9090 -j REDIRECT --to-ports 15080",
+		},
+	}
+	defer func(original func(args ...string) (string, error)) { iptables = original }(iptables)
+	iptables = fake.run
+
+	// stale rules of the port are removed and the rule is only added once
+	for i := 0; i < 2; i++ {
+		err := addRedirect(8080, 15080)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := []string{
+		"-A PREROUTING -p tcp -m tcp --dport 9090 -j REDIRECT --to-ports 15080",
+		"-A PREROUTING -p tcp --dport 8080 -j REDIRECT --to-ports 15080",
+	}
+	if !reflect.DeepEqual(fake.rules, expected) {
+		t.Fatalf("unexpected rules %v", fake.rules)
+	}
+
+	// stopping the proxy removes all redirects of the port
+	err := clearRedirects(8080, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(fake.rules, expected[:1]) {
+		t.Fatalf("unexpected rules %v", fake.rules)
+	}
+}
@@ -23,6 +23,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TunnelClient interface {
 	InitTunnel(ctx context.Context, opts ...grpc.CallOption) (Tunnel_InitTunnelClient, error)
+	InitForward(ctx context.Context, opts ...grpc.CallOption) (Tunnel_InitForwardClient, error)
 	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 }
 
@@ -65,6 +66,37 @@ func (x *tunnelInitTunnelClient) Recv() (*SocketDataResponse, error) {
 	return m, nil
 }
 
+func (c *tunnelClient) InitForward(ctx context.Context, opts ...grpc.CallOption) (Tunnel_InitForwardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Tunnel_ServiceDesc.Streams[1], "/remote.Tunnel/InitForward", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tunnelInitForwardClient{stream}
+	return x, nil
+}
+
+type Tunnel_InitForwardClient interface {
+	Send(*SocketDataRequest) error
+	Recv() (*SocketDataResponse, error)
+	grpc.ClientStream
+}
+
+type tunnelInitForwardClient struct {
+	grpc.ClientStream
+}
+
+func (x *tunnelInitForwardClient) Send(m *SocketDataRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *tunnelInitForwardClient) Recv() (*SocketDataResponse, error) {
+	m := new(SocketDataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *tunnelClient) Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/remote.Tunnel/Ping", in, out, opts...)
@@ -79,6 +111,7 @@ func (c *tunnelClient) Ping(ctx context.Context, in *Empty, opts ...grpc.CallOpt
 // for forward compatibility
 type TunnelServer interface {
 	InitTunnel(Tunnel_InitTunnelServer) error
+	InitForward(Tunnel_InitForwardServer) error
 	Ping(context.Context, *Empty) (*Empty, error)
 	mustEmbedUnimplementedTunnelServer()
 }
@@ -90,6 +123,9 @@ type UnimplementedTunnelServer struct {
 func (UnimplementedTunnelServer) InitTunnel(Tunnel_InitTunnelServer) error {
 	return status.Errorf(codes.Unimplemented, "method InitTunnel not implemented")
 }
+func (UnimplementedTunnelServer) InitForward(Tunnel_InitForwardServer) error {
+	return status.Errorf(codes.Unimplemented, "method InitForward not implemented")
+}
 func (UnimplementedTunnelServer) Ping(context.Context, *Empty) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
@@ -132,6 +168,32 @@ func (x *tunnelInitTunnelServer) Recv() (*SocketDataRequest, error) {
 	return m, nil
 }
 
+func _Tunnel_InitForward_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TunnelServer).InitForward(&tunnelInitForwardServer{stream})
+}
+
+type Tunnel_InitForwardServer interface {
+	Send(*SocketDataResponse) error
+	Recv() (*SocketDataRequest, error)
+	grpc.ServerStream
+}
+
+type tunnelInitForwardServer struct {
+	grpc.ServerStream
+}
+
+func (x *tunnelInitForwardServer) Send(m *SocketDataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *tunnelInitForwardServer) Recv() (*SocketDataRequest, error) {
+	m := new(SocketDataRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Tunnel_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -169,6 +231,12 @@ var Tunnel_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "InitForward",
+			Handler:       _Tunnel_InitForward_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "remote.proto",
 }
@@ -180,6 +248,7 @@ type DownstreamClient interface {
 	Download(ctx context.Context, opts ...grpc.CallOption) (Downstream_DownloadClient, error)
 	Changes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Downstream_ChangesClient, error)
 	ChangesCount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChangeAmount, error)
+	Identity(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FilesystemIdentity, error)
 	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 }
 
@@ -263,6 +332,15 @@ func (c *downstreamClient) ChangesCount(ctx context.Context, in *Empty, opts ...
 	return out, nil
 }
 
+func (c *downstreamClient) Identity(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FilesystemIdentity, error) {
+	out := new(FilesystemIdentity)
+	err := c.cc.Invoke(ctx, "/remote.Downstream/Identity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *downstreamClient) Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/remote.Downstream/Ping", in, out, opts...)
@@ -279,6 +357,7 @@ type DownstreamServer interface {
 	Download(Downstream_DownloadServer) error
 	Changes(*Empty, Downstream_ChangesServer) error
 	ChangesCount(context.Context, *Empty) (*ChangeAmount, error)
+	Identity(context.Context, *Empty) (*FilesystemIdentity, error)
 	Ping(context.Context, *Empty) (*Empty, error)
 	mustEmbedUnimplementedDownstreamServer()
 }
@@ -296,6 +375,9 @@ func (UnimplementedDownstreamServer) Changes(*Empty, Downstream_ChangesServer) e
 func (UnimplementedDownstreamServer) ChangesCount(context.Context, *Empty) (*ChangeAmount, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangesCount not implemented")
 }
+func (UnimplementedDownstreamServer) Identity(context.Context, *Empty) (*FilesystemIdentity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Identity not implemented")
+}
 func (UnimplementedDownstreamServer) Ping(context.Context, *Empty) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
@@ -377,6 +459,24 @@ func _Downstream_ChangesCount_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Downstream_Identity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownstreamServer).Identity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Downstream/Identity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownstreamServer).Identity(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Downstream_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -406,6 +506,10 @@ var Downstream_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangesCount",
 			Handler:    _Downstream_ChangesCount_Handler,
 		},
+		{
+			MethodName: "Identity",
+			Handler:    _Downstream_Identity_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _Downstream_Ping_Handler,
@@ -432,7 +536,9 @@ var Downstream_ServiceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UpstreamClient interface {
 	Checksums(ctx context.Context, in *TouchPaths, opts ...grpc.CallOption) (*PathsChecksum, error)
+	Stat(ctx context.Context, in *Paths, opts ...grpc.CallOption) (*ChangeChunk, error)
 	Upload(ctx context.Context, opts ...grpc.CallOption) (Upstream_UploadClient, error)
+	Patch(ctx context.Context, opts ...grpc.CallOption) (Upstream_PatchClient, error)
 	RestartContainer(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 	Remove(ctx context.Context, opts ...grpc.CallOption) (Upstream_RemoveClient, error)
 	Execute(ctx context.Context, in *Command, opts ...grpc.CallOption) (*Empty, error)
@@ -456,6 +562,15 @@ func (c *upstreamClient) Checksums(ctx context.Context, in *TouchPaths, opts ...
 	return out, nil
 }
 
+func (c *upstreamClient) Stat(ctx context.Context, in *Paths, opts ...grpc.CallOption) (*ChangeChunk, error) {
+	out := new(ChangeChunk)
+	err := c.cc.Invoke(ctx, "/remote.Upstream/Stat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *upstreamClient) Upload(ctx context.Context, opts ...grpc.CallOption) (Upstream_UploadClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Upstream_ServiceDesc.Streams[0], "/remote.Upstream/Upload", opts...)
 	if err != nil {
@@ -490,6 +605,40 @@ func (x *upstreamUploadClient) CloseAndRecv() (*Empty, error) {
 	return m, nil
 }
 
+func (c *upstreamClient) Patch(ctx context.Context, opts ...grpc.CallOption) (Upstream_PatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Upstream_ServiceDesc.Streams[1], "/remote.Upstream/Patch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &upstreamPatchClient{stream}
+	return x, nil
+}
+
+type Upstream_PatchClient interface {
+	Send(*Delta) error
+	CloseAndRecv() (*Empty, error)
+	grpc.ClientStream
+}
+
+type upstreamPatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *upstreamPatchClient) Send(m *Delta) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *upstreamPatchClient) CloseAndRecv() (*Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *upstreamClient) RestartContainer(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/remote.Upstream/RestartContainer", in, out, opts...)
@@ -500,7 +649,7 @@ func (c *upstreamClient) RestartContainer(ctx context.Context, in *Empty, opts .
 }
 
 func (c *upstreamClient) Remove(ctx context.Context, opts ...grpc.CallOption) (Upstream_RemoveClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Upstream_ServiceDesc.Streams[1], "/remote.Upstream/Remove", opts...)
+	stream, err := c.cc.NewStream(ctx, &Upstream_ServiceDesc.Streams[2], "/remote.Upstream/Remove", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -556,7 +705,9 @@ func (c *upstreamClient) Ping(ctx context.Context, in *Empty, opts ...grpc.CallO
 // for forward compatibility
 type UpstreamServer interface {
 	Checksums(context.Context, *TouchPaths) (*PathsChecksum, error)
+	Stat(context.Context, *Paths) (*ChangeChunk, error)
 	Upload(Upstream_UploadServer) error
+	Patch(Upstream_PatchServer) error
 	RestartContainer(context.Context, *Empty) (*Empty, error)
 	Remove(Upstream_RemoveServer) error
 	Execute(context.Context, *Command) (*Empty, error)
@@ -571,9 +722,15 @@ type UnimplementedUpstreamServer struct {
 func (UnimplementedUpstreamServer) Checksums(context.Context, *TouchPaths) (*PathsChecksum, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Checksums not implemented")
 }
+func (UnimplementedUpstreamServer) Stat(context.Context, *Paths) (*ChangeChunk, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
+}
 func (UnimplementedUpstreamServer) Upload(Upstream_UploadServer) error {
 	return status.Errorf(codes.Unimplemented, "method Upload not implemented")
 }
+func (UnimplementedUpstreamServer) Patch(Upstream_PatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Patch not implemented")
+}
 func (UnimplementedUpstreamServer) RestartContainer(context.Context, *Empty) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RestartContainer not implemented")
 }
@@ -617,6 +774,24 @@ func _Upstream_Checksums_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Upstream_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Paths)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpstreamServer).Stat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Upstream/Stat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpstreamServer).Stat(ctx, req.(*Paths))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Upstream_Upload_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(UpstreamServer).Upload(&upstreamUploadServer{stream})
 }
@@ -643,6 +818,32 @@ func (x *upstreamUploadServer) Recv() (*Chunk, error) {
 	return m, nil
 }
 
+func _Upstream_Patch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UpstreamServer).Patch(&upstreamPatchServer{stream})
+}
+
+type Upstream_PatchServer interface {
+	SendAndClose(*Empty) error
+	Recv() (*Delta, error)
+	grpc.ServerStream
+}
+
+type upstreamPatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *upstreamPatchServer) SendAndClose(m *Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *upstreamPatchServer) Recv() (*Delta, error) {
+	m := new(Delta)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Upstream_RestartContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -734,6 +935,10 @@ var Upstream_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Checksums",
 			Handler:    _Upstream_Checksums_Handler,
 		},
+		{
+			MethodName: "Stat",
+			Handler:    _Upstream_Stat_Handler,
+		},
 		{
 			MethodName: "RestartContainer",
 			Handler:    _Upstream_RestartContainer_Handler,
@@ -753,6 +958,11 @@ var Upstream_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Upstream_Upload_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Patch",
+			Handler:       _Upstream_Patch_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "Remove",
 			Handler:       _Upstream_Remove_Handler,
@@ -6,6 +6,7 @@ import (
 	"context"
 	"github.com/loft-sh/devspace/helper/util/pingtimeout"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
+	"github.com/loft-sh/devspace/pkg/util/hash"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"io"
 	"log"
@@ -19,6 +20,7 @@ import (
 	"github.com/loft-sh/devspace/helper/util/stderrlog"
 	"github.com/loft-sh/notify"
 
+	"github.com/google/uuid"
 	"github.com/loft-sh/devspace/helper/remote"
 	"github.com/loft-sh/devspace/helper/util"
 	"github.com/pkg/errors"
@@ -227,6 +229,26 @@ func (d *Downstream) Ping(context.Context, *remote.Empty) (*remote.Empty, error)
 	return &remote.Empty{}, nil
 }
 
+// Identity returns an id that identifies the filesystem of this container. The id is stored in a marker
+// file within the container, which means a restarted or recreated container will return a new id.
+func (d *Downstream) Identity(context.Context, *remote.Empty) (*remote.FilesystemIdentity, error) {
+	fileName := "/tmp/devspace-sync-" + hash.String(d.options.RemotePath)
+	id, err := os.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		id = []byte(uuid.New().String())
+		err = os.WriteFile(fileName, id, 0666)
+		if err != nil {
+			return nil, errors.Wrap(err, "write identity file")
+		}
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read identity file")
+	}
+
+	return &remote.FilesystemIdentity{
+		Id: string(id),
+	}, nil
+}
+
 // ChangesCount returns the amount of changes on the remote side
 func (d *Downstream) ChangesCount(context.Context, *remote.Empty) (*remote.ChangeAmount, error) {
 	newState := make(map[string]*remote.Change)
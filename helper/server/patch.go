@@ -0,0 +1,162 @@
+package server
+
+import (
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/loft-sh/devspace/helper/util/rsync"
+	"github.com/pkg/errors"
+)
+
+// fileChecksum calculates the crc32 checksum and, if requested, the block signatures of the given
+// file while reading it only once. If the file cannot be read an empty signature is returned,
+// which tells the client to upload the complete file.
+func fileChecksum(absolutePath string, withSignature bool) (uint32, *remote.FileSignature, error) {
+	file, err := os.Open(absolutePath)
+	if err != nil {
+		return 0, &remote.FileSignature{}, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, &remote.FileSignature{}, err
+	} else if stat.IsDir() {
+		return 0, &remote.FileSignature{}, nil
+	}
+
+	checksum := crc32.NewIEEE()
+	if !withSignature {
+		_, err = io.Copy(checksum, file)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		return checksum.Sum32(), nil, nil
+	}
+
+	signature, err := rsync.Signature(io.TeeReader(file, checksum), rsync.BlockSize(stat.Size()))
+	if err != nil {
+		return 0, &remote.FileSignature{}, err
+	}
+
+	return checksum.Sum32(), signature, nil
+}
+
+// patchFile rebuilds a single file from a stream of delta operations. The new contents
+// are written to a temporary file next to the original one, which replaces the original
+// file as soon as all operations were applied and the checksum matches.
+type patchFile struct {
+	path string
+
+	absolutePath string
+	options      *UpstreamOptions
+
+	base    *os.File
+	stat    os.FileInfo
+	temp    *os.File
+	written hash.Hash32
+}
+
+func newPatchFile(relativePath string, options *UpstreamOptions) (*patchFile, error) {
+	absolutePath := path.Join(options.UploadPath, getRelativeFromFullPath("/"+relativePath, ""))
+	base, err := os.Open(absolutePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "open base file")
+	}
+
+	stat, err := base.Stat()
+	if err != nil {
+		base.Close()
+		return nil, errors.Wrap(err, "stat base file")
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(absolutePath), ".devspace-patch-")
+	if err != nil {
+		base.Close()
+		return nil, errors.Wrap(err, "create temp file")
+	}
+
+	return &patchFile{
+		path:         relativePath,
+		absolutePath: absolutePath,
+		options:      options,
+		base:         base,
+		stat:         stat,
+		temp:         temp,
+		written:      crc32.NewIEEE(),
+	}, nil
+}
+
+// Apply applies a single delta operation
+func (p *patchFile) Apply(blockSize int64, operation *remote.DeltaOperation) error {
+	return rsync.Apply(p.base, blockSize, operation, io.MultiWriter(p.temp, p.written))
+}
+
+// Finish verifies the written contents and replaces the original file
+func (p *patchFile) Finish(delta *remote.Delta) error {
+	if p.written.Sum32() != delta.Checksum {
+		p.Abort()
+		return errors.Errorf("checksum mismatch %d != %d", p.written.Sum32(), delta.Checksum)
+	}
+
+	_ = p.base.Close()
+	err := p.temp.Close()
+	if err != nil {
+		_ = os.Remove(p.temp.Name())
+		return err
+	}
+
+	// Set old permissions and owner and group
+	if p.options.OverridePermission && delta.Mode > 0 {
+		_ = os.Chmod(p.temp.Name(), os.FileMode(delta.Mode))
+	} else {
+		_ = os.Chmod(p.temp.Name(), p.stat.Mode())
+	}
+	_ = Chown(p.temp.Name(), p.stat)
+
+	err = os.Rename(p.temp.Name(), p.absolutePath)
+	if err != nil {
+		_ = os.Remove(p.temp.Name())
+		return errors.Wrap(err, "replace file")
+	}
+
+	// Set mod time
+	if delta.MtimeUnix > 0 {
+		_ = os.Chtimes(p.absolutePath, time.Now(), time.Unix(delta.MtimeUnix, 0))
+	}
+
+	// Execute command if defined
+	if p.options.FileChangeCmd != "" {
+		cmdArgs := make([]string, 0, len(p.options.FileChangeArgs))
+		for _, arg := range p.options.FileChangeArgs {
+			if arg == "{}" {
+				cmdArgs = append(cmdArgs, p.absolutePath)
+			} else {
+				cmdArgs = append(cmdArgs, arg)
+			}
+		}
+
+		out, err := exec.Command(p.options.FileChangeCmd, cmdArgs...).CombinedOutput()
+		if err != nil {
+			return errors.Errorf("error executing command '%s %s': %s => %v", p.options.FileChangeCmd, strings.Join(cmdArgs, " "), string(out), err)
+		}
+	}
+
+	return nil
+}
+
+// Abort removes the temporary file and leaves the original file untouched
+func (p *patchFile) Abort() {
+	_ = p.base.Close()
+	_ = p.temp.Close()
+	_ = os.Remove(p.temp.Name())
+}
@@ -5,7 +5,6 @@ import (
 	"github.com/loft-sh/devspace/helper/remote"
 	"github.com/loft-sh/devspace/helper/server/ignoreparser"
 	"github.com/loft-sh/devspace/helper/util"
-	"github.com/loft-sh/devspace/helper/util/crc32"
 	"github.com/loft-sh/devspace/helper/util/pingtimeout"
 	"github.com/loft-sh/devspace/helper/util/stderrlog"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
@@ -171,6 +170,7 @@ func (u *Upstream) Checksums(ctx context.Context, paths *remote.TouchPaths) (*re
 		}()
 
 		checksums := make([]uint32, 0, len(paths.Paths))
+		signatures := make([]*remote.FileSignature, 0, len(paths.Paths))
 		for _, path := range paths.Paths {
 			if path.Path == "" {
 				continue
@@ -178,21 +178,55 @@ func (u *Upstream) Checksums(ctx context.Context, paths *remote.TouchPaths) (*re
 
 			// Just remove everything inside and ignore any errors
 			absolutePath := filepath.Join(u.options.UploadPath, path.Path)
-			checksum, err := crc32.Checksum(absolutePath)
+			checksum, signature, err := fileChecksum(absolutePath, paths.Signatures)
 			if err != nil && !os.IsNotExist(err) {
 				stderrlog.Infof("Error checksum %s: %v", path, err)
 			}
 
 			checksums = append(checksums, checksum)
+			if paths.Signatures {
+				signatures = append(signatures, signature)
+			}
 		}
 
 		<-stopChan
+		if paths.Signatures {
+			return &remote.PathsChecksum{Checksums: checksums, Signatures: signatures}, nil
+		}
+
 		return &remote.PathsChecksum{Checksums: checksums}, nil
 	}
 
 	return &remote.PathsChecksum{Checksums: []uint32{}}, nil
 }
 
+// Stat returns the current state of the given paths. Paths that do not exist are returned as delete changes
+func (u *Upstream) Stat(ctx context.Context, paths *remote.Paths) (*remote.ChangeChunk, error) {
+	changes := make([]*remote.Change, 0, len(paths.Paths))
+	for _, path := range paths.Paths {
+		stat, err := os.Stat(filepath.Join(u.options.UploadPath, path))
+		if err != nil {
+			changes = append(changes, &remote.Change{
+				ChangeType: remote.ChangeType_DELETE,
+				Path:       path,
+			})
+			continue
+		}
+
+		changes = append(changes, &remote.Change{
+			ChangeType:    remote.ChangeType_CHANGE,
+			Path:          path,
+			MtimeUnix:     stat.ModTime().Unix(),
+			MtimeUnixNano: stat.ModTime().UnixNano(),
+			Size:          stat.Size(),
+			Mode:          uint32(stat.Mode()),
+			IsDir:         stat.IsDir(),
+		})
+	}
+
+	return &remote.ChangeChunk{Changes: changes}, nil
+}
+
 func (u *Upstream) removeRecursive(absolutePath string) error {
 	files, err := os.ReadDir(absolutePath)
 	if err != nil {
@@ -262,6 +296,58 @@ func (u *Upstream) Upload(stream remote.Upstream_UploadServer) error {
 	return stream.SendAndClose(&remote.Empty{})
 }
 
+// Patch implements the server patch interface and rebuilds the received files from
+// the already existing blocks and the transferred literal data
+func (u *Upstream) Patch(stream remote.Upstream_PatchServer) error {
+	var current *patchFile
+	defer func() {
+		if current != nil {
+			current.Abort()
+		}
+	}()
+
+	for {
+		delta, err := stream.Recv()
+		if delta != nil {
+			if current != nil && current.path != delta.Path {
+				return errors.Errorf("unexpected delta for %s, expected %s", delta.Path, current.path)
+			} else if current == nil {
+				current, err = newPatchFile(delta.Path, u.options)
+				if err != nil {
+					return errors.Wrapf(err, "patch %s", delta.Path)
+				}
+			}
+
+			for _, operation := range delta.Operations {
+				err = current.Apply(delta.BlockSize, operation)
+				if err != nil {
+					return errors.Wrapf(err, "patch %s", delta.Path)
+				}
+			}
+
+			if delta.Done {
+				err = current.Finish(delta)
+				current = nil
+				if err != nil {
+					return errors.Wrapf(err, "patch %s", delta.Path)
+				}
+			}
+
+			continue
+		}
+
+		if err == io.EOF {
+			if current != nil {
+				return errors.Errorf("unexpected end of patch for %s", current.path)
+			}
+
+			return stream.SendAndClose(&remote.Empty{})
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
 func (u *Upstream) writeTar(writer io.WriteCloser, stream remote.Upstream_UploadServer) error {
 	defer writer.Close()
 
@@ -5,8 +5,10 @@ package server
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
 	"context"
+	"hash/crc32"
 	"io"
 	"log"
 	"math/rand"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/loft-sh/devspace/helper/remote"
 	"github.com/loft-sh/devspace/helper/util"
+	"github.com/loft-sh/devspace/helper/util/rsync"
 	"github.com/pkg/errors"
 )
 
@@ -263,3 +266,43 @@ func TestUpstreamServer(t *testing.T) {
 		t.Fatalf("Expected empty toDir, but still has %d entries", len(files))
 	}
 }
+
+func TestFileChecksum(t *testing.T) {
+	content := random(10 * 1024)
+	filePath := filepath.Join(t.TempDir(), "file")
+	err := os.WriteFile(filePath, content, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSignature, err := rsync.Signature(bytes.NewReader(content), rsync.BlockSize(int64(len(content))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksum, signature, err := fileChecksum(filePath, true)
+	if err != nil {
+		t.Fatal(err)
+	} else if checksum != crc32.ChecksumIEEE(content) {
+		t.Fatalf("Unexpected checksum %d", checksum)
+	} else if len(signature.Blocks) != len(expectedSignature.Blocks) || signature.BlockSize != expectedSignature.BlockSize {
+		t.Fatalf("Unexpected signature with %d blocks of size %d", len(signature.Blocks), signature.BlockSize)
+	}
+	for i, block := range signature.Blocks {
+		if block.Weak != expectedSignature.Blocks[i].Weak || !bytes.Equal(block.Strong, expectedSignature.Blocks[i].Strong) {
+			t.Fatalf("Unexpected signature of block %d", i)
+		}
+	}
+
+	checksum, signature, err = fileChecksum(filePath, false)
+	if err != nil {
+		t.Fatal(err)
+	} else if checksum != crc32.ChecksumIEEE(content) || signature != nil {
+		t.Fatalf("Unexpected checksum %d without signature", checksum)
+	}
+
+	_, signature, err = fileChecksum(filepath.Join(t.TempDir(), "missing"), true)
+	if !os.IsNotExist(err) || len(signature.Blocks) != 0 {
+		t.Fatalf("Expected an empty signature for a missing file, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package tunnel
+
+import "strings"
+
+// UnixScheme is the prefix of addresses that point to a unix socket
+const UnixScheme = "unix://"
+
+// IsUnixAddress returns true if the address points to a unix socket
+func IsUnixAddress(address string) bool {
+	return strings.HasPrefix(address, UnixScheme)
+}
+
+// SplitAddress returns the network and address that should be dialed. Addresses in
+// the form unix:///path/to/socket are unix sockets, all other addresses are host:port
+// addresses of the given network.
+func SplitAddress(network, address string) (string, string) {
+	if IsUnixAddress(address) {
+		return "unix", strings.TrimPrefix(address, UnixScheme)
+	}
+
+	return network, address
+}
@@ -0,0 +1,29 @@
+package tunnel
+
+import "testing"
+
+func TestSplitAddress(t *testing.T) {
+	testCases := []struct {
+		address         string
+		expectedNetwork string
+		expectedAddress string
+	}{
+		{
+			address:         "db.local:5432",
+			expectedNetwork: "tcp",
+			expectedAddress: "db.local:5432",
+		},
+		{
+			address:         "unix:///var/run/docker.sock",
+			expectedNetwork: "unix",
+			expectedAddress: "/var/run/docker.sock",
+		},
+	}
+
+	for _, testCase := range testCases {
+		network, address := SplitAddress("tcp", testCase.address)
+		if network != testCase.expectedNetwork || address != testCase.expectedAddress {
+			t.Fatalf("%s: expected %s %s, got %s %s", testCase.address, testCase.expectedNetwork, testCase.expectedAddress, network, address)
+		}
+	}
+}
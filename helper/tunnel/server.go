@@ -63,31 +63,32 @@ func SendData(stream remote.Tunnel_InitTunnelServer, sessions <-chan *Session, c
 			// read the bytes from the buffer
 			// but allow it to keep growing while we send the response
 			session.Lock()
-			bys := session.Buf.Len()
-			bytes := make([]byte, bys)
-			_, _ = session.Buf.Read(bytes)
+			bytes, datagrams := session.Take()
 			resp := &remote.SocketDataResponse{
 				HasErr:      false,
 				LogMessage:  nil,
 				Data:        bytes,
+				Datagrams:   datagrams,
 				RequestId:   session.ID.String(),
 				ShouldClose: !session.Open,
 			}
 			session.Unlock()
 
-			stderrlog.Debugf("sending %d bytes to client", len(bytes))
+			stderrlog.Debugf("sending %d bytes and %d datagrams to client", len(bytes), len(datagrams))
 			err := stream.Send(resp)
 			if err != nil {
 				stderrlog.Errorf("failed sending message to tunnel stream: %v", err)
 				close(closeChan)
 				return
 			}
-			stderrlog.Debugf("sent %d bytes to client", len(bytes))
+			stderrlog.Debugf("sent %d bytes and %d datagrams to client", len(bytes), len(datagrams))
 		}
 	}
 }
 
-func ReceiveData(stream remote.Tunnel_InitTunnelServer, closeChan chan struct{}) {
+// ReceiveData writes the data received from the stream to the sessions. If newSession is
+// not nil, it is called to open a new session for unknown request ids.
+func ReceiveData(stream remote.Tunnel_InitTunnelServer, closeChan chan struct{}, newSession func(id uuid.UUID) (*Session, error)) {
 	for {
 		select {
 		case <-closeChan:
@@ -109,25 +110,37 @@ func ReceiveData(stream remote.Tunnel_InitTunnelServer, closeChan chan struct{})
 			}
 
 			session, ok := GetSession(reqID)
-			if !ok && !message.ShouldClose {
-				stderrlog.Errorf("%s; session not found in openRequests", reqID)
+			if !ok && newSession != nil && !message.ShouldClose {
+				session, err = newSession(reqID)
+				if err != nil {
+					stderrlog.Errorf("%s; failed to open session: %v", reqID, err)
+					_ = stream.Send(&remote.SocketDataResponse{
+						RequestId:   reqID.String(),
+						ShouldClose: true,
+					})
+					continue
+				}
+			} else if !ok {
+				if !message.ShouldClose {
+					stderrlog.Errorf("%s; session not found in openRequests", reqID)
+				}
 				continue
 			}
 
 			data := message.GetData()
+			datagrams := message.GetDatagrams()
 			br := len(data)
 
-			stderrlog.Debugf("received %d bytes from client", len(data))
+			stderrlog.Debugf("received %d bytes and %d datagrams from client", br, len(datagrams))
 
 			// send data if we received any
-			if br > 0 && session.Open {
-				stderrlog.Debugf("writing %d bytes to conn", br)
-				_, err := session.Conn.Write(data)
+			if (br > 0 || len(datagrams) > 0) && session.Open {
+				written, err := session.WriteConn(data, datagrams)
 				if err != nil {
 					stderrlog.Errorf("%s; failed writing data to socket", reqID)
 					message.ShouldClose = true
 				} else {
-					stderrlog.Debugf("wrote %d bytes to conn", br)
+					stderrlog.Debugf("wrote %d bytes to conn", written)
 				}
 			}
 
@@ -141,8 +154,13 @@ func ReceiveData(stream remote.Tunnel_InitTunnelServer, closeChan chan struct{})
 }
 
 func readConn(ctx context.Context, session *Session, sessions chan<- *Session) {
+	bufferSize := BufferSize
+	if session.Packet {
+		bufferSize = DatagramBufferSize
+	}
+
 	for {
-		buff := make([]byte, BufferSize)
+		buff := make([]byte, bufferSize)
 		br, err := session.Conn.Read(buff)
 
 		select {
@@ -164,7 +182,7 @@ func readConn(ctx context.Context, session *Session, sessions chan<- *Session) {
 
 			// write the data to the session buffer, if we have data
 			if br > 0 {
-				session.Buf.Write(buff[0:br])
+				session.Add(buff[0:br])
 			}
 			session.Unlock()
 
@@ -205,6 +223,10 @@ func (t *tunnelServer) InitTunnel(stream remote.Tunnel_InitTunnelServer) error {
 		return errors.New("missing port")
 	}
 
+	if request.GetScheme() == remote.TunnelScheme_UDP {
+		return servePackets(stream, port)
+	}
+
 	ln, err := net.Listen(strings.ToLower(request.GetScheme().String()), fmt.Sprintf(":%d", port))
 	if err != nil {
 		_ = stream.Send(&remote.SocketDataResponse{
@@ -225,7 +247,7 @@ func (t *tunnelServer) InitTunnel(stream remote.Tunnel_InitTunnelServer) error {
 		os.Exit(1)
 	}(closeChan)
 
-	go ReceiveData(stream, closeChan)
+	go ReceiveData(stream, closeChan, nil)
 	go SendData(stream, sessions, closeChan)
 
 	for {
@@ -245,3 +267,109 @@ func (t *tunnelServer) InitTunnel(stream remote.Tunnel_InitTunnelServer) error {
 		go readConn(stream.Context(), session, sessions)
 	}
 }
+
+// servePackets listens for udp datagrams on the given port and tracks a session
+// for every peer that sends datagrams
+func servePackets(stream remote.Tunnel_InitTunnelServer, port int32) error {
+	conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		_ = stream.Send(&remote.SocketDataResponse{
+			HasErr: true,
+			LogMessage: &remote.LogMessage{
+				LogLevel: remote.LogLevel_ERROR,
+				Message:  fmt.Sprintf("failed opening listener type %s on port %d: %v", remote.TunnelScheme_UDP, port, err),
+			},
+		})
+		return fmt.Errorf("failed listening on port %d: %v", port, err)
+	}
+
+	sessions := make(chan *Session)
+	closeChan := make(chan struct{})
+	go func(close chan struct{}) {
+		<-close
+		_ = conn.Close()
+		os.Exit(1)
+	}(closeChan)
+
+	go ReceiveData(stream, closeChan, nil)
+	go SendData(stream, sessions, closeChan)
+
+	peers := NewPeerSessions(conn)
+	go peers.CloseIdle(stream.Context(), IdleTimeout, sessions)
+
+	buff := make([]byte, DatagramBufferSize)
+	for {
+		br, peer, err := conn.ReadFrom(buff)
+		if err != nil {
+			return err
+		}
+
+		session, created, err := peers.Get(peer)
+		if err != nil {
+			stderrlog.Errorf("create new session: %v", err)
+			continue
+		} else if created {
+			stderrlog.Debugf("new udp peer %s on ::%d", peer.String(), port)
+		}
+
+		session.Lock()
+		session.Add(buff[0:br])
+		session.Unlock()
+		sessions <- session
+	}
+}
+
+// InitForward opens a connection to the given port within the container for every
+// session the client starts
+func (t *tunnelServer) InitForward(stream remote.Tunnel_InitForwardServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("failed receiving initial connection from tunnel")
+	}
+	port := request.GetPort()
+	if port == 0 && request.GetAddress() == "" {
+		err := stream.Send(&remote.SocketDataResponse{
+			HasErr: true,
+			LogMessage: &remote.LogMessage{
+				LogLevel: remote.LogLevel_ERROR,
+				Message:  "missing port",
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return errors.New("missing port")
+	}
+
+	// dial either the given address, e.g. a unix socket, or the port on localhost
+	network, address := strings.ToLower(request.GetScheme().String()), fmt.Sprintf("localhost:%d", port)
+	if request.GetAddress() != "" {
+		network, address = SplitAddress(network, request.GetAddress())
+	}
+
+	sessions := make(chan *Session)
+	closeChan := make(chan struct{})
+	go ReceiveData(stream, closeChan, func(id uuid.UUID) (*Session, error) {
+		conn, err := net.Dial(network, address)
+		if err != nil {
+			return nil, err
+		}
+
+		session, err := NewSessionFromStream(id, conn)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+
+		stderrlog.Debugf("opened new %s connection to %s", network, address)
+		go readConn(stream.Context(), session, sessions)
+		return session, nil
+	})
+	go SendData(stream, sessions, closeChan)
+
+	select {
+	case <-closeChan:
+	case <-stream.Context().Done():
+	}
+	return nil
+}
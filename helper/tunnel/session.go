@@ -13,6 +13,12 @@ import (
 
 const (
 	BufferSize = 1024 * 10
+
+	// DatagramBufferSize is the maximum size of a single udp datagram
+	DatagramBufferSize = 64 * 1024
+
+	// IdleTimeout is the time after which udp sessions without any traffic are closed
+	IdleTimeout = 2 * time.Minute
 )
 
 var openSessions = sync.Map{}
@@ -24,6 +30,14 @@ type Session struct {
 	Context    context.Context
 	cancelFunc context.CancelFunc
 	Open       bool
+
+	// Datagrams holds the received datagrams of an udp session that were not
+	// sent yet. In contrast to Buf the boundaries of the datagrams are kept.
+	Datagrams [][]byte
+	// Packet is true if the session transfers udp datagrams
+	Packet bool
+
+	lastActivity time.Time
 	sync.Mutex
 }
 
@@ -39,37 +53,84 @@ func (s *Session) Close() {
 	}()
 }
 
+// Add adds data that was read from the connection to the session. For udp sessions
+// data is a single datagram. The caller needs to hold the session lock.
+func (s *Session) Add(data []byte) {
+	s.lastActivity = time.Now()
+	if s.Packet {
+		datagram := make([]byte, len(data))
+		copy(datagram, data)
+		s.Datagrams = append(s.Datagrams, datagram)
+		return
+	}
+
+	s.Buf.Write(data)
+}
+
+// Take returns and removes all data that was added to the session. The caller needs
+// to hold the session lock.
+func (s *Session) Take() ([]byte, [][]byte) {
+	data := make([]byte, s.Buf.Len())
+	_, _ = s.Buf.Read(data)
+
+	datagrams := s.Datagrams
+	s.Datagrams = nil
+	return data, datagrams
+}
+
+// WriteConn writes data and datagrams received from the tunnel to the connection
+func (s *Session) WriteConn(data []byte, datagrams [][]byte) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.lastActivity = time.Now()
+	written := 0
+	if len(data) > 0 {
+		n, err := s.Conn.Write(data)
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	for _, datagram := range datagrams {
+		n, err := s.Conn.Write(datagram)
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// Idle returns true if the session had no traffic for longer than timeout
+func (s *Session) Idle(timeout time.Duration) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	return time.Since(s.lastActivity) > timeout
+}
+
 type RedirectRequest struct {
 	Source int32
 	Target int32
 }
 
 func NewSession(conn net.Conn) (*Session, error) {
-	ctx, cancel := context.WithCancel(context.Background())
-	r := &Session{
-		ID:         uuid.New(),
-		Conn:       conn,
-		Context:    ctx,
-		cancelFunc: cancel,
-		Buf:        bytes.Buffer{},
-		Open:       true,
-	}
-	err := addSession(r)
-	if err != nil {
-		return nil, err
-	}
-	return r, nil
+	return NewSessionFromStream(uuid.New(), conn)
 }
 
 func NewSessionFromStream(id uuid.UUID, conn net.Conn) (*Session, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	r := &Session{
-		ID:         id,
-		Conn:       conn,
-		Context:    ctx,
-		cancelFunc: cancel,
-		Buf:        bytes.Buffer{},
-		Open:       true,
+		ID:           id,
+		Conn:         conn,
+		Context:      ctx,
+		cancelFunc:   cancel,
+		Buf:          bytes.Buffer{},
+		Open:         true,
+		Packet:       isPacketConn(conn),
+		lastActivity: time.Now(),
 	}
 	err := addSession(r)
 	if err != nil {
@@ -93,3 +154,123 @@ func GetSession(id uuid.UUID) (*Session, bool) {
 	}
 	return nil, ok
 }
+
+func isPacketConn(conn net.Conn) bool {
+	switch conn.(type) {
+	case *net.UDPConn, *peerConn:
+		return true
+	}
+
+	return false
+}
+
+// PeerSessions tracks the sessions of an udp listener by the address of the remote
+// peer, because udp has no connections that could be accepted
+type PeerSessions struct {
+	m        sync.Mutex
+	conn     net.PacketConn
+	sessions map[string]*Session
+}
+
+// NewPeerSessions creates a new session tracker for the given udp listener
+func NewPeerSessions(conn net.PacketConn) *PeerSessions {
+	return &PeerSessions{
+		conn:     conn,
+		sessions: map[string]*Session{},
+	}
+}
+
+// Get returns the open session of the peer or creates a new one. created is
+// true if a new session was created.
+func (p *PeerSessions) Get(peer net.Addr) (session *Session, created bool, err error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	session, ok := p.sessions[peer.String()]
+	if ok {
+		session.Lock()
+		open := session.Open
+		session.Unlock()
+		if open {
+			return session, false, nil
+		}
+	}
+
+	session, err = NewSession(&peerConn{PacketConn: p.conn, peer: peer})
+	if err != nil {
+		return nil, false, err
+	}
+
+	p.sessions[peer.String()] = session
+	return session, true, nil
+}
+
+// RemoveIdle removes and returns all sessions that had no traffic for longer
+// than timeout or that were closed already
+func (p *PeerSessions) RemoveIdle(timeout time.Duration) []*Session {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	idle := []*Session{}
+	for peer, session := range p.sessions {
+		session.Lock()
+		open := session.Open
+		session.Unlock()
+		if !open {
+			delete(p.sessions, peer)
+		} else if session.Idle(timeout) {
+			delete(p.sessions, peer)
+			idle = append(idle, session)
+		}
+	}
+
+	return idle
+}
+
+// CloseIdle periodically closes all sessions that had no traffic for longer than timeout.
+// Closed sessions are sent to sessions, so that the other side of the tunnel is notified.
+func (p *PeerSessions) CloseIdle(ctx context.Context, timeout time.Duration, sessions chan<- *Session) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(timeout / 4):
+			for _, session := range p.RemoveIdle(timeout) {
+				session.Lock()
+				session.Open = false
+				session.Unlock()
+
+				select {
+				case sessions <- session:
+				case <-ctx.Done():
+					return
+				}
+				session.Close()
+			}
+		}
+	}
+}
+
+// peerConn is the connection to a single peer of an udp listener. The listener is
+// shared by all peers, so closing the connection does not close the listener and
+// datagrams are read by the listener itself.
+type peerConn struct {
+	net.PacketConn
+	peer net.Addr
+}
+
+func (p *peerConn) Read(b []byte) (int, error) {
+	return 0, fmt.Errorf("reading from a peer connection is not supported")
+}
+
+func (p *peerConn) Write(b []byte) (int, error) {
+	return p.WriteTo(b, p.peer)
+}
+
+func (p *peerConn) RemoteAddr() net.Addr {
+	return p.peer
+}
+
+func (p *peerConn) Close() error {
+	return nil
+}
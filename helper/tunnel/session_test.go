@@ -0,0 +1,97 @@
+package tunnel
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestPeerSessions(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	peers := NewPeerSessions(listener)
+	clients := []net.Conn{}
+	for i := 0; i < 2; i++ {
+		client, err := net.Dial("udp", listener.LocalAddr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+		clients = append(clients, client)
+	}
+
+	// every peer gets its own session and datagrams keep their boundaries
+	for i, client := range clients {
+		for _, msg := range []string{"hello", "world"} {
+			_, err = client.Write([]byte(msg))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		buff := make([]byte, DatagramBufferSize)
+		var session *Session
+		for j := 0; j < 2; j++ {
+			br, peer, err := listener.ReadFrom(buff)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			next, created, err := peers.Get(peer)
+			if err != nil {
+				t.Fatal(err)
+			} else if created != (j == 0) {
+				t.Fatalf("client %d: expected session to be created only for the first datagram", i)
+			} else if session != nil && next != session {
+				t.Fatalf("client %d: expected the same session for the same peer", i)
+			}
+
+			session = next
+			session.Lock()
+			session.Add(buff[0:br])
+			session.Unlock()
+		}
+
+		if !session.Packet {
+			t.Fatalf("client %d: expected a packet session", i)
+		}
+
+		session.Lock()
+		data, datagrams := session.Take()
+		session.Unlock()
+		if len(data) != 0 || len(datagrams) != 2 || string(datagrams[0]) != "hello" || string(datagrams[1]) != "world" {
+			t.Fatalf("client %d: unexpected datagrams %q and data %q", i, datagrams, data)
+		}
+
+		// replies are sent to the correct peer
+		_, err = session.WriteConn(nil, [][]byte{[]byte("reply")})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_ = client.SetReadDeadline(time.Now().Add(5 * time.Second))
+		br, err := client.Read(buff)
+		if err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(buff[0:br], []byte("reply")) {
+			t.Fatalf("client %d: unexpected reply %q", i, buff[0:br])
+		}
+	}
+
+	if len(peers.RemoveIdle(time.Hour)) != 0 {
+		t.Fatal("expected no idle sessions")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if idle := peers.RemoveIdle(time.Millisecond); len(idle) != 2 {
+		t.Fatalf("expected 2 idle sessions, got %d", len(idle))
+	}
+	if len(peers.sessions) != 0 {
+		t.Fatal("expected idle sessions to be removed")
+	}
+}
@@ -0,0 +1,265 @@
+package rsync
+
+import (
+	"bufio"
+	"crypto/md5"
+	"io"
+	"math"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MinBlockSize is the smallest block size used for file signatures
+	MinBlockSize = 2 * 1024
+
+	// MaxBlockSize is the biggest block size used for file signatures
+	MaxBlockSize = 128 * 1024
+
+	// MaxLiteralSize is the maximum amount of literal data within a single operation
+	MaxLiteralSize = 64 * 1024
+
+	modulus = 1 << 16
+)
+
+// BlockSize returns the block size that should be used for a file of the given size. Similar
+// to rsync the block size grows with the square root of the file size.
+func BlockSize(fileSize int64) int64 {
+	blockSize := int64(math.Sqrt(float64(fileSize)))
+	blockSize = (blockSize + 7) &^ 7
+	if blockSize < MinBlockSize {
+		return MinBlockSize
+	} else if blockSize > MaxBlockSize {
+		return MaxBlockSize
+	}
+
+	return blockSize
+}
+
+// Signature calculates the block signatures of the given reader
+func Signature(reader io.Reader, blockSize int64) (*remote.FileSignature, error) {
+	if blockSize <= 0 {
+		return nil, errors.Errorf("invalid block size %d", blockSize)
+	}
+
+	signature := &remote.FileSignature{
+		BlockSize: blockSize,
+	}
+
+	buf := make([]byte, blockSize)
+	for {
+		n, err := io.ReadFull(reader, buf)
+		if n > 0 {
+			strong := md5.Sum(buf[:n])
+			signature.Blocks = append(signature.Blocks, &remote.BlockSignature{
+				Weak:   newRollingChecksum(buf[:n]).Sum(),
+				Strong: strong[:],
+			})
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return signature, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delta calculates the operations needed to transform the file described by signature
+// into the contents of reader. Consecutive block matches are merged into a single
+// operation and literal data is split into chunks of at most MaxLiteralSize.
+func Delta(reader io.Reader, signature *remote.FileSignature, emit func(operation *remote.DeltaOperation) error) error {
+	if signature == nil || signature.BlockSize <= 0 {
+		return errors.New("invalid file signature")
+	}
+
+	// index the blocks by their weak checksum
+	blocks := map[uint32][]int64{}
+	for idx, block := range signature.Blocks {
+		blocks[block.Weak] = append(blocks[block.Weak], int64(idx))
+	}
+
+	var (
+		blockSize = int(signature.BlockSize)
+		br        = bufio.NewReaderSize(reader, 64*1024)
+		window    = make([]byte, 0, blockSize)
+		literal   = make([]byte, 0, MaxLiteralSize)
+		match     *remote.DeltaOperation
+		eof       = false
+	)
+
+	flushLiteral := func() error {
+		if len(literal) == 0 {
+			return nil
+		}
+
+		data := make([]byte, len(literal))
+		copy(data, literal)
+		literal = literal[:0]
+		return emit(&remote.DeltaOperation{Data: data})
+	}
+	flushMatch := func() error {
+		if match == nil {
+			return nil
+		}
+
+		operation := match
+		match = nil
+		return emit(operation)
+	}
+	fill := func() error {
+		for !eof && len(window) < blockSize {
+			b, err := br.ReadByte()
+			if err == io.EOF {
+				eof = true
+				break
+			} else if err != nil {
+				return err
+			}
+
+			window = append(window, b)
+		}
+
+		return nil
+	}
+
+	err := fill()
+	if err != nil {
+		return err
+	}
+
+	rolling := newRollingChecksum(window)
+	for len(window) > 0 {
+		if candidates, ok := blocks[rolling.Sum()]; ok {
+			if idx := findBlock(signature, candidates, window); idx >= 0 {
+				err = flushLiteral()
+				if err != nil {
+					return err
+				}
+
+				if match != nil && match.BlockIndex+match.BlockCount == idx {
+					match.BlockCount++
+				} else {
+					err = flushMatch()
+					if err != nil {
+						return err
+					}
+
+					match = &remote.DeltaOperation{BlockIndex: idx, BlockCount: 1}
+				}
+
+				window = window[:0]
+				err = fill()
+				if err != nil {
+					return err
+				}
+
+				rolling = newRollingChecksum(window)
+				continue
+			}
+		}
+
+		// no match, so move the first byte of the window into the literal data
+		err = flushMatch()
+		if err != nil {
+			return err
+		}
+
+		out := window[0]
+		literal = append(literal, out)
+		if len(literal) >= MaxLiteralSize {
+			err = flushLiteral()
+			if err != nil {
+				return err
+			}
+		}
+
+		if eof {
+			window = window[1:]
+			rolling.Remove(out)
+			continue
+		}
+
+		in, err := br.ReadByte()
+		if err == io.EOF {
+			eof = true
+			window = window[1:]
+			rolling.Remove(out)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		window = append(window[1:], in)
+		rolling.Roll(out, in)
+	}
+
+	err = flushMatch()
+	if err != nil {
+		return err
+	}
+
+	return flushLiteral()
+}
+
+// Apply writes the result of the given operation to writer, where base is the original
+// file the signature was calculated from.
+func Apply(base io.ReaderAt, blockSize int64, operation *remote.DeltaOperation, writer io.Writer) error {
+	if operation.BlockCount == 0 {
+		_, err := writer.Write(operation.Data)
+		return err
+	} else if base == nil {
+		return errors.New("block reference without base file")
+	}
+
+	_, err := io.Copy(writer, io.NewSectionReader(base, operation.BlockIndex*blockSize, operation.BlockCount*blockSize))
+	return err
+}
+
+func findBlock(signature *remote.FileSignature, candidates []int64, window []byte) int64 {
+	strong := md5.Sum(window)
+	for _, idx := range candidates {
+		if string(signature.Blocks[idx].Strong) == string(strong[:]) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// rollingChecksum is the weak rsync checksum that can be rolled over a byte stream
+type rollingChecksum struct {
+	a, b   uint32
+	length uint32
+}
+
+func newRollingChecksum(data []byte) *rollingChecksum {
+	r := &rollingChecksum{length: uint32(len(data))}
+	for i, c := range data {
+		r.a += uint32(c)
+		r.b += uint32(len(data)-i) * uint32(c)
+	}
+
+	r.a %= modulus
+	r.b %= modulus
+	return r
+}
+
+// Sum returns the current checksum
+func (r *rollingChecksum) Sum() uint32 {
+	return r.a | r.b<<16
+}
+
+// Roll moves the window by one byte
+func (r *rollingChecksum) Roll(out, in byte) {
+	r.a = (r.a - uint32(out) + uint32(in)) % modulus
+	r.b = (r.b - r.length*uint32(out) + r.a) % modulus
+}
+
+// Remove removes the first byte from the window
+func (r *rollingChecksum) Remove(out byte) {
+	r.a = (r.a - uint32(out)) % modulus
+	r.b = (r.b - r.length*uint32(out)) % modulus
+	r.length--
+}
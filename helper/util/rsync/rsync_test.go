@@ -0,0 +1,163 @@
+package rsync
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"gotest.tools/assert"
+)
+
+const testBlockSize = 16
+
+type deltaTestCase struct {
+	name string
+
+	base    []byte
+	changed []byte
+
+	expectedLiteral int
+	expectedBlocks  int64
+}
+
+func TestDelta(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	base := make([]byte, 8*testBlockSize)
+	_, _ = random.Read(base)
+	inserted := make([]byte, testBlockSize)
+	_, _ = random.Read(inserted)
+
+	join := func(parts ...[]byte) []byte {
+		return bytes.Join(parts, nil)
+	}
+
+	testCases := []deltaTestCase{
+		{
+			name:           "unchanged file",
+			base:           base,
+			changed:        base,
+			expectedBlocks: 8,
+		},
+		{
+			name:            "inserted block",
+			base:            base,
+			changed:         join(base[:4*testBlockSize], inserted, base[4*testBlockSize:]),
+			expectedLiteral: testBlockSize,
+			expectedBlocks:  8,
+		},
+		{
+			name:           "removed block",
+			base:           base,
+			changed:        join(base[:2*testBlockSize], base[3*testBlockSize:]),
+			expectedBlocks: 7,
+		},
+		{
+			name:            "shifted blocks",
+			base:            base,
+			changed:         join([]byte("abc"), base),
+			expectedLiteral: 3,
+			expectedBlocks:  8,
+		},
+		{
+			name:            "changed byte",
+			base:            base,
+			changed:         join(base[:testBlockSize+5], []byte{base[testBlockSize+5] + 1}, base[testBlockSize+6:]),
+			expectedLiteral: testBlockSize,
+			expectedBlocks:  7,
+		},
+		{
+			name:            "appended data",
+			base:            base,
+			changed:         join(base, []byte("appended")),
+			expectedLiteral: len("appended"),
+			expectedBlocks:  8,
+		},
+		{
+			name:    "empty changed file",
+			base:    base,
+			changed: []byte{},
+		},
+		{
+			name:            "empty base file",
+			base:            []byte{},
+			changed:         base,
+			expectedLiteral: len(base),
+		},
+		{
+			name:           "unchanged file smaller than one block",
+			base:           base[:10],
+			changed:        base[:10],
+			expectedBlocks: 1,
+		},
+		{
+			name:            "changed file smaller than one block",
+			base:            base[:10],
+			changed:         base[1:11],
+			expectedLiteral: 10,
+		},
+	}
+
+	for _, testCase := range testCases {
+		signature, err := Signature(bytes.NewReader(testCase.base), testBlockSize)
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		assert.Equal(t, len(signature.Blocks), (len(testCase.base)+testBlockSize-1)/testBlockSize, "Unexpected block count in testCase %s", testCase.name)
+
+		result := &bytes.Buffer{}
+		literal := 0
+		blocks := int64(0)
+		err = Delta(bytes.NewReader(testCase.changed), signature, func(operation *remote.DeltaOperation) error {
+			literal += len(operation.Data)
+			blocks += operation.BlockCount
+			return Apply(bytes.NewReader(testCase.base), testBlockSize, operation, result)
+		})
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		assert.Assert(t, bytes.Equal(result.Bytes(), testCase.changed), "Unexpected result in testCase %s", testCase.name)
+		assert.Equal(t, literal, testCase.expectedLiteral, "Unexpected literal data in testCase %s", testCase.name)
+		assert.Equal(t, blocks, testCase.expectedBlocks, "Unexpected block matches in testCase %s", testCase.name)
+	}
+}
+
+func TestDeltaMergesBlocks(t *testing.T) {
+	base := make([]byte, 6*testBlockSize)
+	_, _ = rand.New(rand.NewSource(2)).Read(base)
+	signature, err := Signature(bytes.NewReader(base), testBlockSize)
+	assert.NilError(t, err)
+
+	// consecutive matches are sent as a single operation
+	changed := bytes.Join([][]byte{[]byte("new"), base[testBlockSize : 4*testBlockSize], base[5*testBlockSize:]}, nil)
+	operations := []string{}
+	err = Delta(bytes.NewReader(changed), signature, func(operation *remote.DeltaOperation) error {
+		if operation.BlockCount > 0 {
+			operations = append(operations, fmt.Sprintf("blocks %d-%d", operation.BlockIndex, operation.BlockIndex+operation.BlockCount-1))
+		} else {
+			operations = append(operations, "data "+string(operation.Data))
+		}
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, operations, []string{"data new", "blocks 1-3", "blocks 5-5"})
+}
+
+func TestDeltaSplitsLiteralData(t *testing.T) {
+	signature, err := Signature(bytes.NewReader(nil), MinBlockSize)
+	assert.NilError(t, err)
+
+	changed := make([]byte, 2*MaxLiteralSize+1)
+	sizes := []int{}
+	err = Delta(bytes.NewReader(changed), signature, func(operation *remote.DeltaOperation) error {
+		sizes = append(sizes, len(operation.Data))
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, sizes, []int{MaxLiteralSize, MaxLiteralSize, 1})
+}
+
+func TestBlockSize(t *testing.T) {
+	assert.Equal(t, BlockSize(0), int64(MinBlockSize))
+	assert.Equal(t, BlockSize(1024), int64(MinBlockSize))
+	assert.Equal(t, BlockSize(100*1024*1024), int64(10240))
+	assert.Equal(t, BlockSize(100*1024*1024*1024), int64(MaxBlockSize))
+}
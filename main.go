@@ -7,6 +7,7 @@ import (
 
 	"github.com/loft-sh/devspace/cmd"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
+	"github.com/loft-sh/devspace/pkg/util/log"
 )
 
 var version = ""
@@ -15,5 +16,6 @@ func main() {
 	upgrade.SetVersion(version)
 
 	cmd.Execute()
+	log.Flush()
 	os.Exit(0)
 }
@@ -9,6 +9,8 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/build/types"
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/events"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/util/randutil"
 	"github.com/loft-sh/devspace/pkg/util/stringutil"
 
@@ -208,14 +210,31 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 				return pluginErr
 			}
 			ctx.Log().Infof("Skip building image '%s'", imageConfigName)
+			events.Emit(events.BuildSkipped, &events.Build{
+				ImageConfigName: imageConfigName,
+				Image:           resolvedImage,
+				Tags:            imageTags,
+			})
 			continue
 		}
 
+		events.Emit(events.BuildStart, &events.Build{
+			ImageConfigName: imageConfigName,
+			Image:           resolvedImage,
+			Tags:            imageTags,
+		})
+
 		// Sequential or parallel build?
 		if options.Sequential {
 			// Build the image
-			err = builder.Build(ctx)
+			err = buildImage(ctx, builder, imageConfigName, imageTags)
 			if err != nil {
+				events.Emit(events.BuildFinish, &events.Build{
+					ImageConfigName: imageConfigName,
+					Image:           resolvedImage,
+					Tags:            imageTags,
+					Error:           err.Error(),
+				})
 				pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 					"IMAGE_CONFIG_NAME": imageConfigName,
 					"IMAGE_NAME":        resolvedImage,
@@ -245,6 +264,11 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 				ImageName:       imageName,
 				ImageTag:        imageTags[0],
 			}
+			events.Emit(events.BuildFinish, &events.Build{
+				ImageConfigName: imageConfigName,
+				Image:           resolvedImage,
+				Tags:            imageTags,
+			})
 
 			// Execute before images build hook
 			pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
@@ -270,8 +294,14 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 			imagesToBuild++
 			go func(ctx devspacecontext.Context) {
 				// Build the image
-				err := builder.Build(ctx)
+				err := buildImage(ctx, builder, imageConfigName, imageTags)
 				if err != nil {
+					events.Emit(events.BuildFinish, &events.Build{
+						ImageConfigName: imageConfigName,
+						Image:           resolvedImage,
+						Tags:            imageTags,
+						Error:           err.Error(),
+					})
 					hook.LogExecuteHooks(ctx, map[string]interface{}{
 						"IMAGE_CONFIG_NAME": imageConfigName,
 						"IMAGE_NAME":        resolvedImage,
@@ -340,6 +370,17 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 	return nil
 }
 
+// buildImage builds a single image within its own tracing span
+func buildImage(ctx devspacecontext.Context, imageBuilder builder.Interface, imageConfigName string, imageTags []string) error {
+	spanCtx, span := tracing.Start(ctx.Context(), tracing.CategoryBuild, "build "+imageConfigName)
+	defer span.End()
+	span.SetAttribute("tags", strings.Join(imageTags, ","))
+
+	err := imageBuilder.Build(ctx.WithContext(spanCtx))
+	span.SetError(err)
+	return err
+}
+
 func (c *controller) waitForBuild(ctx devspacecontext.Context, errChan <-chan error, cacheChan <-chan imageNameAndTag, builtImages map[string]types.ImageNameTag) error {
 	select {
 	case err := <-errChan:
@@ -366,6 +407,11 @@ func (c *controller) waitForBuild(ctx devspacecontext.Context, errChan <-chan er
 			ImageName:       done.imageName,
 			ImageTag:        done.imageTag,
 		}
+		events.Emit(events.BuildFinish, &events.Build{
+			ImageConfigName: done.imageConfigName,
+			Image:           resolvedImage,
+			Tags:            done.imageTags,
+		})
 
 		// Execute plugin hook
 		pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
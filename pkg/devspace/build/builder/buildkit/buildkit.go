@@ -121,10 +121,104 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfil
 
 	// Should we build with cli?
 	skipPush := b.skipPush || b.helper.ImageConf.SkipPush
-	return buildWithCLI(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), body, writer, ctx.KubeClient(), builder, buildKitConfig, *buildOptions, useMinikubeDocker, skipPush, ctx.Log())
+
+	extraArgs := []string{}
+	metadataFile := ""
+
+	// BuildKit builds all platforms at once and pushes them as image index
+	if len(b.helper.ImageConf.Platforms) > 0 {
+		extraArgs = append(extraArgs, "--platform", strings.Join(b.helper.ImageConf.Platforms, ","))
+		if skipPush && len(b.helper.ImageConf.Platforms) > 1 {
+			ctx.Log().Warnf("Image %s is built for multiple platforms but not pushed, a docker daemon might not be able to load it", b.helper.ImageName)
+		}
+	}
+
+	// attestations can only be attached to pushed images, otherwise
+	// the build helper creates the provenance locally
+	if b.helper.ImageConf.Attestations != nil && !skipPush {
+		tempFile, err := os.CreateTemp("", "devspace-buildx-metadata-*.json")
+		if err != nil {
+			return err
+		}
+		_ = tempFile.Close()
+		metadataFile = tempFile.Name()
+		defer os.Remove(metadataFile)
+
+		extraArgs = append(extraArgs, attestationArgs(b.helper.ImageConf.Attestations)...)
+		extraArgs = append(extraArgs, "--metadata-file", metadataFile)
+	}
+
+	err = buildWithCLI(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), body, writer, ctx.KubeClient(), builder, buildKitConfig, *buildOptions, extraArgs, useMinikubeDocker, skipPush, ctx.Log())
+	if err != nil {
+		return err
+	}
+
+	b.helper.Pushed = !skipPush && len(buildOptions.Tags) > 0
+	if metadataFile == "" {
+		return nil
+	}
+
+	digest, err := readMetadataDigest(metadataFile)
+	if err != nil {
+		ctx.Log().Warnf("Error reading digest of image %s: %v", b.helper.ImageName, err)
+		return nil
+	}
+
+	// the attestations are attached to the pushed image index as separate manifests. If they
+	// cannot be found, the build helper writes the provenance locally instead
+	b.helper.ImageDigest = digest
+	attestationDigest, err := helper.AttestationManifestDigest(ctx.Context(), b.helper.ImageName, digest)
+	if err != nil {
+		ctx.Log().Warnf("Error reading attestations of image %s: %v", b.helper.ImageName, err)
+		return nil
+	}
+
+	b.helper.AttestationDigest = attestationDigest
+	return nil
+}
+
+// attestationArgs returns the docker buildx flags for the attestations
+func attestationArgs(attestations *latest.Attestations) []string {
+	args := []string{}
+	if attestations.SBOM {
+		args = append(args, "--sbom=true")
+	}
+	if attestations.Provenance {
+		mode := latest.ProvenanceModeMin
+		if attestations.ProvenanceMode != "" {
+			mode = attestations.ProvenanceMode
+		}
+
+		args = append(args, "--provenance=mode="+string(mode))
+	} else {
+		args = append(args, "--provenance=false")
+	}
+
+	return args
+}
+
+// readMetadataDigest returns the image digest from the metadata file written by docker buildx
+func readMetadataDigest(metadataFile string) (string, error) {
+	out, err := os.ReadFile(metadataFile)
+	if err != nil {
+		return "", err
+	}
+
+	metadata := map[string]interface{}{}
+	err = json.Unmarshal(out, &metadata)
+	if err != nil {
+		return "", errors.Wrap(err, "parse buildx metadata")
+	}
+
+	digest, _ := metadata["containerimage.digest"].(string)
+	if digest == "" {
+		return "", errors.New("buildx metadata does not contain an image digest")
+	}
+
+	return digest, nil
 }
 
-func buildWithCLI(ctx context.Context, dir string, environ expand.Environ, context io.Reader, writer io.Writer, kubeClient kubectl.Client, builder string, imageConf *latest.BuildKitConfig, options types.ImageBuildOptions, useMinikubeDocker, skipPush bool, log logpkg.Logger) error {
+func buildWithCLI(ctx context.Context, dir string, environ expand.Environ, context io.Reader, writer io.Writer, kubeClient kubectl.Client, builder string, imageConf *latest.BuildKitConfig, options types.ImageBuildOptions, extraArgs []string, useMinikubeDocker, skipPush bool, log logpkg.Logger) error {
 	command := []string{"docker", "buildx"}
 	if len(imageConf.Command) > 0 {
 		command = imageConf.Command
@@ -177,6 +271,7 @@ func buildWithCLI(ctx context.Context, dir string, environ expand.Environ, conte
 		// is created in parallel.
 		time.Sleep(time.Millisecond * time.Duration(rand.Intn(3000)+500))
 	}
+	args = append(args, extraArgs...)
 	args = append(args, imageConf.Args...)
 
 	args = append(args, "-")
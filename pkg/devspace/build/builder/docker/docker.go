@@ -152,10 +152,13 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfil
 	// Check if we skip push
 	if !b.skipPush && !b.helper.ImageConf.SkipPush {
 		for _, tag := range buildOptions.Tags {
-			err = b.pushImage(ctx.Context(), writer, tag)
+			digest, err := b.pushImage(ctx.Context(), writer, tag)
 			if err != nil {
 				return errors.Errorf("error during image push: %v", err)
+			} else if digest != "" {
+				b.helper.ImageDigest = digest
 			}
+			b.helper.Pushed = true
 
 			ctx.Log().Info("Image pushed to registry (" + displayRegistryURL + ")")
 		}
@@ -193,32 +196,43 @@ func (b *Builder) Authenticate(ctx context.Context) (*types.AuthConfig, error) {
 	return b.authConfig, nil
 }
 
-// pushImage pushes an image to the specified registry
-func (b *Builder) pushImage(ctx context.Context, writer io.Writer, imageName string) error {
+// pushImage pushes an image to the specified registry and returns the digest of the pushed image
+func (b *Builder) pushImage(ctx context.Context, writer io.Writer, imageName string) (string, error) {
 	ref, err := reference.ParseNormalizedNamed(imageName)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	encodedAuth, err := encodeAuthToBase64(*b.authConfig)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	out, err := b.client.ImagePush(ctx, reference.FamiliarString(ref), types.ImagePushOptions{
 		RegistryAuth: encodedAuth,
 	})
 	if err != nil {
-		return err
+		return "", err
 	}
 
+	// the digest of the pushed image is reported as aux message
+	digest := ""
 	outStream := streams.NewOut(writer)
-	err = jsonmessage.DisplayJSONMessagesStream(out, outStream, outStream.FD(), outStream.IsTerminal(), nil)
+	err = jsonmessage.DisplayJSONMessagesStream(out, outStream, outStream.FD(), outStream.IsTerminal(), func(message jsonmessage.JSONMessage) {
+		if message.Aux == nil {
+			return
+		}
+
+		result := &types.PushResult{}
+		if json.Unmarshal(*message.Aux, result) == nil && result.Digest != "" {
+			digest = result.Digest
+		}
+	})
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	return digest, nil
 }
 
 func encodeAuthToBase64(authConfig types.AuthConfig) (string, error) {
@@ -0,0 +1,165 @@
+package helper
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/pkg/errors"
+)
+
+const (
+	// InTotoStatementType is the type of the generated in-toto statement
+	InTotoStatementType = "https://in-toto.io/Statement/v0.1"
+	// SLSAProvenancePredicateType is the predicate type of the generated provenance
+	SLSAProvenancePredicateType = "https://slsa.dev/provenance/v0.2"
+	// BuildType identifies builds made by DevSpace in the provenance
+	BuildType = "https://devspace.sh/build/v1"
+)
+
+// AttestationsFolder is the folder within the cache folder where local attestations are stored
+var AttestationsFolder = filepath.Join(constants.DefaultCacheFolder, "attestations")
+
+// Statement is an in-toto statement with a SLSA provenance predicate
+type Statement struct {
+	Type          string     `json:"_type"`
+	PredicateType string     `json:"predicateType"`
+	Subject       []Subject  `json:"subject"`
+	Predicate     Provenance `json:"predicate"`
+}
+
+// Subject is an artifact the statement is about
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// Provenance is the SLSA provenance predicate
+type Provenance struct {
+	Builder    ProvenanceBuilder    `json:"builder"`
+	BuildType  string               `json:"buildType"`
+	Invocation ProvenanceInvocation `json:"invocation"`
+	Metadata   ProvenanceMetadata   `json:"metadata"`
+	Materials  []Subject            `json:"materials,omitempty"`
+}
+
+// ProvenanceBuilder identifies the build engine
+type ProvenanceBuilder struct {
+	ID string `json:"id"`
+}
+
+// ProvenanceInvocation holds the parameters of the build
+type ProvenanceInvocation struct {
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// ProvenanceMetadata holds the timing and completeness of the build
+type ProvenanceMetadata struct {
+	BuildStartedOn  *time.Time `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn *time.Time `json:"buildFinishedOn,omitempty"`
+	Reproducible    bool       `json:"reproducible"`
+}
+
+// NewProvenanceStatement creates an in-toto provenance statement for the built image from the hashes
+// of the image cache. The image digest is optional, because not every build engine reports it.
+func (b *BuildHelper) NewProvenanceStatement(imageCache localcache.ImageCache, imageDigest string, started, finished time.Time) *Statement {
+	subjects := []Subject{}
+	for _, tag := range b.ImageTags {
+		subject := Subject{Name: b.ImageName + ":" + tag}
+		if imageDigest != "" {
+			subject.Digest = digestMap(imageDigest)
+		}
+		subjects = append(subjects, subject)
+	}
+
+	materials := []Subject{}
+	for _, material := range []struct{ name, digest string }{
+		{name: "context", digest: imageCache.ContextHash},
+		{name: "dockerfile", digest: imageCache.DockerfileHash},
+		{name: "config", digest: imageCache.ImageConfigHash},
+		{name: "entrypoint", digest: imageCache.EntrypointHash},
+	} {
+		if material.digest != "" {
+			materials = append(materials, Subject{Name: material.name, Digest: map[string]string{"sha256": material.digest}})
+		}
+	}
+
+	parameters := map[string]interface{}{
+		"dockerfile": b.DockerfilePath,
+		"context":    b.ContextPath,
+	}
+	if b.ImageConf.Target != "" {
+		parameters["target"] = b.ImageConf.Target
+	}
+	if len(b.ImageConf.BuildArgs) > 0 && b.ImageConf.Attestations.ProvenanceMode == latest.ProvenanceModeMax {
+		buildArgs := []string{}
+		for name := range b.ImageConf.BuildArgs {
+			buildArgs = append(buildArgs, name)
+		}
+		sort.Strings(buildArgs)
+		parameters["buildArgs"] = buildArgs
+	}
+
+	return &Statement{
+		Type:          InTotoStatementType,
+		PredicateType: SLSAProvenancePredicateType,
+		Subject:       subjects,
+		Predicate: Provenance{
+			Builder:    ProvenanceBuilder{ID: "https://devspace.sh/builder/" + b.EngineName},
+			BuildType:  BuildType,
+			Invocation: ProvenanceInvocation{Parameters: parameters},
+			Metadata: ProvenanceMetadata{
+				BuildStartedOn:  &started,
+				BuildFinishedOn: &finished,
+			},
+			Materials: materials,
+		},
+	}
+}
+
+// WriteProvenance writes the provenance statement of the built image to the attestations folder
+// and records its digest in the image cache
+func (b *BuildHelper) WriteProvenance(ctx devspacecontext.Context, imageDigest string, started, finished time.Time) error {
+	imageCache, _ := ctx.Config().LocalCache().GetImageCache(b.ImageConf.Name)
+	statement := b.NewProvenanceStatement(imageCache, imageDigest, started, finished)
+	out, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal provenance")
+	}
+
+	folder := filepath.Join(ctx.WorkingDir(), AttestationsFolder)
+	err = os.MkdirAll(folder, 0755)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(folder, b.ImageConf.Name+".intoto.json")
+	err = os.WriteFile(path, out, 0644)
+	if err != nil {
+		return errors.Wrap(err, "write provenance")
+	}
+
+	imageCache.ImageDigest = imageDigest
+	imageCache.AttestationDigest = "sha256:" + hash.String(string(out))
+	ctx.Config().LocalCache().SetImageCache(b.ImageConf.Name, imageCache)
+	ctx.Log().Donef("Wrote provenance of image '%s' to %s", b.ImageName, path)
+	return nil
+}
+
+// digestMap converts a digest in the form algorithm:hex into an in-toto digest set
+func digestMap(digest string) map[string]string {
+	splitted := strings.SplitN(digest, ":", 2)
+	if len(splitted) != 2 {
+		return map[string]string{"sha256": digest}
+	}
+
+	return map[string]string{splitted[0]: splitted[1]}
+}
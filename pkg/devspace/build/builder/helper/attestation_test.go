@@ -0,0 +1,75 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestWriteProvenance(t *testing.T) {
+	dir := t.TempDir()
+	cache := &localcache.LocalCache{
+		Images: map[string]localcache.ImageCache{
+			"api": {
+				ContextHash:     "context",
+				DockerfileHash:  "dockerfile",
+				ImageConfigHash: "config",
+			},
+		},
+	}
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).
+		WithWorkingDir(dir).
+		WithConfig(config.NewConfig(nil, nil, latest.NewRaw(), cache, &remotecache.RemoteCache{}, nil, ""))
+
+	helper := &BuildHelper{
+		ImageConf: &latest.Image{
+			Name:         "api",
+			Target:       "production",
+			Attestations: &latest.Attestations{Provenance: true},
+		},
+		EngineName: "kaniko",
+		ImageName:  "registry.com/api",
+		ImageTags:  []string{"v1", "latest"},
+	}
+
+	started := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := helper.WriteProvenance(ctx, "sha256:abc", started, started.Add(time.Minute))
+	assert.NilError(t, err)
+
+	out, err := os.ReadFile(filepath.Join(dir, AttestationsFolder, "api.intoto.json"))
+	assert.NilError(t, err)
+
+	statement := &Statement{}
+	err = json.Unmarshal(out, statement)
+	assert.NilError(t, err)
+	assert.Equal(t, statement.Type, InTotoStatementType)
+	assert.Equal(t, statement.PredicateType, SLSAProvenancePredicateType)
+	assert.DeepEqual(t, statement.Subject, []Subject{
+		{Name: "registry.com/api:v1", Digest: map[string]string{"sha256": "abc"}},
+		{Name: "registry.com/api:latest", Digest: map[string]string{"sha256": "abc"}},
+	})
+	assert.Equal(t, statement.Predicate.Builder.ID, "https://devspace.sh/builder/kaniko")
+	assert.Equal(t, statement.Predicate.Invocation.Parameters["target"], "production")
+	assert.DeepEqual(t, statement.Predicate.Materials, []Subject{
+		{Name: "context", Digest: map[string]string{"sha256": "context"}},
+		{Name: "dockerfile", Digest: map[string]string{"sha256": "dockerfile"}},
+		{Name: "config", Digest: map[string]string{"sha256": "config"}},
+	})
+
+	imageCache, _ := ctx.Config().LocalCache().GetImageCache("api")
+	assert.Equal(t, imageCache.ImageDigest, "sha256:abc")
+	assert.Equal(t, imageCache.AttestationDigest, "sha256:"+hash.String(string(out)))
+	assert.Equal(t, imageCache.ContextHash, "context")
+}
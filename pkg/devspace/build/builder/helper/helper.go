@@ -6,6 +6,7 @@ import (
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/streamformatter"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/restart"
+	"github.com/loft-sh/devspace/pkg/devspace/build/sign"
 	"github.com/loft-sh/devspace/pkg/util/kubeconfig"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	dockerterm "github.com/moby/term"
@@ -14,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/docker/cli/cli/command/image/build"
 	"github.com/docker/docker/api/types"
@@ -45,6 +47,13 @@ type BuildHelper struct {
 	ImageTags  []string
 	Entrypoint []string
 	Cmd        []string
+
+	// ImageDigest and AttestationDigest are set by build engines that report them
+	ImageDigest       string
+	AttestationDigest string
+
+	// Pushed is set by build engines that pushed the image to its registry
+	Pushed bool
 }
 
 // BuildHelperInterface is the interface the build helper uses to build an image
@@ -92,15 +101,72 @@ func (b *BuildHelper) Build(ctx devspacecontext.Context, imageBuilder BuildHelpe
 	ctx.Log().Infof("Building image '%s:%s' with engine '%s'", b.ImageName, b.ImageTags[0], b.EngineName)
 
 	// Build Image
+	started := time.Now()
 	err := imageBuilder.BuildImage(ctx, b.ContextPath, b.DockerfilePath, b.Entrypoint, b.Cmd)
 	if err != nil {
 		return err
 	}
 
+	err = b.recordAttestations(ctx, started, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "attestations")
+	}
+
+	err = b.signImage(ctx)
+	if err != nil {
+		return errors.Wrap(err, "sign image")
+	}
+
 	ctx.Log().Done("Done processing image '" + b.ImageName + "'")
 	return nil
 }
 
+// recordAttestations stores the digests reported by the build engine in the image cache. If the
+// engine did not attach attestations to the image, the provenance is generated locally instead.
+func (b *BuildHelper) recordAttestations(ctx devspacecontext.Context, started, finished time.Time) error {
+	imageCache, _ := ctx.Config().LocalCache().GetImageCache(b.ImageConf.Name)
+	imageCache.ImageDigest = b.ImageDigest
+	imageCache.AttestationDigest = b.AttestationDigest
+	ctx.Config().LocalCache().SetImageCache(b.ImageConf.Name, imageCache)
+
+	attestations := b.ImageConf.Attestations
+	if attestations == nil || b.AttestationDigest != "" {
+		return nil
+	}
+
+	if attestations.SBOM {
+		ctx.Log().Warnf("Skip SBOM of image '%s', because it can only be created by BuildKit when pushing the image", b.ImageName)
+	}
+	if !attestations.Provenance {
+		return nil
+	}
+
+	return b.WriteProvenance(ctx, b.ImageDigest, started, finished)
+}
+
+// signImage signs the pushed image with the configured key
+func (b *BuildHelper) signImage(ctx devspacecontext.Context) error {
+	if b.ImageConf.Sign == nil {
+		return nil
+	} else if !b.Pushed {
+		ctx.Log().Infof("Skip signing image '%s', because it was not pushed", b.ImageName)
+		return nil
+	}
+
+	image := b.ImageName + ":" + b.ImageTags[0]
+	if b.ImageDigest != "" {
+		image = b.ImageName + "@" + b.ImageDigest
+	}
+
+	ref, err := sign.SignImage(ctx.Context(), ctx.WorkingDir(), b.ImageConf.Sign, image)
+	if err != nil {
+		return err
+	}
+
+	ctx.Log().Donef("Signed image %s", ref.String())
+	return nil
+}
+
 // ShouldRebuild determines if the image should be rebuilt
 func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bool) (bool, error) {
 	imageCache, _ := ctx.Config().LocalCache().GetImageCache(b.ImageConf.Name)
@@ -146,9 +212,12 @@ func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bo
 	}
 
 	// only rebuild Docker image when Dockerfile or context has changed since latest build
-	mustRebuild := imageCache.Tag == "" || imageCache.DockerfileHash != dockerfileHash || imageCache.ImageConfigHash != imageConfigHash || imageCache.EntrypointHash != entrypointHash
+	platformsChanged := strings.Join(imageCache.Platforms, ",") != strings.Join(b.ImageConf.Platforms, ",")
+	mustRebuild := imageCache.Tag == "" || platformsChanged || imageCache.DockerfileHash != dockerfileHash || imageCache.ImageConfigHash != imageConfigHash || imageCache.EntrypointHash != entrypointHash
 	if imageCache.Tag == "" {
 		ctx.Log().Infof("Rebuild image %s because tag is missing", imageCache.ImageName)
+	} else if platformsChanged {
+		ctx.Log().Infof("Rebuild image %s because platforms have changed", imageCache.ImageName)
 	} else if imageCache.DockerfileHash != dockerfileHash {
 		ctx.Log().Infof("Rebuild image %s because dockerfile has changed", imageCache.ImageName)
 	} else if imageCache.ImageConfigHash != imageConfigHash {
@@ -223,6 +292,7 @@ func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bo
 		imageCache.DockerfileHash = dockerfileHash
 		imageCache.ImageConfigHash = imageConfigHash
 		imageCache.EntrypointHash = entrypointHash
+		imageCache.Platforms = b.ImageConf.Platforms
 	}
 
 	ctx.Config().LocalCache().SetImageCache(b.ImageConf.Name, imageCache)
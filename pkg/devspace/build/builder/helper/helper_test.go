@@ -2,7 +2,9 @@ package helper
 
 import (
 	"context"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config"
@@ -224,3 +226,43 @@ func hasBuildKit() bool {
 	err := cmd.Run()
 	return err == nil
 }
+
+func TestShouldRebuildPlatforms(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine"), 0666))
+
+	cache := &localcache.LocalCache{
+		Images: map[string]localcache.ImageCache{},
+	}
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).
+		WithWorkingDir(dir).
+		WithConfig(config.NewConfig(nil, nil, latest.NewRaw(), cache, &remotecache.RemoteCache{}, nil, ""))
+
+	helper := &BuildHelper{
+		ImageConf: &latest.Image{
+			Name:      "api",
+			Platforms: []string{"linux/amd64", "linux/arm64"},
+		},
+		DockerfilePath: filepath.Join(dir, "Dockerfile"),
+		ContextPath:    dir,
+	}
+
+	rebuild, err := helper.ShouldRebuild(ctx, false)
+	assert.NilError(t, err)
+	assert.Assert(t, rebuild)
+
+	imageCache, _ := ctx.Config().LocalCache().GetImageCache("api")
+	assert.DeepEqual(t, imageCache.Platforms, []string{"linux/amd64", "linux/arm64"})
+	imageCache.Tag = "v1"
+	ctx.Config().LocalCache().SetImageCache("api", imageCache)
+
+	rebuild, err = helper.ShouldRebuild(ctx, false)
+	assert.NilError(t, err)
+	assert.Assert(t, !rebuild)
+
+	imageCache.Platforms = []string{"linux/amd64"}
+	ctx.Config().LocalCache().SetImageCache("api", imageCache)
+	rebuild, err = helper.ShouldRebuild(ctx, false)
+	assert.NilError(t, err)
+	assert.Assert(t, rebuild)
+}
@@ -0,0 +1,112 @@
+package helper
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+)
+
+// attestationReferenceType is the annotation BuildKit sets on the manifests of an image index
+// that hold the attestations of an image
+const attestationReferenceType = "vnd.docker.reference.type"
+
+// PlatformTag returns the tag the image of a single platform is pushed to by build engines
+// that build each platform separately, e.g. latest-linux-arm64 for linux/arm64
+func PlatformTag(tag, platform string) string {
+	return tag + "-" + strings.ReplaceAll(platform, "/", "-")
+}
+
+// PushIndex creates an OCI image index from the platform images that were pushed to their
+// PlatformTag and pushes it to all tags of the image. Returns the digest of the index.
+func PushIndex(ctx context.Context, imageName string, tags, platforms []string, options ...name.Option) (string, error) {
+	if len(tags) == 0 {
+		return "", errors.New("no tags to push the image index to")
+	}
+
+	remoteOptions := []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}
+
+	adds := []mutate.IndexAddendum{}
+	for _, platform := range platforms {
+		parsed, err := v1.ParsePlatform(platform)
+		if err != nil {
+			return "", err
+		}
+
+		ref, err := name.NewTag(imageName+":"+PlatformTag(tags[0], platform), options...)
+		if err != nil {
+			return "", err
+		}
+
+		image, err := remote.Image(ref, remoteOptions...)
+		if err != nil {
+			return "", errors.Wrapf(err, "get image %s", ref.String())
+		}
+
+		adds = append(adds, mutate.IndexAddendum{
+			Add: image,
+			Descriptor: v1.Descriptor{
+				Platform: parsed,
+			},
+		})
+	}
+
+	index := mutate.AppendManifests(mutate.IndexMediaType(empty.Index, types.OCIImageIndex), adds...)
+	for _, tag := range tags {
+		ref, err := name.NewTag(imageName+":"+tag, options...)
+		if err != nil {
+			return "", err
+		}
+
+		err = remote.WriteIndex(ref, index, remoteOptions...)
+		if err != nil {
+			return "", errors.Wrapf(err, "push image index %s", ref.String())
+		}
+	}
+
+	digest, err := index.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	return digest.String(), nil
+}
+
+// AttestationManifestDigest returns the digest of the attestation manifest BuildKit attached to the pushed
+// image index. If the image was built for several platforms, the digests of the attestation manifests of all
+// platforms are separated by commas. Returns an empty string if the index holds no attestations.
+func AttestationManifestDigest(ctx context.Context, imageName, indexDigest string, options ...name.Option) (string, error) {
+	ref, err := name.NewDigest(imageName+"@"+indexDigest, options...)
+	if err != nil {
+		return "", err
+	}
+
+	index, err := remote.Index(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return "", errors.Wrapf(err, "get image index %s", ref.String())
+	}
+
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return "", errors.Wrapf(err, "get image index %s", ref.String())
+	}
+
+	digests := []string{}
+	for _, descriptor := range manifest.Manifests {
+		if descriptor.Annotations[attestationReferenceType] == "attestation-manifest" {
+			digests = append(digests, descriptor.Digest.String())
+		}
+	}
+
+	return strings.Join(digests, ","), nil
+}
@@ -0,0 +1,110 @@
+package helper
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"gotest.tools/assert"
+)
+
+func TestPushIndex(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	imageName := strings.TrimPrefix(server.URL, "http://") + "/test/api"
+	platforms := []string{"linux/amd64", "linux/arm64"}
+	for _, platform := range platforms {
+		image, err := random.Image(1024, 1)
+		assert.NilError(t, err)
+
+		ref, err := name.NewTag(imageName + ":" + PlatformTag("v1", platform))
+		assert.NilError(t, err)
+		assert.NilError(t, remote.Write(ref, image))
+	}
+	assert.Equal(t, PlatformTag("v1", "linux/arm64/v8"), "v1-linux-arm64-v8")
+
+	digest, err := PushIndex(context.Background(), imageName, []string{"v1", "latest"}, platforms)
+	assert.NilError(t, err)
+
+	for _, tag := range []string{"v1", "latest"} {
+		ref, err := name.NewTag(imageName + ":" + tag)
+		assert.NilError(t, err)
+
+		index, err := remote.Index(ref)
+		assert.NilError(t, err)
+		indexDigest, err := index.Digest()
+		assert.NilError(t, err)
+		assert.Equal(t, indexDigest.String(), digest)
+
+		manifest, err := index.IndexManifest()
+		assert.NilError(t, err)
+		assert.Equal(t, manifest.MediaType, types.OCIImageIndex)
+		assert.Equal(t, len(manifest.Manifests), 2)
+		for i, platform := range platforms {
+			assert.Equal(t, manifest.Manifests[i].Platform.String(), platform)
+		}
+	}
+
+	_, err = PushIndex(context.Background(), imageName, []string{"v2"}, platforms)
+	assert.ErrorContains(t, err, "get image")
+}
+
+func TestAttestationManifestDigest(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	imageName := strings.TrimPrefix(server.URL, "http://") + "/test/api"
+	image, err := random.Image(1024, 1)
+	assert.NilError(t, err)
+	imageDigest, err := image.Digest()
+	assert.NilError(t, err)
+	attestation, err := random.Image(1024, 1)
+	assert.NilError(t, err)
+	attestationDigest, err := attestation.Digest()
+	assert.NilError(t, err)
+
+	// BuildKit attaches the attestations as a separate manifest to the image index
+	pushIndex := func(tag string, adds ...mutate.IndexAddendum) string {
+		index := mutate.AppendManifests(mutate.IndexMediaType(empty.Index, types.OCIImageIndex), adds...)
+		ref, err := name.NewTag(imageName + ":" + tag)
+		assert.NilError(t, err)
+		assert.NilError(t, remote.WriteIndex(ref, index))
+
+		digest, err := index.Digest()
+		assert.NilError(t, err)
+		return digest.String()
+	}
+	imageAddendum := mutate.IndexAddendum{Add: image, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}}
+	withAttestations := pushIndex("v1", imageAddendum, mutate.IndexAddendum{
+		Add: attestation,
+		Descriptor: v1.Descriptor{
+			Platform: &v1.Platform{OS: "unknown", Architecture: "unknown"},
+			Annotations: map[string]string{
+				"vnd.docker.reference.type":   "attestation-manifest",
+				"vnd.docker.reference.digest": imageDigest.String(),
+			},
+		},
+	})
+	withoutAttestations := pushIndex("v2", imageAddendum)
+
+	digest, err := AttestationManifestDigest(context.Background(), imageName, withAttestations)
+	assert.NilError(t, err)
+	assert.Equal(t, digest, attestationDigest.String())
+
+	digest, err = AttestationManifestDigest(context.Background(), imageName, withoutAttestations)
+	assert.NilError(t, err)
+	assert.Equal(t, digest, "")
+
+	_, err = AttestationManifestDigest(context.Background(), imageName, "sha256:"+strings.Repeat("0", 64))
+	assert.ErrorContains(t, err, "get image index")
+}
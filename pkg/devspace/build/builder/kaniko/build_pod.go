@@ -3,7 +3,9 @@ package kaniko
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/kaniko/util"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 
@@ -54,7 +56,7 @@ var defaultResources = &availableResources{
 	EphemeralStorage: resource.MustParse("10Gi"),
 }
 
-func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, options *types.ImageBuildOptions, dockerfilePath string) (*k8sv1.Pod, error) {
+func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, options *types.ImageBuildOptions, dockerfilePath, platform string) (*k8sv1.Pod, error) {
 	kanikoOptions := b.helper.ImageConf.Kaniko
 
 	registryURL, err := pullsecrets.GetRegistryFromImageName(b.FullImageName)
@@ -90,9 +92,20 @@ func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, optio
 
 	// specify destinations
 	for _, tag := range b.helper.ImageTags {
+		if platform != "" {
+			tag = helper.PlatformTag(tag, platform)
+		}
+
 		kanikoArgs = append(kanikoArgs, "--destination="+b.helper.ImageName+":"+tag)
 	}
 
+	// set platform and schedule the pod on a node with that platform
+	nodeSelector := kanikoOptions.NodeSelector
+	if platform != "" {
+		kanikoArgs = append(kanikoArgs, "--custom-platform="+platform)
+		nodeSelector = platformNodeSelector(kanikoOptions.NodeSelector, platform)
+	}
+
 	// set target
 	if options.Target != "" {
 		kanikoArgs = append(kanikoArgs, "--target="+options.Target)
@@ -255,7 +268,7 @@ func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, optio
 					VolumeMounts:    volumeMounts,
 				},
 			},
-			NodeSelector:       kanikoOptions.NodeSelector,
+			NodeSelector:       nodeSelector,
 			Tolerations:        kanikoOptions.Tolerations,
 			ServiceAccountName: kanikoOptions.ServiceAccount,
 			Volumes:            volumes,
@@ -369,6 +382,24 @@ func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, optio
 	return pod, nil
 }
 
+// platformNodeSelector adds the os and architecture of the platform to the node selector
+func platformNodeSelector(nodeSelector map[string]string, platform string) map[string]string {
+	retSelector := map[string]string{}
+	for k, v := range nodeSelector {
+		retSelector[k] = v
+	}
+
+	parts := strings.Split(platform, "/")
+	if _, ok := retSelector[k8sv1.LabelOSStable]; !ok {
+		retSelector[k8sv1.LabelOSStable] = parts[0]
+	}
+	if _, ok := retSelector[k8sv1.LabelArchStable]; !ok && len(parts) > 1 {
+		retSelector[k8sv1.LabelArchStable] = parts[1]
+	}
+
+	return retSelector
+}
+
 // Determine available resources (This is only necessary in the devspace cloud)
 func (b *Builder) getAvailableResources(ctx devspacecontext.Context) (*availableResources, error) {
 	quota, err := ctx.KubeClient().KubeClient().CoreV1().ResourceQuotas(b.BuildNamespace).Get(ctx.Context(), devspaceQuota, metav1.GetOptions{})
@@ -34,6 +34,7 @@ import (
 
 	"github.com/docker/cli/cli/command/image/build"
 	"github.com/docker/docker/api/types"
+	"github.com/google/go-containerregistry/pkg/name"
 	dockerterm "github.com/moby/term"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -138,10 +139,49 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfil
 		defer os.RemoveAll(filepath.Dir(dockerfilePath))
 	}
 
+	platforms := b.helper.ImageConf.Platforms
+	if len(platforms) == 0 {
+		err = b.runBuildPod(ctx, contextPath, dockerfilePath, options, injectRestartHelper, "")
+		if err != nil {
+			return err
+		}
+	} else {
+		// kaniko cannot build for other architectures, so each platform is built
+		// in its own pod on a matching node and pushed to its platform tag
+		for _, platform := range platforms {
+			ctx.Log().Infof("Build image %s for platform %s", b.FullImageName, platform)
+			err = b.runBuildPod(ctx, contextPath, dockerfilePath, options, injectRestartHelper, platform)
+			if err != nil {
+				return errors.Wrapf(err, "build platform %s", platform)
+			}
+		}
+
+		var nameOptions []name.Option
+		if b.allowInsecureRegistry {
+			nameOptions = append(nameOptions, name.Insecure)
+		}
+
+		digest, err := helper.PushIndex(ctx.Context(), b.helper.ImageName, b.helper.ImageTags, platforms, nameOptions...)
+		if err != nil {
+			return errors.Wrap(err, "push image index")
+		}
+
+		ctx.Log().Donef("Pushed image index %s@%s", b.helper.ImageName, digest)
+		b.helper.ImageDigest = digest
+	}
+
+	// kaniko always pushes the image
+	b.helper.Pushed = true
+	return nil
+}
+
+// runBuildPod builds the image in a kaniko pod. If platform is set, the pod is scheduled
+// on a node of that platform and pushes the image to the platform tags.
+func (b *Builder) runBuildPod(ctx devspacecontext.Context, contextPath, dockerfilePath string, options *types.ImageBuildOptions, injectRestartHelper bool, platform string) error {
 	// Generate the build pod spec
 	randString := randutil.GenerateRandomString(12)
 	buildID := strings.ToLower(randString)
-	buildPod, err := b.getBuildPod(ctx, buildID, options, dockerfilePath)
+	buildPod, err := b.getBuildPod(ctx, buildID, options, dockerfilePath, platform)
 	if err != nil {
 		return errors.Wrap(err, "get build pod")
 	}
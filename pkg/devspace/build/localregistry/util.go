@@ -67,6 +67,8 @@ func UseLocalRegistry(client kubectl.Client, config *latest.Config, imageConfig
 			return false
 		} else if imageConfig.BuildKit != nil && imageConfig.BuildKit.InCluster != nil {
 			return false
+		} else if len(imageConfig.Platforms) > 0 {
+			return false
 		}
 	}
 
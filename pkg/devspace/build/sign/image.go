@@ -0,0 +1,105 @@
+package sign
+
+import (
+	"context"
+	"crypto"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+)
+
+// PasswordEnv is the environment variable that holds the password of encrypted private keys
+const PasswordEnv = "COSIGN_PASSWORD"
+
+// LoadSigner loads the private key of the sign config. Relative paths are resolved against dir.
+func LoadSigner(dir string, config *latest.ImageSign) (crypto.Signer, error) {
+	pemBytes, err := loadKey(dir, config.Key, config.KeyEnv)
+	if err != nil {
+		return nil, err
+	} else if pemBytes == nil {
+		return nil, errors.New("please specify either key or keyEnv")
+	}
+
+	signer, err := ParsePrivateKey(pemBytes, []byte(os.Getenv(PasswordEnv)))
+	if err != nil {
+		return nil, errors.Wrap(err, "load private key")
+	}
+
+	return signer, nil
+}
+
+// LoadPublicKey loads the public key of the sign config. If there is no public key configured,
+// the public key of the private key is used.
+func LoadPublicKey(dir string, config *latest.ImageSign) (crypto.PublicKey, error) {
+	pemBytes, err := loadKey(dir, config.PublicKey, config.PublicKeyEnv)
+	if err != nil {
+		return nil, err
+	} else if pemBytes == nil {
+		signer, err := LoadSigner(dir, config)
+		if err != nil {
+			return nil, err
+		}
+
+		return signer.Public(), nil
+	}
+
+	publicKey, err := ParsePublicKey(pemBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "load public key")
+	}
+
+	return publicKey, nil
+}
+
+// SignImage signs the pushed image with the configured key and returns the signed digest
+func SignImage(ctx context.Context, dir string, config *latest.ImageSign, image string, options ...remote.Option) (name.Digest, error) {
+	signer, err := LoadSigner(dir, config)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	ref, err := Resolve(ctx, image, options...)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	return ref, Sign(ctx, ref, signer, config.Annotations, options...)
+}
+
+// VerifyImage verifies the signature of the image with the configured public key and returns the verified digest
+func VerifyImage(ctx context.Context, dir string, config *latest.ImageSign, image string, options ...remote.Option) (name.Digest, error) {
+	publicKey, err := LoadPublicKey(dir, config)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	ref, err := Resolve(ctx, image, options...)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	return ref, Verify(ctx, ref, publicKey, options...)
+}
+
+func loadKey(dir, path, env string) ([]byte, error) {
+	if env != "" {
+		value := os.Getenv(env)
+		if value == "" {
+			return nil, errors.Errorf("environment variable %s is empty", env)
+		}
+
+		return []byte(value), nil
+	} else if path == "" {
+		return nil, nil
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	return os.ReadFile(path)
+}
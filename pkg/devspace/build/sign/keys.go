@@ -0,0 +1,132 @@
+package sign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// List of pem block types that can hold a private key
+const (
+	encryptedCosignKeyType   = "ENCRYPTED COSIGN PRIVATE KEY"
+	encryptedSigstoreKeyType = "ENCRYPTED SIGSTORE PRIVATE KEY"
+	pkcs8KeyType             = "PRIVATE KEY"
+	ecKeyType                = "EC PRIVATE KEY"
+	rsaKeyType               = "RSA PRIVATE KEY"
+)
+
+// encryptedKey is the format cosign uses for password protected private keys
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// ParsePrivateKey parses a pem encoded private key. Keys generated with cosign generate-key-pair
+// are decrypted with the password.
+func ParsePrivateKey(pemBytes, password []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no pem encoded private key found")
+	}
+
+	der := block.Bytes
+	switch block.Type {
+	case encryptedCosignKeyType, encryptedSigstoreKeyType:
+		var err error
+		der, err = decrypt(block.Bytes, password)
+		if err != nil {
+			return nil, err
+		}
+	case ecKeyType:
+		return x509.ParseECPrivateKey(der)
+	case rsaKeyType:
+		return x509.ParsePKCS1PrivateKey(der)
+	case pkcs8KeyType:
+	default:
+		return nil, errors.Errorf("unsupported private key type %s", block.Type)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse private key")
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported private key %T", key)
+	}
+
+	return signer, nil
+}
+
+// ParsePublicKey parses a pem encoded public key
+func ParsePublicKey(pemBytes []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no pem encoded public key found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse public key")
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	}
+
+	return nil, errors.Errorf("unsupported public key %T", key)
+}
+
+func decrypt(data, password []byte) ([]byte, error) {
+	key := &encryptedKey{}
+	err := json.Unmarshal(data, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse encrypted private key")
+	} else if key.KDF.Name != "scrypt" {
+		return nil, errors.Errorf("unsupported key derivation function %s", key.KDF.Name)
+	} else if key.Cipher.Name != "nacl/secretbox" {
+		return nil, errors.Errorf("unsupported cipher %s", key.Cipher.Name)
+	} else if len(key.Cipher.Nonce) != 24 {
+		return nil, errors.New("invalid nonce of encrypted private key")
+	}
+
+	secret, err := scrypt.Key(password, key.KDF.Salt, key.KDF.Params.N, key.KDF.Params.R, key.KDF.Params.P, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "derive key")
+	}
+
+	var (
+		nonce       [24]byte
+		secretBytes [32]byte
+	)
+	copy(nonce[:], key.Cipher.Nonce)
+	copy(secretBytes[:], secret)
+	out, ok := secretbox.Open(nil, key.Ciphertext, &nonce, &secretBytes)
+	if !ok {
+		return nil, errors.New("decrypt private key: wrong password")
+	}
+
+	return out, nil
+}
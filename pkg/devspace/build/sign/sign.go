@@ -0,0 +1,254 @@
+package sign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SimpleSigningMediaType is the media type of the signature payload layers
+	SimpleSigningMediaType types.MediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// SignatureAnnotation is the layer annotation that holds the base64 encoded signature
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// SignatureType is the type of the signature payload
+	SignatureType = "cosign container image signature"
+)
+
+// Payload is the simple signing payload that is signed for an image digest
+type Payload struct {
+	Critical Critical          `json:"critical"`
+	Optional map[string]string `json:"optional"`
+}
+
+// Critical holds the signed image identity
+type Critical struct {
+	Identity Identity `json:"identity"`
+	Image    Image    `json:"image"`
+	Type     string   `json:"type"`
+}
+
+// Identity is the repository of the signed image
+type Identity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+// Image is the digest of the signed image
+type Image struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// SignatureTag returns the tag cosign stores the signatures of the image digest under
+func SignatureTag(ref name.Digest) (name.Tag, error) {
+	return name.NewTag(ref.Context().String()+":"+strings.Replace(ref.DigestStr(), ":", "-", 1)+".sig", name.WeakValidation)
+}
+
+// Resolve returns the digest of the image in the registry
+func Resolve(ctx context.Context, image string, options ...remote.Option) (name.Digest, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return name.Digest{}, err
+	}
+	if digest, ok := ref.(name.Digest); ok {
+		return digest, nil
+	}
+
+	descriptor, err := remote.Head(ref, remoteOptions(ctx, options)...)
+	if err != nil {
+		return name.Digest{}, errors.Wrapf(err, "resolve digest of %s", image)
+	}
+
+	return ref.Context().Digest(descriptor.Digest.String()), nil
+}
+
+// Sign signs the image digest and adds the signature to the signature image in the registry
+func Sign(ctx context.Context, ref name.Digest, signer crypto.Signer, annotations map[string]string, options ...remote.Option) error {
+	payload, err := json.Marshal(&Payload{
+		Critical: Critical{
+			Identity: Identity{DockerReference: ref.Context().Name()},
+			Image:    Image{DockerManifestDigest: ref.DigestStr()},
+			Type:     SignatureType,
+		},
+		Optional: annotations,
+	})
+	if err != nil {
+		return err
+	}
+
+	signature, err := signPayload(signer, payload)
+	if err != nil {
+		return errors.Wrap(err, "sign payload")
+	}
+
+	tag, err := SignatureTag(ref)
+	if err != nil {
+		return err
+	}
+
+	options = remoteOptions(ctx, options)
+	signatures, err := signatureImage(tag, options)
+	if err != nil {
+		return err
+	}
+
+	signatures, err = mutate.Append(signatures, mutate.Addendum{
+		Layer:     static.NewLayer(payload, SimpleSigningMediaType),
+		MediaType: SimpleSigningMediaType,
+		Annotations: map[string]string{
+			SignatureAnnotation: base64.StdEncoding.EncodeToString(signature),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = remote.Write(tag, signatures, options...)
+	if err != nil {
+		return errors.Wrapf(err, "write signature %s", tag.String())
+	}
+
+	return nil
+}
+
+// Verify checks that the image digest has at least one signature that was created by the public key
+func Verify(ctx context.Context, ref name.Digest, publicKey crypto.PublicKey, options ...remote.Option) error {
+	tag, err := SignatureTag(ref)
+	if err != nil {
+		return err
+	}
+
+	signatures, err := remote.Image(tag, remoteOptions(ctx, options)...)
+	if err != nil {
+		if isNotFound(err) {
+			return errors.Errorf("no signatures found for %s", ref.String())
+		}
+
+		return errors.Wrapf(err, "get signatures of %s", ref.String())
+	}
+
+	manifest, err := signatures.Manifest()
+	if err != nil {
+		return err
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != SimpleSigningMediaType {
+			continue
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[SignatureAnnotation])
+		if err != nil {
+			continue
+		}
+
+		payload, err := layerContent(signatures, layer.Digest)
+		if err != nil {
+			return err
+		}
+
+		if verifyPayload(publicKey, payload, signature) != nil {
+			continue
+		}
+
+		// the signature has to be made for this digest
+		parsed := &Payload{}
+		err = json.Unmarshal(payload, parsed)
+		if err == nil && parsed.Critical.Image.DockerManifestDigest == ref.DigestStr() {
+			return nil
+		}
+	}
+
+	return errors.Errorf("no valid signature found for %s", ref.String())
+}
+
+func signPayload(signer crypto.Signer, payload []byte) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		return signer.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+
+	digest := sha256.Sum256(payload)
+	return signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+func verifyPayload(publicKey crypto.PublicKey, payload, signature []byte) error {
+	digest := sha256.Sum256(payload)
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, signature) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return errors.Errorf("unsupported public key %T", publicKey)
+	}
+
+	return nil
+}
+
+// signatureImage returns the existing signature image or an empty one
+func signatureImage(tag name.Tag, options []remote.Option) (v1.Image, error) {
+	signatures, err := remote.Image(tag, options...)
+	if err == nil {
+		return signatures, nil
+	} else if !isNotFound(err) {
+		return nil, errors.Wrapf(err, "get signatures %s", tag.String())
+	}
+
+	return mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON), nil
+}
+
+func layerContent(image v1.Image, digest v1.Hash) ([]byte, error) {
+	layer, err := image.LayerByDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := layer.Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+func remoteOptions(ctx context.Context, options []remote.Option) []remote.Option {
+	return append([]remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}, options...)
+}
+
+func isNotFound(err error) bool {
+	transportErr := &transport.Error{}
+	if errors.As(err, &transportErr) {
+		return transportErr.StatusCode == http.StatusNotFound
+	}
+
+	return false
+}
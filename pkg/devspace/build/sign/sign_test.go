@@ -0,0 +1,150 @@
+package sign
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+	"gotest.tools/assert"
+)
+
+func pushRandomImage(t *testing.T, image string) {
+	img, err := random.Image(1024, 1)
+	assert.NilError(t, err)
+
+	ref, err := name.ParseReference(image)
+	assert.NilError(t, err)
+	assert.NilError(t, remote.Write(ref, img))
+}
+
+func writeKeys(t *testing.T, dir string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NilError(t, err)
+	publicDer, err := x509.MarshalPKIXPublicKey(key.Public())
+	assert.NilError(t, err)
+
+	keyPath := filepath.Join(dir, "cosign.key")
+	publicKeyPath := filepath.Join(dir, "cosign.pub")
+	assert.NilError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: pkcs8KeyType, Bytes: der}), 0600))
+	assert.NilError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDer}), 0600))
+}
+
+func TestSignAndVerify(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	image := strings.TrimPrefix(server.URL, "http://") + "/test/api:v1"
+	pushRandomImage(t, image)
+
+	dir := t.TempDir()
+	writeKeys(t, dir)
+	config := &latest.ImageSign{
+		Key:         "cosign.key",
+		PublicKey:   "cosign.pub",
+		Annotations: map[string]string{"team": "devspace"},
+	}
+
+	// unsigned images should fail verification
+	_, err := VerifyImage(context.Background(), dir, config, image)
+	assert.ErrorContains(t, err, "no signatures found")
+
+	signed, err := SignImage(context.Background(), dir, config, image)
+	assert.NilError(t, err)
+
+	verified, err := VerifyImage(context.Background(), dir, config, image)
+	assert.NilError(t, err)
+	assert.Equal(t, verified.String(), signed.String())
+
+	// signing twice should keep both signatures
+	assert.NilError(t, Sign(context.Background(), signed, mustLoadSigner(t, dir, config), nil))
+	tag, err := SignatureTag(signed)
+	assert.NilError(t, err)
+	signatures, err := remote.Image(tag)
+	assert.NilError(t, err)
+	manifest, err := signatures.Manifest()
+	assert.NilError(t, err)
+	assert.Equal(t, len(manifest.Layers), 2)
+
+	payload, err := layerContent(signatures, manifest.Layers[0].Digest)
+	assert.NilError(t, err)
+	parsed := &Payload{}
+	assert.NilError(t, json.Unmarshal(payload, parsed))
+	assert.Equal(t, parsed.Critical.Image.DockerManifestDigest, signed.DigestStr())
+	assert.Equal(t, parsed.Optional["team"], "devspace")
+
+	// a different key should fail verification
+	otherDir := t.TempDir()
+	writeKeys(t, otherDir)
+	_, err = VerifyImage(context.Background(), otherDir, config, image)
+	assert.ErrorContains(t, err, "no valid signature found")
+
+	// a signature of another image digest should not verify this image
+	other := strings.TrimPrefix(server.URL, "http://") + "/test/api:v2"
+	pushRandomImage(t, other)
+	otherRef, err := Resolve(context.Background(), other)
+	assert.NilError(t, err)
+	err = Verify(context.Background(), otherRef, mustLoadSigner(t, dir, config).Public())
+	assert.ErrorContains(t, err, "no signatures found")
+}
+
+func TestParseEncryptedPrivateKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NilError(t, err)
+
+	encrypted := &encryptedKey{}
+	encrypted.KDF.Name = "scrypt"
+	encrypted.KDF.Params.N = 32768
+	encrypted.KDF.Params.R = 8
+	encrypted.KDF.Params.P = 1
+	encrypted.KDF.Salt = []byte("0123456789abcdef0123456789abcdef")
+	encrypted.Cipher.Name = "nacl/secretbox"
+	encrypted.Cipher.Nonce = []byte("0123456789abcdef01234567")
+
+	secret, err := scrypt.Key([]byte("password"), encrypted.KDF.Salt, 32768, 8, 1, 32)
+	assert.NilError(t, err)
+	var (
+		nonce       [24]byte
+		secretBytes [32]byte
+	)
+	copy(nonce[:], encrypted.Cipher.Nonce)
+	copy(secretBytes[:], secret)
+	encrypted.Ciphertext = secretbox.Seal(nil, der, &nonce, &secretBytes)
+
+	out, err := json.Marshal(encrypted)
+	assert.NilError(t, err)
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: encryptedCosignKeyType, Bytes: out})
+
+	signer, err := ParsePrivateKey(pemBytes, []byte("password"))
+	assert.NilError(t, err)
+	assert.Assert(t, key.Equal(signer))
+
+	_, err = ParsePrivateKey(pemBytes, []byte("wrong"))
+	assert.ErrorContains(t, err, "wrong password")
+}
+
+func mustLoadSigner(t *testing.T, dir string, config *latest.ImageSign) *ecdsa.PrivateKey {
+	signer, err := LoadSigner(dir, config)
+	assert.NilError(t, err)
+	return signer.(*ecdsa.PrivateKey)
+}
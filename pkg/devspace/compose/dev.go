@@ -38,6 +38,9 @@ func (cb *configBuilder) AddDev(service composetypes.ServiceConfig) error {
 		if port.HostIP != "" {
 			portMapping.BindAddress = port.HostIP
 		}
+		if port.Protocol == "udp" {
+			portMapping.Protocol = latest.PortProtocolUDP
+		}
 
 		devPorts = append(devPorts, portMapping)
 	}
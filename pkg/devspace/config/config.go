@@ -4,6 +4,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
 )
 
 type Config interface {
@@ -32,6 +33,10 @@ type Config interface {
 
 	// Path returns the absolute path from which the config was loaded
 	Path() string
+
+	// Lockfile returns the lockfile the remote sources of the config
+	// are locked in or nil if there is none
+	Lockfile() *lockfile.Lockfile
 }
 
 func NewConfig(raw map[string]interface{}, rawBeforeConversion map[string]interface{}, parsed *latest.Config, localCache localcache.Cache, remoteCache remotecache.Cache, resolvedVariables map[string]interface{}, path string) Config {
@@ -57,6 +62,7 @@ type config struct {
 	remoteCache         remotecache.Cache
 	resolvedVariables   map[string]interface{}
 	path                string
+	lockfile            *lockfile.Lockfile
 }
 
 func (c *config) RawBeforeConversion() map[string]interface{} {
@@ -92,6 +98,34 @@ func (c *config) Path() string {
 	return c.path
 }
 
+func (c *config) Lockfile() *lockfile.Lockfile {
+	return c.lockfile
+}
+
+// WithRemoteCache returns a copy of the config that uses the given remote cache, e.g. the cache
+// of another cluster. Runtime variables are shared with the original config.
+func WithRemoteCache(conf Config, remoteCache remotecache.Cache) Config {
+	if c, ok := conf.(*config); ok {
+		n := *c
+		n.remoteCache = remoteCache
+		return &n
+	}
+
+	return WithLockfile(NewConfig(conf.Raw(), conf.RawBeforeConversion(), conf.Config(), conf.LocalCache(), remoteCache, conf.Variables(), conf.Path()), conf.Lockfile())
+}
+
+// WithLockfile returns a copy of the config that holds the given lockfile. Runtime variables
+// are shared with the original config.
+func WithLockfile(conf Config, lock *lockfile.Lockfile) Config {
+	if c, ok := conf.(*config); ok {
+		n := *c
+		n.lockfile = lock
+		return &n
+	}
+
+	return conf
+}
+
 func Ensure(config Config) Config {
 	retConfig := config
 	if retConfig == nil {
@@ -114,6 +148,7 @@ func Ensure(config Config) Config {
 	}
 
 	if config != nil {
+		retConfig = WithLockfile(retConfig, config.Lockfile())
 		runtimeVars := config.ListRuntimeVariables()
 		for k, v := range runtimeVars {
 			retConfig.SetRuntimeVariable(k, v)
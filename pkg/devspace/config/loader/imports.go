@@ -123,6 +123,9 @@ func ResolveImports(ctx context.Context, resolver variable.Resolver, basePath st
 				_, ok := mergedMap[section].(map[string]interface{})[key]
 				if !ok {
 					mergedMap[section].(map[string]interface{})[key] = value
+					if section == "vars" {
+						resolver.SetVariableOrigin(key, configPath)
+					}
 				}
 			}
 		}
@@ -15,12 +15,14 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/utils/pkg/command"
 
 	"github.com/loft-sh/devspace/pkg/util/constraint"
 
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
@@ -164,6 +166,13 @@ func (l *configLoader) LoadWithParser(ctx context.Context, localCache localcache
 	// set name to context
 	ctx = values.WithName(ctx, name)
 
+	spanCtx, span := tracing.Start(ctx, tracing.CategoryConfig, "load config "+name)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+	ctx = spanCtx
+
 	// create remote cache
 	var remoteCache remotecache.Cache
 	if client != nil {
@@ -173,17 +182,41 @@ func (l *configLoader) LoadWithParser(ctx context.Context, localCache localcache
 		}
 	}
 
+	// remote sources are locked in the devspace.lock of the root config
+	lock := lockfile.FromContext(ctx)
+	ownLock := lock == nil
+	if ownLock {
+		lock, err = lockfile.Load(filepath.Join(filepath.Dir(l.absConfigPath), lockfile.FileName), lockfile.Options{Frozen: options.FrozenLockfile})
+		if err != nil {
+			return nil, err
+		}
+
+		ctx = lockfile.WithLockfile(ctx, lock)
+	}
+
 	parsedConfig, rawBeforeConversion, resolver, err := l.parseConfig(ctx, data, localCache, remoteCache, client, parser, options, log)
 	if err != nil {
 		return nil, err
 	}
 
+	if ownLock {
+		err = lock.Save()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = l.ensureRequires(ctx, parsedConfig, log)
 	if err != nil {
 		return nil, errors.Wrap(err, "require versions")
 	}
 
-	c := config.NewConfig(data, rawBeforeConversion, parsedConfig, localCache, remoteCache, resolver.ResolvedVariables(), l.absConfigPath)
+	// make sure pull secret passwords never show up in the log output
+	maskPullSecrets(parsedConfig)
+
+	// the lockfile is kept with the config, so that remote sources resolved
+	// later on, e.g. helm charts, are locked as well
+	c := config.WithLockfile(config.NewConfig(data, rawBeforeConversion, parsedConfig, localCache, remoteCache, resolver.ResolvedVariables(), l.absConfigPath), lock)
 	pluginErr = plugin.ExecutePluginHookWithContext(map[string]interface{}{
 		"LOAD_PATH":     l.absConfigPath,
 		"LOADED_CONFIG": c.Config(),
@@ -197,6 +230,14 @@ func (l *configLoader) LoadWithParser(ctx context.Context, localCache localcache
 	return c, nil
 }
 
+func maskPullSecrets(config *latest.Config) {
+	for _, pullSecret := range config.PullSecrets {
+		if pullSecret != nil && pullSecret.Password != "" {
+			log.AddMaskedValue(pullSecret.Password)
+		}
+	}
+}
+
 func (l *configLoader) ensureRequires(ctx context.Context, config *latest.Config, log log.Logger) error {
 	if config == nil {
 		return nil
@@ -314,7 +355,10 @@ func (l *configLoader) parseConfig(
 	}
 
 	// copy raw config
+	_, importsSpan := tracing.Start(ctx, tracing.CategoryConfig, "resolve imports")
 	copiedRawConfig, err := ResolveImports(ctx, resolver, filepath.Dir(l.absConfigPath), rawConfig, log)
+	importsSpan.SetError(err)
+	importsSpan.End()
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -344,7 +388,10 @@ func (l *configLoader) parseConfig(
 	delete(copiedRawConfig, "vars")
 
 	// parse the config
+	_, parseSpan := tracing.Start(ctx, tracing.CategoryConfig, "resolve variables")
 	latestConfig, rawBeforeConversion, err := parser.Parse(ctx, rawConfig, copiedRawConfig, resolver, log)
+	parseSpan.SetError(err)
+	parseSpan.End()
 	if err != nil {
 		return nil, nil, nil, err
 	}
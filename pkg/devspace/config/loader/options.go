@@ -17,6 +17,8 @@ type ConfigOptions struct {
 	ProfileRefresh bool
 	// If the profile activations should be disabled
 	DisableProfileActivation bool
+	// If loading should fail instead of updating an outdated devspace.lock
+	FrozenLockfile bool
 
 	Vars []string
 }
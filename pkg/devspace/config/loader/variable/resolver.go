@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/expression"
@@ -32,6 +33,8 @@ func NewResolver(localCache localcache.Cache, predefinedVariableOptions *Predefi
 
 	return &resolver{
 		memoryCache: memoryCache,
+		validated:   map[string]bool{},
+		origins:     map[string]string{},
 		localCache:  localCache,
 		options:     predefinedVariableOptions,
 		log:         log,
@@ -56,6 +59,8 @@ func MergeVarsWithFlags(vars map[string]interface{}, flags []string) error {
 type resolver struct {
 	vars        map[string]*latest.Variable
 	memoryCache map[string]interface{}
+	validated   map[string]bool
+	origins     map[string]string
 
 	localCache localcache.Cache
 	options    *PredefinedVariableOptions
@@ -74,6 +79,34 @@ func (r *resolver) UpdateVars(vars map[string]*latest.Variable) {
 	r.vars = vars
 }
 
+func (r *resolver) SetVariableOrigin(name, origin string) {
+	r.origins[name] = origin
+}
+
+// variableOrigin returns the path of the file the variable was defined in relative to the devspace.yaml
+func (r *resolver) variableOrigin(name string) string {
+	configPath := ""
+	if r.options != nil {
+		configPath = r.options.ConfigPath
+	}
+
+	origin, ok := r.origins[name]
+	if !ok {
+		origin = configPath
+	}
+	if origin == "" {
+		return "unknown origin"
+	}
+	if configPath != "" {
+		relPath, err := filepath.Rel(filepath.Dir(configPath), origin)
+		if err == nil && !strings.HasPrefix(relPath, "..") {
+			return relPath
+		}
+	}
+
+	return origin
+}
+
 func (r *resolver) fillVariables(ctx context.Context, haystack interface{}, exclude, include []*regexp.Regexp) (interface{}, error) {
 	switch t := haystack.(type) {
 	case string:
@@ -340,13 +373,23 @@ func (r *resolver) findAndFillVariables(ctx context.Context, haystack interface{
 		}
 	}
 
-	// resolve used defined variables
+	// resolve used defined variables and collect all invalid values
+	failures := []string{}
 	for _, v := range varsUsed {
 		_, err := r.resolve(ctx, v.Name, v)
 		if err != nil {
-			return nil, err
+			invalidErr, ok := err.(*invalidValueError)
+			if !ok {
+				return nil, err
+			}
+
+			failures = append(failures, invalidErr.failures...)
 		}
 	}
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return nil, errors.Errorf("invalid variable values:\n  %s", strings.Join(failures, "\n  "))
+	}
 
 	return r.fillVariables(ctx, haystack, exclude, include)
 }
@@ -357,7 +400,13 @@ func (r *resolver) resolve(ctx context.Context, name string, definition *latest.
 	// check if in vars already
 	v, ok := r.memoryCache[name]
 	if ok {
-		return v, nil
+		maskSensitiveValue(definition, v)
+		if definition == nil || r.validated[name] {
+			return v, nil
+		}
+
+		// values from flags are cached before the definition is known
+		return r.validateValue(name, definition, v)
 	}
 
 	// is predefined variable?
@@ -388,12 +437,50 @@ func (r *resolver) resolve(ctx context.Context, name string, definition *latest.
 	if err != nil {
 		return nil, err
 	}
+	maskSensitiveValue(definition, value)
 
 	// set variable so that we don't ask again
 	r.memoryCache[name] = value
+	return r.validateValue(name, definition, value)
+}
+
+// validateValue validates the value against the variable definition and caches the value
+// converted to the variable type, so that variables used in the definition of other
+// variables are substituted with the converted value as well
+func (r *resolver) validateValue(name string, definition *latest.Variable, value interface{}) (interface{}, error) {
+	value, validationFailures := validateValue(definition, value)
+	if len(validationFailures) > 0 {
+		err := &invalidValueError{}
+		for _, failure := range validationFailures {
+			err.failures = append(err.failures, fmt.Sprintf("%s (defined in %s): %s", name, r.variableOrigin(name), failure))
+		}
+
+		return nil, err
+	}
+
+	r.memoryCache[name] = value
+	r.validated[name] = true
 	return value, nil
 }
 
+// invalidValueError is returned by resolve if the value of a variable is not valid
+type invalidValueError struct {
+	failures []string
+}
+
+func (e *invalidValueError) Error() string {
+	return fmt.Sprintf("invalid variable value:\n  %s", strings.Join(e.failures, "\n  "))
+}
+
+// maskSensitiveValue registers the values of password and sensitive variables to be redacted from the log output
+func maskSensitiveValue(definition *latest.Variable, value interface{}) {
+	if definition == nil || value == nil || (!definition.Password && !definition.Sensitive) {
+		return
+	}
+
+	log.AddMaskedValue(fmt.Sprintf("%v", value))
+}
+
 func (r *resolver) findVariablesInDefinition(definition *latest.Variable) map[string]bool {
 	varsUsed := map[string]bool{}
 	if definition == nil {
@@ -447,6 +534,16 @@ func (r *resolver) findVariablesInDefinition(definition *latest.Variable) map[st
 		}
 	}
 
+	// check secret
+	if definition.Secret != nil {
+		for _, field := range []string{definition.Secret.File, definition.Secret.IdentityFile, definition.Secret.Name, definition.Secret.Namespace, definition.Secret.Key} {
+			_, _ = varspkg.ParseString(field, func(v string) (interface{}, error) {
+				varsUsed[v] = true
+				return "", nil
+			})
+		}
+	}
+
 	// filter out runtime environment variables and non existing ones
 	for k := range varsUsed {
 		if !strings.HasPrefix(k, "runtime.") && !IsPredefinedVariable(k) && r.getVariableDefinition(k) == nil {
@@ -473,6 +570,11 @@ func (r *resolver) fillVariableDefinition(ctx context.Context, definition *lates
 		definition.Source = latest.VariableSourceNone
 	}
 
+	// a secret definition implies source secret
+	if definition.Secret != nil && definition.Source == latest.VariableSourceDefault {
+		definition.Source = latest.VariableSourceSecret
+	}
+
 	// if the definition has a default value, we try to resolve possible variables
 	// in that definition from the cache (or predefined) before continuing
 	if definition.Default != nil {
@@ -498,6 +600,16 @@ func (r *resolver) fillVariableDefinition(ctx context.Context, definition *lates
 		}
 	}
 
+	// resolve secret
+	if definition.Secret != nil {
+		for _, field := range []*string{&definition.Secret.File, &definition.Secret.IdentityFile, &definition.Secret.Name, &definition.Secret.Namespace, &definition.Secret.Key} {
+			*field, err = r.resolveDefinitionStringToString(ctx, *field, definition)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	// resolve commands
 	for ci := range definition.Commands {
 		definition.Commands[ci].Command, err = r.resolveDefinitionStringToString(ctx, definition.Commands[ci].Command, definition)
@@ -579,7 +691,9 @@ func (r *resolver) fillVariable(ctx context.Context, name string, definition *la
 		return NewNoneVariable(name).Load(ctx, definition)
 	case latest.VariableSourceCommand:
 		return NewCommandVariable(name, filepath.Dir(r.options.ConfigPath)).Load(ctx, definition)
+	case latest.VariableSourceSecret:
+		return NewSecretVariable(name, filepath.Dir(r.options.ConfigPath), r.options.KubeClient).Load(ctx, definition)
 	default:
-		return nil, errors.Errorf("unrecognized variable source '%s', please choose one of 'all', 'input', 'env' or 'none'", name)
+		return nil, errors.Errorf("unrecognized variable source '%s', please choose one of 'all', 'input', 'env', 'command', 'secret' or 'none'", definition.Source)
 	}
 }
@@ -0,0 +1,82 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/utils/pkg/command"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/expand"
+)
+
+// sopsProvider decrypts sops encrypted files with the sops cli
+type sopsProvider struct{}
+
+func (s *sopsProvider) Load(ctx context.Context, secret *latest.VariableSecret, options *Options) (string, error) {
+	if secret.File == "" {
+		return "", errors.New("file is required for the sops secret provider")
+	}
+
+	content, err := decrypt(ctx, options.WorkingDirectory, "sops", "--decrypt", resolvePath(options.WorkingDirectory, secret.File))
+	if err != nil {
+		return "", err
+	}
+
+	return extractKey(content, secret.Key)
+}
+
+// ageProvider decrypts age encrypted files with the age cli
+type ageProvider struct{}
+
+func (a *ageProvider) Load(ctx context.Context, secret *latest.VariableSecret, options *Options) (string, error) {
+	if secret.File == "" {
+		return "", errors.New("file is required for the age secret provider")
+	}
+
+	identityFile := secret.IdentityFile
+	if identityFile == "" {
+		identityFile = os.Getenv("SOPS_AGE_KEY_FILE")
+	}
+	if identityFile == "" {
+		homeDir, err := homedir.Dir()
+		if err != nil {
+			return "", err
+		}
+
+		identityFile = filepath.Join(homeDir, ".config", "sops", "age", "keys.txt")
+	}
+
+	content, err := decrypt(ctx, options.WorkingDirectory, "age", "--decrypt", "--identity", resolvePath(options.WorkingDirectory, identityFile), resolvePath(options.WorkingDirectory, secret.File))
+	if err != nil {
+		return "", err
+	}
+
+	return extractKey(content, secret.Key)
+}
+
+func decrypt(ctx context.Context, dir, cmd string, args ...string) ([]byte, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	err := command.Command(ctx, dir, expand.ListEnviron(os.Environ()...), stdout, stderr, nil, cmd, args...)
+	if err != nil {
+		return nil, errors.Errorf("error running '%s %s': %v -> %s", cmd, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func resolvePath(dir, path string) string {
+	if expanded, err := homedir.Expand(path); err == nil {
+		path = expanded
+	}
+	if filepath.IsAbs(path) || dir == "" {
+		return path
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(path))
+}
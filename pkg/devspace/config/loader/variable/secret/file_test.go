@@ -0,0 +1,90 @@
+//go:build !windows
+// +build !windows
+
+package secret
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/mitchellh/go-homedir"
+	"gotest.tools/assert"
+)
+
+// fakeDecrypt installs fake sops and age binaries on the PATH, that print the given content
+// and record their arguments in the returned file
+func fakeDecrypt(t *testing.T, content string) string {
+	binDir := t.TempDir()
+	argsFile := filepath.Join(t.TempDir(), "args")
+	script := "#!/bin/sh\necho \"$@\" > \"" + argsFile + "\"\nprintf '" + content + "'\n"
+	for _, name := range []string{"sops", "age"} {
+		assert.NilError(t, os.WriteFile(filepath.Join(binDir, name), []byte(script), 0755))
+	}
+
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return argsFile
+}
+
+func readArgs(t *testing.T, argsFile string) string {
+	out, err := os.ReadFile(argsFile)
+	assert.NilError(t, err)
+	return strings.TrimSpace(string(out))
+}
+
+func TestResolvePath(t *testing.T) {
+	homeDir, err := homedir.Dir()
+	assert.NilError(t, err)
+
+	assert.Equal(t, resolvePath("/project", "secrets.enc.yaml"), filepath.Join("/project", "secrets.enc.yaml"))
+	assert.Equal(t, resolvePath("/project", "config/secrets.enc.yaml"), filepath.Join("/project", "config", "secrets.enc.yaml"))
+	assert.Equal(t, resolvePath("/project", "/etc/secrets.enc.yaml"), "/etc/secrets.enc.yaml")
+	assert.Equal(t, resolvePath("/project", "~/keys.txt"), filepath.Join(homeDir, "keys.txt"))
+	assert.Equal(t, resolvePath("", "secrets.enc.yaml"), "secrets.enc.yaml")
+}
+
+func TestSopsProvider(t *testing.T) {
+	argsFile := fakeDecrypt(t, "database:\\n  password: s3cret\\n")
+	projectDir := t.TempDir()
+
+	value, err := Load(context.Background(), &latest.VariableSecret{Provider: "sops", File: "secrets.enc.yaml", Key: "database.password"}, &Options{WorkingDirectory: projectDir})
+	assert.NilError(t, err)
+	assert.Equal(t, value, "s3cret")
+	assert.Equal(t, readArgs(t, argsFile), "--decrypt "+filepath.Join(projectDir, "secrets.enc.yaml"))
+
+	_, err = Load(context.Background(), &latest.VariableSecret{Provider: "sops"}, &Options{WorkingDirectory: projectDir})
+	assert.Error(t, err, "file is required for the sops secret provider")
+}
+
+func TestAgeProvider(t *testing.T) {
+	argsFile := fakeDecrypt(t, "API_TOKEN=abc123\\n")
+	projectDir := t.TempDir()
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	homedir.DisableCache = true
+	defer func() { homedir.DisableCache = false }()
+
+	// the identity file of the secret is used first
+	t.Setenv("SOPS_AGE_KEY_FILE", "/keys/env.txt")
+	value, err := Load(context.Background(), &latest.VariableSecret{Provider: "age", File: "token.age", IdentityFile: "keys/age.txt", Key: "API_TOKEN"}, &Options{WorkingDirectory: projectDir})
+	assert.NilError(t, err)
+	assert.Equal(t, value, "abc123")
+	assert.Equal(t, readArgs(t, argsFile), "--decrypt --identity "+filepath.Join(projectDir, "keys", "age.txt")+" "+filepath.Join(projectDir, "token.age"))
+
+	// then the identity file of the environment
+	_, err = Load(context.Background(), &latest.VariableSecret{Provider: "age", File: "token.age", Key: "API_TOKEN"}, &Options{WorkingDirectory: projectDir})
+	assert.NilError(t, err)
+	assert.Equal(t, readArgs(t, argsFile), "--decrypt --identity /keys/env.txt "+filepath.Join(projectDir, "token.age"))
+
+	// and the sops age keys in the home directory otherwise
+	t.Setenv("SOPS_AGE_KEY_FILE", "")
+	_, err = Load(context.Background(), &latest.VariableSecret{Provider: "age", File: "token.age", Key: "API_TOKEN"}, &Options{WorkingDirectory: projectDir})
+	assert.NilError(t, err)
+	assert.Equal(t, readArgs(t, argsFile), "--decrypt --identity "+filepath.Join(homeDir, ".config", "sops", "age", "keys.txt")+" "+filepath.Join(projectDir, "token.age"))
+
+	_, err = Load(context.Background(), &latest.VariableSecret{Provider: "age"}, &Options{WorkingDirectory: projectDir})
+	assert.Error(t, err, "file is required for the age secret provider")
+}
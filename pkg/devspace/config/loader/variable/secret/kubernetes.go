@@ -0,0 +1,39 @@
+package secret
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// kubernetesProvider loads values from Kubernetes secrets in the target cluster
+type kubernetesProvider struct{}
+
+func (k *kubernetesProvider) Load(ctx context.Context, secret *latest.VariableSecret, options *Options) (string, error) {
+	if secret.Name == "" {
+		return "", errors.New("name is required for the kubernetes secret provider")
+	} else if secret.Key == "" {
+		return "", errors.New("key is required for the kubernetes secret provider")
+	} else if options.KubeClient == nil {
+		return "", errors.Errorf("cannot load kubernetes secret %s, because there is no kube context", secret.Name)
+	}
+
+	namespace := secret.Namespace
+	if namespace == "" {
+		namespace = options.KubeClient.Namespace()
+	}
+
+	kubeSecret, err := options.KubeClient.KubeClient().CoreV1().Secrets(namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "get secret %s/%s", namespace, secret.Name)
+	}
+
+	value, ok := kubeSecret.Data[secret.Key]
+	if !ok {
+		return "", errors.Errorf("key %s not found in secret %s/%s", secret.Key, namespace, secret.Name)
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,97 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/yamlutil"
+	"github.com/pkg/errors"
+)
+
+// Options are passed to the secret providers
+type Options struct {
+	// WorkingDirectory is the directory relative file paths are resolved against
+	WorkingDirectory string
+	// KubeClient is the client of the target cluster, which might be nil
+	KubeClient kubectl.Client
+}
+
+// Provider loads values from a secret store
+type Provider interface {
+	Load(ctx context.Context, secret *latest.VariableSecret, options *Options) (string, error)
+}
+
+// Providers holds the secret providers by name. Additional providers can be registered here.
+var Providers = map[string]Provider{
+	"sops":       &sopsProvider{},
+	"age":        &ageProvider{},
+	"kubernetes": &kubernetesProvider{},
+}
+
+// Load loads the value of the secret from the configured provider
+func Load(ctx context.Context, secret *latest.VariableSecret, options *Options) (string, error) {
+	provider, ok := Providers[secret.Provider]
+	if !ok {
+		names := []string{}
+		for name := range Providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return "", errors.Errorf("unknown secret provider '%s', please choose one of %s", secret.Provider, strings.Join(names, ", "))
+	}
+
+	return provider.Load(ctx, secret, options)
+}
+
+// extractKey returns the value of the key in the decrypted yaml, json or dotenv file. Nested keys of
+// yaml and json files are separated by dots.
+func extractKey(content []byte, key string) (string, error) {
+	if key == "" {
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	document := map[string]interface{}{}
+	err := yamlutil.Unmarshal(content, &document)
+	if err != nil {
+		// try dotenv next
+		env, envErr := godotenv.Unmarshal(string(content))
+		if envErr != nil {
+			return "", errors.Wrap(err, "parse decrypted file")
+		}
+
+		value, ok := env[key]
+		if !ok {
+			return "", errors.Errorf("key %s not found", key)
+		}
+
+		return value, nil
+	}
+
+	var value interface{} = document
+	for _, part := range strings.Split(key, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return "", errors.Errorf("key %s not found", key)
+		}
+
+		value, ok = m[part]
+		if !ok {
+			return "", errors.Errorf("key %s not found", key)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}, []interface{}:
+		return "", errors.Errorf("key %s is not a single value", key)
+	case nil:
+		return "", nil
+	default:
+		return fmt.Sprintf("%v", v), nil
+	}
+}
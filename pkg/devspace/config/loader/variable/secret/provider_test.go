@@ -0,0 +1,57 @@
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakekubectl "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestExtractKey(t *testing.T) {
+	value, err := extractKey([]byte("database:\n  password: s3cret\n  port: 5432\n"), "database.password")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "s3cret")
+
+	value, err = extractKey([]byte(`{"database": {"port": 5432}}`), "database.port")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "5432")
+
+	value, err = extractKey([]byte("API_TOKEN=abc123\nOTHER=value\n"), "API_TOKEN")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "abc123")
+
+	value, err = extractKey([]byte("  whole file  \n"), "")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "whole file")
+
+	_, err = extractKey([]byte("database:\n  password: s3cret\n"), "database")
+	assert.Error(t, err, "key database is not a single value")
+
+	_, err = extractKey([]byte("database:\n  password: s3cret\n"), "database.user")
+	assert.Error(t, err, "key database.user not found")
+}
+
+func TestKubernetesProvider(t *testing.T) {
+	client := &fakekubectl.Client{Client: fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "testNamespace"},
+		Data:       map[string][]byte{"password": []byte("s3cret")},
+	})}
+
+	value, err := Load(context.Background(), &latest.VariableSecret{Provider: "kubernetes", Name: "db", Key: "password"}, &Options{KubeClient: client})
+	assert.NilError(t, err)
+	assert.Equal(t, value, "s3cret")
+
+	_, err = Load(context.Background(), &latest.VariableSecret{Provider: "kubernetes", Name: "db", Key: "user"}, &Options{KubeClient: client})
+	assert.Error(t, err, "key user not found in secret testNamespace/db")
+
+	_, err = Load(context.Background(), &latest.VariableSecret{Provider: "kubernetes", Name: "db", Key: "password"}, &Options{})
+	assert.Error(t, err, "cannot load kubernetes secret db, because there is no kube context")
+
+	_, err = Load(context.Background(), &latest.VariableSecret{Provider: "vault"}, &Options{})
+	assert.Error(t, err, "unknown secret provider 'vault', please choose one of age, kubernetes, sops")
+}
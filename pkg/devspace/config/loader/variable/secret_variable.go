@@ -0,0 +1,44 @@
+package variable
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/secret"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// NewSecretVariable creates a new variable that is loaded from a secret store
+func NewSecretVariable(name, workingDirectory string, kubeClient kubectl.Client) Variable {
+	return &secretVariable{
+		name:             name,
+		workingDirectory: workingDirectory,
+		kubeClient:       kubeClient,
+	}
+}
+
+type secretVariable struct {
+	name             string
+	workingDirectory string
+	kubeClient       kubectl.Client
+}
+
+func (s *secretVariable) Load(ctx context.Context, definition *latest.Variable) (interface{}, error) {
+	if definition.Secret == nil {
+		return nil, errors.Errorf("couldn't set variable '%s', because source is '%s' but no secret is specified", s.name, latest.VariableSourceSecret)
+	}
+
+	value, err := secret.Load(ctx, definition.Secret, &secret.Options{
+		WorkingDirectory: s.workingDirectory,
+		KubeClient:       s.kubeClient,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fill variable %s from %s secret", s.name, definition.Secret.Provider)
+	}
+
+	// make sure the value never shows up in the log output
+	log.AddMaskedValue(value)
+	return value, nil
+}
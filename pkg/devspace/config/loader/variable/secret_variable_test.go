@@ -0,0 +1,60 @@
+package variable
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakekubectl "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/sirupsen/logrus"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSecretVariable(t *testing.T) {
+	client := &fakekubectl.Client{Client: fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "ci"},
+		Data:       map[string][]byte{"token": []byte("ghp_secretvariabletoken")},
+	})}
+
+	resolver, err := NewResolver(nil, &PredefinedVariableOptions{ConfigPath: "devspace.yaml", KubeClient: client}, nil, log.Discard)
+	assert.NilError(t, err)
+	resolver.UpdateVars(map[string]*latest.Variable{
+		"NAMESPACE": {Name: "NAMESPACE", Value: "ci"},
+		"TOKEN": {Name: "TOKEN", Secret: &latest.VariableSecret{
+			Provider:  "kubernetes",
+			Name:      "registry",
+			Namespace: "${NAMESPACE}",
+			Key:       "token",
+		}},
+	})
+
+	out, err := resolver.FillVariables(context.Background(), map[string]interface{}{"token": "${TOKEN}"}, true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, out, map[string]interface{}{"token": "ghp_secretvariabletoken"})
+
+	// the resolved value is masked in the log output
+	buffer := &bytes.Buffer{}
+	logger := log.NewStreamLoggerWithFormat(buffer, buffer, logrus.InfoLevel, log.RawFormat)
+	logger.Infof("docker login -p ghp_secretvariabletoken")
+	logger.WriteString(logrus.InfoLevel, "token=ghp_secretvariabletoken\n")
+	assert.Equal(t, buffer.String(), "docker login -p ******\ntoken=******\n")
+}
+
+func TestSensitiveVariable(t *testing.T) {
+	resolver, err := NewResolver(nil, &PredefinedVariableOptions{ConfigPath: "devspace.yaml"}, []string{"DB_PASSWORD=flag-password-value"}, log.Discard)
+	assert.NilError(t, err)
+	resolver.UpdateVars(map[string]*latest.Variable{
+		"API_KEY":     {Name: "API_KEY", Value: "sensitive-api-key", Sensitive: true},
+		"DB_PASSWORD": {Name: "DB_PASSWORD", Password: true},
+		"PUBLIC":      {Name: "PUBLIC", Value: "public-value"},
+	})
+
+	_, err = resolver.FillVariables(context.Background(), map[string]interface{}{"values": "${API_KEY} ${DB_PASSWORD} ${PUBLIC}"}, true)
+	assert.NilError(t, err)
+	assert.Equal(t, log.Mask("sensitive-api-key flag-password-value public-value"), "****** ****** public-value")
+}
@@ -29,6 +29,10 @@ type Resolver interface {
 	// UpdateVars sets the defined variables to use in the resolver
 	UpdateVars(vars map[string]*latest.Variable)
 
+	// SetVariableOrigin sets the path of the file the variable was defined in, which is
+	// shown if the value of the variable is invalid
+	SetVariableOrigin(name, origin string)
+
 	// FindVariables returns all variable names that were found in the given map
 	FindVariables(haystack interface{}) ([]*latest.Variable, error)
 
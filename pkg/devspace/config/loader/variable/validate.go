@@ -0,0 +1,261 @@
+package variable
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/yamlutil"
+)
+
+// validateValue checks the resolved value against the type, minimum, maximum and schema
+// of the variable definition. It returns the value converted to the type of the variable
+// and all validation failures.
+func validateValue(definition *latest.Variable, value interface{}) (interface{}, []string) {
+	if definition == nil || (definition.Type == "" && definition.Minimum == nil && definition.Maximum == nil && definition.Schema == nil) {
+		return value, nil
+	}
+
+	// convert to the type first
+	value, err := convertValue(definition, value)
+	if err != nil {
+		return value, []string{err.Error()}
+	}
+
+	failures := []string{}
+	if definition.Minimum != nil || definition.Maximum != nil {
+		number, ok := toNumber(value)
+		if !ok {
+			failures = append(failures, fmt.Sprintf("value %s is not a number", formatValue(value)))
+		} else if definition.Minimum != nil && number < float64(*definition.Minimum) {
+			failures = append(failures, fmt.Sprintf("value %s is less than the minimum %d", formatValue(value), *definition.Minimum))
+		} else if definition.Maximum != nil && number > float64(*definition.Maximum) {
+			failures = append(failures, fmt.Sprintf("value %s is greater than the maximum %d", formatValue(value), *definition.Maximum))
+		}
+	}
+
+	if definition.Schema != nil {
+		failures = append(failures, validateSchema("", definition.Schema, value)...)
+	}
+
+	return value, failures
+}
+
+// convertValue converts the value to the type of the variable definition
+func convertValue(definition *latest.Variable, value interface{}) (interface{}, error) {
+	switch definition.Type {
+	case latest.VariableTypeString:
+		switch t := value.(type) {
+		case string:
+			return t, nil
+		case int, int64, float64, bool:
+			return fmt.Sprintf("%v", t), nil
+		}
+	case latest.VariableTypeInt:
+		switch t := value.(type) {
+		case int:
+			return t, nil
+		case int64:
+			return int(t), nil
+		case float64:
+			if t == math.Trunc(t) {
+				return int(t), nil
+			}
+		case string:
+			i, err := strconv.Atoi(strings.TrimSpace(t))
+			if err == nil {
+				return i, nil
+			}
+		}
+	case latest.VariableTypeBool:
+		switch t := value.(type) {
+		case bool:
+			return t, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(t))
+			if err == nil {
+				return b, nil
+			}
+		}
+	case latest.VariableTypeList:
+		switch t := value.(type) {
+		case []interface{}:
+			return t, nil
+		case string:
+			list := []interface{}{}
+			err := yamlutil.Unmarshal([]byte(t), &list)
+			if err == nil {
+				return list, nil
+			}
+		}
+	case latest.VariableTypeObject:
+		switch t := value.(type) {
+		case map[string]interface{}:
+			return t, nil
+		case string:
+			object := map[string]interface{}{}
+			err := yamlutil.Unmarshal([]byte(t), &object)
+			if err == nil {
+				return object, nil
+			}
+		}
+	case latest.VariableTypeEnum:
+		for _, option := range definition.Options {
+			if option == fmt.Sprintf("%v", value) {
+				return value, nil
+			}
+		}
+
+		return value, fmt.Errorf("value %s is not one of %s", formatValue(value), strings.Join(definition.Options, ", "))
+	case "":
+		return value, nil
+	default:
+		return value, fmt.Errorf("unknown type %s", definition.Type)
+	}
+
+	return value, fmt.Errorf("value %s is not of type %s", formatValue(value), definition.Type)
+}
+
+// validateSchema validates the value against a subset of JSON schema. Supported keywords are
+// type, enum, minimum, maximum, minLength, maxLength, pattern, items, properties, required
+// and additionalProperties.
+func validateSchema(path string, schema map[string]interface{}, value interface{}) []string {
+	prefix := ""
+	if path != "" {
+		prefix = path + ": "
+	}
+
+	// check type
+	if schemaType, ok := schema["type"].(string); ok && !matchesSchemaType(schemaType, value) {
+		return []string{fmt.Sprintf("%svalue %s is not of type %s", prefix, formatValue(value), schemaType)}
+	}
+
+	failures := []string{}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		options := []string{}
+		for _, option := range enum {
+			options = append(options, fmt.Sprintf("%v", option))
+			if fmt.Sprintf("%v", option) == fmt.Sprintf("%v", value) {
+				found = true
+			}
+		}
+		if !found {
+			failures = append(failures, fmt.Sprintf("%svalue %s is not one of %s", prefix, formatValue(value), strings.Join(options, ", ")))
+		}
+	}
+
+	switch t := value.(type) {
+	case string:
+		if minLength, ok := toNumber(schema["minLength"]); ok && float64(len(t)) < minLength {
+			failures = append(failures, fmt.Sprintf("%svalue %s is shorter than %v characters", prefix, formatValue(t), minLength))
+		}
+		if maxLength, ok := toNumber(schema["maxLength"]); ok && float64(len(t)) > maxLength {
+			failures = append(failures, fmt.Sprintf("%svalue %s is longer than %v characters", prefix, formatValue(t), maxLength))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			expr, err := regexp.Compile(pattern)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%sinvalid pattern %s: %v", prefix, pattern, err))
+			} else if !expr.MatchString(t) {
+				failures = append(failures, fmt.Sprintf("%svalue %s does not match pattern %s", prefix, formatValue(t), pattern))
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range t {
+				failures = append(failures, validateSchema(fmt.Sprintf("%s[%d]", path, i), items, item)...)
+			}
+		}
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, ok := t[fmt.Sprintf("%v", name)]; !ok {
+					failures = append(failures, fmt.Sprintf("%sproperty %v is required", prefix, name))
+				}
+			}
+		}
+
+		keys := []string{}
+		for key := range t {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			propertyPath := key
+			if path != "" {
+				propertyPath = path + "." + key
+			}
+
+			if propertySchema, ok := properties[key].(map[string]interface{}); ok {
+				failures = append(failures, validateSchema(propertyPath, propertySchema, t[key])...)
+			} else if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+				failures = append(failures, fmt.Sprintf("%sproperty %s is not allowed", prefix, key))
+			}
+		}
+	default:
+		if number, ok := toNumber(t); ok {
+			if minimum, ok := toNumber(schema["minimum"]); ok && number < minimum {
+				failures = append(failures, fmt.Sprintf("%svalue %s is less than the minimum %v", prefix, formatValue(t), minimum))
+			}
+			if maximum, ok := toNumber(schema["maximum"]); ok && number > maximum {
+				failures = append(failures, fmt.Sprintf("%svalue %s is greater than the maximum %v", prefix, formatValue(t), maximum))
+			}
+		}
+	}
+
+	return failures
+}
+
+func matchesSchemaType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "integer":
+		number, ok := toNumber(value)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := toNumber(value)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "null":
+		return value == nil
+	}
+
+	return true
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch t := value.(type) {
+	case int:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case float64:
+		return t, true
+	}
+
+	return 0, false
+}
+
+func formatValue(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return strconv.Quote(str)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
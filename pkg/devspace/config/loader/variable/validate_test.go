@@ -0,0 +1,103 @@
+package variable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestTypedVariables(t *testing.T) {
+	minimum, maximum := 1, 5
+	vars := map[string]*latest.Variable{
+		"REPLICAS": {Name: "REPLICAS", Type: latest.VariableTypeInt, Minimum: &minimum, Maximum: &maximum},
+		"DEBUG":    {Name: "DEBUG", Value: "true", Type: latest.VariableTypeBool},
+		"TAG":      {Name: "TAG", Value: 2, Type: latest.VariableTypeString},
+		"PORTS":    {Name: "PORTS", Value: "[8080, 9090]", Type: latest.VariableTypeList, Schema: map[string]interface{}{"items": map[string]interface{}{"type": "integer", "maximum": 65535}}},
+		"STAGE":    {Name: "STAGE", Value: "dev", Type: latest.VariableTypeEnum, Options: []string{"dev", "prod"}},
+	}
+
+	resolver, err := NewResolver(nil, &PredefinedVariableOptions{ConfigPath: "/project/devspace.yaml"}, []string{"REPLICAS=3"}, log.Discard)
+	assert.NilError(t, err)
+	resolver.UpdateVars(vars)
+
+	// values are converted to the variable type
+	out, err := resolver.FillVariables(context.Background(), map[string]interface{}{
+		"replicas": "${REPLICAS}",
+		"debug":    "${DEBUG}",
+		"tag":      "${TAG}",
+		"ports":    "${PORTS}",
+		"stage":    "${STAGE}",
+	}, true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, out, map[string]interface{}{
+		"replicas": 3,
+		"debug":    true,
+		"tag":      "2",
+		"ports":    []interface{}{8080, 9090},
+		"stage":    "dev",
+	})
+
+	// every invalid value is reported with the origin of the variable
+	resolver, err = NewResolver(nil, &PredefinedVariableOptions{ConfigPath: "/project/devspace.yaml"}, []string{"REPLICAS=three", "STAGE=qa"}, log.Discard)
+	assert.NilError(t, err)
+	vars = map[string]*latest.Variable{
+		"REPLICAS": {Name: "REPLICAS", Type: latest.VariableTypeInt, Minimum: &minimum, Maximum: &maximum},
+		"PORTS":    {Name: "PORTS", Value: "[8080, 70000]", Type: latest.VariableTypeList, Schema: map[string]interface{}{"items": map[string]interface{}{"type": "integer", "maximum": 65535}}},
+		"STAGE":    {Name: "STAGE", Type: latest.VariableTypeEnum, Options: []string{"dev", "prod"}},
+	}
+	vars["CONFIG"] = &latest.Variable{Name: "CONFIG", Value: map[string]interface{}{"name": "api", "size": "large"}, Type: latest.VariableTypeObject, Schema: map[string]interface{}{
+		"required":             []interface{}{"name", "image"},
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{"type": "string", "pattern": "^[a-z]+$"},
+		},
+	}}
+	resolver.UpdateVars(vars)
+	resolver.SetVariableOrigin("STAGE", "/project/imports/stages.yaml")
+	resolver.SetVariableOrigin("CONFIG", "/shared/config.yaml")
+
+	_, err = resolver.FillVariables(context.Background(), map[string]interface{}{
+		"replicas": "${REPLICAS}",
+		"ports":    "${PORTS}",
+		"stage":    "${STAGE}",
+		"config":   "${CONFIG}",
+	}, true)
+	assert.Error(t, err, `invalid variable values:
+  CONFIG (defined in /shared/config.yaml): property image is required
+  CONFIG (defined in /shared/config.yaml): property size is not allowed
+  PORTS (defined in devspace.yaml): [1]: value 70000 is greater than the maximum 65535
+  REPLICAS (defined in devspace.yaml): value "three" is not of type int
+  STAGE (defined in imports/stages.yaml): value "qa" is not one of dev, prod`)
+}
+
+func TestTypedVariablesInDefinitions(t *testing.T) {
+	vars := map[string]*latest.Variable{
+		"A": {Name: "A", Value: "3", Type: latest.VariableTypeInt},
+		"B": {Name: "B", Value: "${A}"},
+		"C": {Name: "C", Type: latest.VariableTypeBool},
+		"D": {Name: "D", Value: "${C}"},
+	}
+
+	resolver, err := NewResolver(nil, &PredefinedVariableOptions{ConfigPath: "/project/devspace.yaml"}, []string{"C=yes"}, log.Discard)
+	assert.NilError(t, err)
+	resolver.UpdateVars(vars)
+
+	// variables used in the definition of other variables are converted before they are substituted
+	out, err := resolver.FillVariables(context.Background(), map[string]interface{}{
+		"b": "${B}",
+	}, true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, out, map[string]interface{}{
+		"b": 3,
+	})
+
+	// and validated
+	_, err = resolver.FillVariables(context.Background(), map[string]interface{}{
+		"d": "${D}",
+	}, true)
+	assert.Error(t, err, `invalid variable values:
+  C (defined in devspace.yaml): value "yes" is not of type bool`)
+}
@@ -69,6 +69,16 @@ type ImageCache struct {
 	ImageName              string `yaml:"imageName,omitempty"`
 	LocalRegistryImageName string `yaml:"localRegistryImageName,omitempty"`
 	Tag                    string `yaml:"tag,omitempty"`
+
+	// Platforms are the platforms the image was built for
+	Platforms []string `yaml:"platforms,omitempty"`
+
+	// ImageDigest is the digest of the pushed image if the builder reported it
+	ImageDigest string `yaml:"imageDigest,omitempty"`
+	// AttestationDigest is the digest of the attestations of the last build. For images pushed
+	// by BuildKit this is the digest of the attestation manifest in the image index, comma separated
+	// for each platform, otherwise the digest of the local in-toto statement
+	AttestationDigest string `yaml:"attestationDigest,omitempty"`
 }
 
 func (ic ImageCache) IsLocalRegistryImage() bool {
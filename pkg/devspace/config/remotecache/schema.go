@@ -80,6 +80,14 @@ type DevPodCache struct {
 
 	// TargetName is the parent name of the original parent
 	TargetName string `yaml:"parentName,omitempty"`
+
+	// TargetManifest is the json manifest of the original parent if it is a bare pod,
+	// which is deleted during the replacement and recreated on revert
+	TargetManifest string `yaml:"parentManifest,omitempty"`
+
+	// InterceptTemplate is the json pod template of the original parent before the
+	// intercept proxy was injected, which is restored on revert
+	InterceptTemplate string `yaml:"interceptTemplate,omitempty"`
 }
 
 // DeploymentCache holds the information about a specific deployment
@@ -107,11 +115,26 @@ type HelmCache struct {
 	ChartHash       string `yaml:"chartHash,omitempty"`
 	ValuesHash      string `yaml:"valuesHash,omitempty"`
 	ReleaseRevision string `yaml:"releaseRevision,omitempty"`
+
+	// PreviousReleaseRevision is the revision of the release before the last upgrade
+	// and is used to roll back the release
+	PreviousReleaseRevision string `yaml:"previousReleaseRevision,omitempty"`
 }
 
 type KubectlCache struct {
 	Objects       []KubectlObject `yaml:"kubectlObjects,omitempty"`
 	ManifestsHash string          `yaml:"kubectlManifestsHash,omitempty"`
+
+	// AppliedManifestsHash is the hash of the manifests of the last deploy. The manifests itself
+	// are stored in a separate secret per deployment
+	AppliedManifestsHash string `yaml:"appliedManifestsHash,omitempty"`
+
+	// PreviousManifestsHash is the hash of the manifests that were applied before the last deploy
+	// and are used to roll back the deployment
+	PreviousManifestsHash string `yaml:"previousManifestsHash,omitempty"`
+
+	// PreviousObjects are the objects that were applied before the last deploy
+	PreviousObjects []KubectlObject `yaml:"previousKubectlObjects,omitempty"`
 }
 
 type KubectlObject struct {
@@ -138,7 +138,7 @@ type Import struct {
 // of one or more conditional steps that are executed in order.
 type Pipeline struct {
 	// Name of the pipeline, will be filled automatically
-	Name string `yaml:"name,omitempty" json:"name,omitempty" jsonschema:"enum=dev,enum=deploy,enum=build,enum=purge,enum=.*"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty" jsonschema:"enum=dev,enum=deploy,enum=build,enum=purge,enum=rollback,enum=.*"`
 
 	// Run is the actual shell command that should be executed during this pipeline
 	Run string `yaml:"run,omitempty" json:"run,omitempty" jsonschema:"required"`
@@ -150,6 +150,10 @@ type Pipeline struct {
 	// ContinueOnError will not fail the whole job and pipeline if
 	// a call within the step fails.
 	ContinueOnError bool `yaml:"continueOnError,omitempty" json:"continueOnError,omitempty"`
+
+	// RollbackOnFailure will roll back all deployments that were changed by this pipeline
+	// to their previous revision if the pipeline fails.
+	RollbackOnFailure bool `yaml:"rollbackOnFailure,omitempty" json:"rollbackOnFailure,omitempty"`
 }
 
 // PipelineFlag defines an extra pipeline flag
@@ -275,6 +279,11 @@ type Image struct {
 	// Network is the network that should get used to build the image
 	Network string `yaml:"network,omitempty" json:"network,omitempty" jsonschema_extras:"group=buildConfig"`
 
+	// Platforms are the target platforms of the image, e.g. linux/amd64 or linux/arm64. BuildKit builds all
+	// platforms natively, kaniko builds each platform in its own pod. Both push an OCI image index that
+	// contains the images of all platforms. Only works if buildKit or kaniko is chosen as build method
+	Platforms []string `yaml:"platforms,omitempty" json:"platforms,omitempty" jsonschema_extras:"group=buildConfig"`
+
 	// RebuildStrategy is used to determine when DevSpace should rebuild an image. By default, devspace will
 	// rebuild an image if one of the following conditions is true:
 	// - The dockerfile has changed
@@ -291,6 +300,15 @@ type Image struct {
 	// target namespace. Defaults to true
 	CreatePullSecret *bool `yaml:"createPullSecret,omitempty" json:"createPullSecret,omitempty" jsonschema:"required" jsonschema_extras:"group=pushPull"`
 
+	// Attestations configures the supply-chain metadata that is created for the image. BuildKit attaches
+	// the attestations to the pushed image, all other build engines create an in-toto provenance statement
+	// locally in .devspace/attestations
+	Attestations *Attestations `yaml:"attestations,omitempty" json:"attestations,omitempty" jsonschema_extras:"group=pushPull"`
+
+	// Sign signs the pushed image with a cosign compatible signature. The signatures can be
+	// checked with verify_images before deploying
+	Sign *ImageSign `yaml:"sign,omitempty" json:"sign,omitempty" jsonschema_extras:"group=pushPull"`
+
 	// BuildKit if buildKit is specified, DevSpace will build the image either in-cluster or locally with BuildKit
 	BuildKit *BuildKitConfig `yaml:"buildKit,omitempty" json:"buildKit,omitempty" jsonschema_extras:"group=engines,group_name=Build Engines"`
 
@@ -330,6 +348,51 @@ const (
 	RebuildStrategyIgnoreContextChanges RebuildStrategy = "ignoreContextChanges"
 )
 
+// Attestations holds the attestations that should be created for an image
+type Attestations struct {
+	// SBOM if enabled will create a software bill of materials for the image. Only supported by BuildKit
+	SBOM bool `yaml:"sbom,omitempty" json:"sbom,omitempty"`
+
+	// Provenance if enabled will create a SLSA provenance attestation for the image
+	Provenance bool `yaml:"provenance,omitempty" json:"provenance,omitempty"`
+
+	// ProvenanceMode is the level of detail of the provenance attestation. Defaults to min
+	ProvenanceMode ProvenanceMode `yaml:"provenanceMode,omitempty" json:"provenanceMode,omitempty" jsonschema:"enum=min,enum=max"`
+}
+
+// ProvenanceMode is the type of a provenance mode
+type ProvenanceMode string
+
+// List of values that provenance mode can take
+const (
+	ProvenanceModeMin ProvenanceMode = "min"
+	ProvenanceModeMax ProvenanceMode = "max"
+)
+
+// ImageSign holds the keys to sign and verify an image
+type ImageSign struct {
+	// Key is the path to the pem encoded private key. Keys created with cosign generate-key-pair
+	// are decrypted with the password from the COSIGN_PASSWORD environment variable
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
+
+	// KeyEnv is the name of the environment variable that holds the pem encoded private key
+	KeyEnv string `yaml:"keyEnv,omitempty" json:"keyEnv,omitempty"`
+
+	// PublicKey is the path to the pem encoded public key that is used to verify the image. Defaults
+	// to the public key of the private key
+	PublicKey string `yaml:"publicKey,omitempty" json:"publicKey,omitempty"`
+
+	// PublicKeyEnv is the name of the environment variable that holds the pem encoded public key
+	PublicKeyEnv string `yaml:"publicKeyEnv,omitempty" json:"publicKeyEnv,omitempty"`
+
+	// Annotations are added to the signed payload
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+
+	// Verify checks the signature of the pushed image in the default deploy and dev pipelines
+	// before the deployments are created
+	Verify bool `yaml:"verify,omitempty" json:"verify,omitempty"`
+}
+
 // DockerConfig tells the DevSpace CLI to build with Docker on Minikube or on localhost
 type DockerConfig struct {
 	// DisableFallback allows you to turn off kaniko building if docker isn't installed
@@ -652,6 +715,27 @@ type DeploymentConfig struct {
 
 	// Namespace where to deploy this deployment
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// KubeContext is the kube context this deployment is deployed to. Defaults to the current kube context.
+	KubeContext string `yaml:"kubeContext,omitempty" json:"kubeContext,omitempty"`
+
+	// KubeConfig is the path of the kube config that contains the kube context. Defaults to the kube
+	// config DevSpace uses, e.g. $KUBECONFIG or ~/.kube/config.
+	KubeConfig string `yaml:"kubeConfig,omitempty" json:"kubeConfig,omitempty"`
+
+	// Wait tells DevSpace to wait after deploying until all Deployments, StatefulSets, DaemonSets and
+	// Jobs of this deployment are ready
+	Wait *DeploymentWait `yaml:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// DeploymentWait defines how DevSpace waits for the workloads of a deployment
+type DeploymentWait struct {
+	// Enabled can be used to enable waiting for the workloads. By default, waiting is enabled if
+	// the wait section is defined
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// Timeout is how long to wait (in seconds) for the workloads to become ready. Default is 300 seconds.
+	Timeout int64 `yaml:"timeout,omitempty" json:"timeout,omitempty" jsonschema:"default=300"`
 }
 
 // ComponentConfig holds the component information
@@ -852,8 +936,21 @@ type HelmConfig struct {
 
 	// DisableDependencyUpdate disables helm dependencies update, default to false
 	DisableDependencyUpdate *bool `yaml:"disableDependencyUpdate,omitempty" json:"disableDependencyUpdate,omitempty"`
+
+	// Engine is the way DevSpace talks to helm. Either cli, which executes the helm binary, or sdk, which
+	// uses the helm go sdk and does not need a helm binary. Defaults to cli
+	Engine HelmEngine `yaml:"engine,omitempty" json:"engine,omitempty" jsonschema:"enum=cli,enum=sdk"`
 }
 
+// HelmEngine is the way DevSpace talks to helm
+type HelmEngine string
+
+// List of values that source can take
+const (
+	HelmEngineCLI HelmEngine = "cli"
+	HelmEngineSDK HelmEngine = "sdk"
+)
+
 // ChartConfig defines the helm chart options
 type ChartConfig struct {
 	// Name is the name of the helm chart to deploy. Can also be a local path or an oci url
@@ -894,8 +991,22 @@ type KubectlConfig struct {
 
 	// Patches are additional changes to the pod spec that should be applied
 	Patches []*PatchTarget `yaml:"patches,omitempty" json:"patches,omitempty" jsonschema_extras:"group=modifications"`
+
+	// ApplyMode is the way DevSpace applies the manifests. Either client, which executes `kubectl apply`, or
+	// serverSide, which applies the manifests in-process via server-side apply and does not need a kubectl binary.
+	// Defaults to client
+	ApplyMode KubectlApplyMode `yaml:"applyMode,omitempty" json:"applyMode,omitempty" jsonschema:"enum=client,enum=serverSide"`
 }
 
+// KubectlApplyMode is the way DevSpace applies kubectl manifests
+type KubectlApplyMode string
+
+// List of values that source can take
+const (
+	KubectlApplyModeClient     KubectlApplyMode = "client"
+	KubectlApplyModeServerSide KubectlApplyMode = "serverSide"
+)
+
 // DevPod holds configurations for selecting a pod and starting dev services for that pod
 type DevPod struct {
 	// Name of the dev configuration
@@ -906,6 +1017,10 @@ type DevPod struct {
 	LabelSelector map[string]string `yaml:"labelSelector,omitempty" json:"labelSelector,omitempty" jsonschema_extras:"group=selector"`
 	// Namespace where to select the pod
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty" jsonschema_extras:"group=selector"`
+	// KubeContext is the kube context where to select the pod. Defaults to the current kube context.
+	KubeContext string `yaml:"kubeContext,omitempty" json:"kubeContext,omitempty" jsonschema_extras:"group=selector"`
+	// KubeConfig is the path of the kube config that contains the kube context
+	KubeConfig string `yaml:"kubeConfig,omitempty" json:"kubeConfig,omitempty" jsonschema_extras:"group=selector"`
 
 	// DevContainer can either be defined inline if the pod only has a single container or
 	// containers can be used to define configurations for multiple containers in the same
@@ -922,12 +1037,37 @@ type DevPod struct {
 	// Patches are additional changes to the pod spec that should be applied
 	Patches []*PatchConfig `yaml:"patches,omitempty" json:"patches,omitempty" jsonschema_extras:"group=modifications"`
 
+	// Intercept keeps the original pod running and only routes requests that match certain
+	// headers to the developer. If set, the pod is not replaced and other modifications are ignored.
+	Intercept *InterceptConfig `yaml:"intercept,omitempty" json:"intercept,omitempty" jsonschema_extras:"group=modifications"`
+
 	// Open defines urls that should be opened as soon as they are reachable
 	Open []*OpenConfig `yaml:"open,omitempty" json:"open,omitempty" jsonschema_extras:"group=workflows_background,group_name=Background Dev Workflows"`
 
 	Containers map[string]*DevContainer `yaml:"containers,omitempty" json:"containers,omitempty" jsonschema_extras:"group=selector"`
 }
 
+// InterceptConfig defines how requests to the original pod are intercepted
+type InterceptConfig struct {
+	// Port is the container port of the application whose requests should be intercepted
+	Port int `yaml:"port" json:"port"`
+
+	// Headers are the headers a request needs to match to be routed to the developer, e.g.
+	// x-dev-user: alice. All other requests still reach the original application.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// LocalPort is the port of the local process matching requests are sent to. Defaults to port.
+	LocalPort int `yaml:"localPort,omitempty" json:"localPort,omitempty"`
+
+	// Target is an in-cluster address (host:port), e.g. the service of a dev container, matching
+	// requests are sent to instead of a local process
+	Target string `yaml:"target,omitempty" json:"target,omitempty"`
+
+	// Image is the image used for the intercept proxy. It needs sh and iptables available.
+	// Defaults to devspacesh/intercept:v1
+	Image string `yaml:"image,omitempty" json:"image,omitempty"`
+}
+
 // DevContainer holds options for dev services that should
 // get started within a certain container of the selected pod
 type DevContainer struct {
@@ -1096,14 +1236,38 @@ type PortMapping struct {
 	// you port forward the remote port will be available at the local port.
 	// If you do reverse port forwarding, the local port will be available
 	// at the remote port in the container. If only port is specified, local and
-	// remote port are the same.
-	Port string `yaml:"port" json:"port"`
+	// remote port are the same. Not required for ports that expose a localSocket.
+	Port string `yaml:"port,omitempty" json:"port,omitempty"`
 
 	// BindAddress is the address DevSpace should listen on. Optional and defaults
 	// to localhost.
 	BindAddress string `yaml:"bindAddress,omitempty" json:"bindAddress,omitempty"`
+
+	// Protocol is the protocol of the port, either tcp or udp. Defaults to tcp. UDP ports
+	// are forwarded through the DevSpace helper that is injected into the container.
+	Protocol PortProtocol `yaml:"protocol,omitempty" json:"protocol,omitempty" jsonschema:"enum=tcp,enum=udp"`
+
+	// Target is the address traffic is forwarded to instead of localhost and the port, either
+	// host:port or unix:///path/to/socket. For reversePorts this is an address on the local machine,
+	// e.g. a database running in docker compose. For ports this is an address within the container,
+	// e.g. unix:///var/run/docker.sock.
+	Target string `yaml:"target,omitempty" json:"target,omitempty"`
+
+	// LocalSocket is a local unix socket path DevSpace listens on instead of the local port. Only
+	// valid for ports, e.g. together with target unix:///var/run/docker.sock to use the docker
+	// daemon of the container locally.
+	LocalSocket string `yaml:"localSocket,omitempty" json:"localSocket,omitempty"`
 }
 
+// PortProtocol is the protocol of a forwarded port
+type PortProtocol string
+
+// List of values that protocol can take
+const (
+	PortProtocolTCP PortProtocol = "tcp"
+	PortProtocolUDP PortProtocol = "udp"
+)
+
 // OpenConfig defines what to open after services have been started
 type OpenConfig struct {
 	// URL is the url to open in the browser after it is available
@@ -1144,9 +1308,15 @@ type SyncConfig struct {
 	// WaitInitialSync can be used to tell DevSpace to not wait until the initial sync is done
 	WaitInitialSync *bool `yaml:"waitInitialSync,omitempty" json:"waitInitialSync,omitempty" jsonschema_extras:"group=initial_sync"`
 
-	// InitialSyncCompareBy defines if the sync should only compare by the given type. Either mtime or size are possible
+	// InitialSyncCompareBy defines if the sync should only compare by the given type. Either mtime, size or checksum are possible.
+	// If checksum is used, files with equal size are compared by their contents and changed files are uploaded as block deltas
 	InitialSyncCompareBy InitialSyncCompareBy `yaml:"initialSyncCompareBy,omitempty" json:"initialSyncCompareBy,omitempty" jsonschema_extras:"group=initial_sync"`
 
+	// ConflictStrategy defines how a file is resolved that was changed locally and in the container at the same time.
+	// Either preferLocal, preferRemote, keepBoth or fail are possible. If keepBoth is used, the local file is saved as
+	// <file>.conflict and the container file is downloaded. Defaults to preferLocal
+	ConflictStrategy ConflictStrategy `yaml:"conflictStrategy,omitempty" json:"conflictStrategy,omitempty" jsonschema:"enum=preferLocal,enum=preferRemote,enum=keepBoth,enum=fail"`
+
 	// DisableDownload will disable downloading completely
 	DisableDownload bool `yaml:"disableDownload,omitempty" json:"disableDownload,omitempty" jsonschema_extras:"group=one_direction,group_name=One-Directional Sync"`
 	// DisableUpload will disable uploading completely
@@ -1265,8 +1435,20 @@ type InitialSyncCompareBy string
 
 // List of values that compare by can take
 const (
-	InitialSyncCompareByMTime InitialSyncCompareBy = "mtime"
-	InitialSyncCompareBySize  InitialSyncCompareBy = "size"
+	InitialSyncCompareByMTime    InitialSyncCompareBy = "mtime"
+	InitialSyncCompareBySize     InitialSyncCompareBy = "size"
+	InitialSyncCompareByChecksum InitialSyncCompareBy = "checksum"
+)
+
+// ConflictStrategy is the type of how a sync conflict should be resolved
+type ConflictStrategy string
+
+// List of values that conflict strategy can take
+const (
+	ConflictStrategyPreferLocal  ConflictStrategy = "preferLocal"
+	ConflictStrategyPreferRemote ConflictStrategy = "preferRemote"
+	ConflictStrategyKeepBoth     ConflictStrategy = "keepBoth"
+	ConflictStrategyFail         ConflictStrategy = "fail"
 )
 
 // BandwidthLimits defines the struct for specifying the sync bandwidth limits
@@ -1331,6 +1513,13 @@ type DependencyConfig struct {
 	// Namespace specifies the namespace this dependency should be deployed to
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty" jsonschema_extras:"group=execution"`
 
+	// KubeContext specifies the kube context this dependency should be deployed to. Defaults to the
+	// kube context of the parent project.
+	KubeContext string `yaml:"kubeContext,omitempty" json:"kubeContext,omitempty" jsonschema_extras:"group=execution"`
+
+	// KubeConfig is the path of the kube config that contains the kube context
+	KubeConfig string `yaml:"kubeConfig,omitempty" json:"kubeConfig,omitempty" jsonschema_extras:"group=execution"`
+
 	// Profiles specifies which profiles should be applied while loading the dependency
 	Profiles []string `yaml:"profiles,omitempty" json:"profiles,omitempty" jsonschema:"-"`
 
@@ -1341,15 +1530,28 @@ type DependencyConfig struct {
 // SourceConfig defines an artifact source
 type SourceConfig struct {
 	// Path is the local path where DevSpace can find the artifact.
-	// This option is mutually exclusive with the git option.
+	// This option is mutually exclusive with the git, oci and url option.
 	Path string `yaml:"path,omitempty" json:"path,omitempty" jsonschema_extras:"group=path,group_name=Source: Local Filesystem"`
 
 	// Git is the remote repository to download the artifact from. You can either use
 	// https projects or ssh projects here, but need to make sure git can pull the project.
-	// This option is mutually exclusive with the path option.
+	// This option is mutually exclusive with the path, oci and url option.
 	Git string `yaml:"git,omitempty" json:"git,omitempty" jsonschema_extras:"group=git,group_name=Source: Git Repository"`
 
-	// SubPath is a path within the git repository where the artifact lies in
+	// OCI is an artifact in an OCI registry to download the artifact from, e.g. registry.com/configs/base:v1.
+	// Pin a digest with registry.com/configs/base@sha256:... Layers that are tar archives are extracted,
+	// all other layers are saved with the file name of their org.opencontainers.image.title annotation.
+	// This option is mutually exclusive with the path, git and url option.
+	OCI string `yaml:"oci,omitempty" json:"oci,omitempty" jsonschema_extras:"group=oci,group_name=Source: OCI Artifact"`
+
+	// URL is a http(s) url of a tar or tar.gz archive to download the artifact from.
+	// This option is mutually exclusive with the path, git and oci option.
+	URL string `yaml:"url,omitempty" json:"url,omitempty" jsonschema_extras:"group=url,group_name=Source: Archive URL"`
+
+	// SHA256 is the checksum of the archive downloaded from url and is required for url sources
+	SHA256 string `yaml:"sha256,omitempty" json:"sha256,omitempty" jsonschema_extras:"group=url"`
+
+	// SubPath is a path within the git repository, OCI artifact or archive where the artifact lies in
 	SubPath string `yaml:"subPath,omitempty" json:"subPath,omitempty" jsonschema_extras:"group=git"`
 
 	// Branch is the git branch to pull
@@ -1551,7 +1753,7 @@ type Variable struct {
 	// Options are options that can be selected when the variable question is asked
 	Options []string `yaml:"options,omitempty" json:"options,omitempty" jsonschema_extras:"group=question"`
 
-	// Password signals that this variable should not be visible if entered
+	// Password signals that this variable should not be visible if entered. The value is masked in the log output
 	Password bool `yaml:"password,omitempty" json:"password,omitempty" jsonschema_extras:"group=question"`
 
 	// ValidationPattern can be used to verify the user input
@@ -1574,11 +1776,33 @@ type Variable struct {
 	// system.
 	Commands []VariableCommand `yaml:"commands,omitempty" json:"commands,omitempty" jsonschema_extras:"group=execution"`
 
+	// Secret defines the secret store the variable is loaded from if source is secret. Values loaded from
+	// a secret store are masked in the log output.
+	Secret *VariableSecret `yaml:"secret,omitempty" json:"secret,omitempty" jsonschema_extras:"group=secret,group_name=Value From Secret Store"`
+
 	// AlwaysResolve makes sure this variable will always be resolved and not only if it is used somewhere. Defaults to false.
 	AlwaysResolve *bool `yaml:"alwaysResolve,omitempty" json:"alwaysResolve,omitempty"`
 
+	// Type is the type of the variable value. Either string, int, bool, list, object or enum. Resolved values
+	// are converted to the type if possible, otherwise loading the config fails. Allowed values of an enum are
+	// defined via options
+	Type VariableType `yaml:"type,omitempty" json:"type,omitempty" jsonschema:"enum=string,enum=int,enum=bool,enum=list,enum=object,enum=enum" jsonschema_extras:"group=validation,group_name=Validation"`
+
+	// Minimum is the minimum value of a numeric variable
+	Minimum *int `yaml:"minimum,omitempty" json:"minimum,omitempty" jsonschema_extras:"group=validation"`
+
+	// Maximum is the maximum value of a numeric variable
+	Maximum *int `yaml:"maximum,omitempty" json:"maximum,omitempty" jsonschema_extras:"group=validation"`
+
+	// Schema is a JSON schema the resolved value is validated against
+	Schema map[string]interface{} `yaml:"schema,omitempty" json:"schema,omitempty" jsonschema_extras:"group=validation"`
+
+	// Sensitive marks the value of this variable as secret, which masks it as ****** in the log output and
+	// in devspace print, regardless of the source of the variable
+	Sensitive bool `yaml:"sensitive,omitempty" json:"sensitive,omitempty"`
+
 	// Source defines where the variable should be taken from
-	Source VariableSource `yaml:"source,omitempty" json:"source,omitempty" jsonschema:"enum=all,enum=env,enum=input,enum=command,enum=none"`
+	Source VariableSource `yaml:"source,omitempty" json:"source,omitempty" jsonschema:"enum=all,enum=env,enum=input,enum=command,enum=secret,enum=none"`
 }
 
 func (v *Variable) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -1609,6 +1833,30 @@ func (v *Variable) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// VariableSecret defines a value in a secret store
+type VariableSecret struct {
+	// Provider is the secret store to load the value from. Either sops (sops encrypted files), age (age encrypted files)
+	// or kubernetes (Kubernetes secrets)
+	Provider string `yaml:"provider" json:"provider" jsonschema:"required,enum=sops,enum=age,enum=kubernetes"`
+
+	// File is the path to the encrypted file for the sops and age providers
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+
+	// IdentityFile is the age identity file to decrypt the file with. Defaults to SOPS_AGE_KEY_FILE or
+	// ~/.config/sops/age/keys.txt
+	IdentityFile string `yaml:"identityFile,omitempty" json:"identityFile,omitempty"`
+
+	// Name is the name of the Kubernetes secret
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// Namespace is the namespace of the Kubernetes secret. Defaults to the target namespace
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// Key is the key of the value within the Kubernetes secret or within the decrypted yaml, json or dotenv file.
+	// Nested keys of yaml and json files are separated by dots. If omitted, the whole decrypted file is used
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
+}
+
 type VariableCommand struct {
 	// OperatingSystem is optional and defines the operating system this
 	// command should be executed on
@@ -1622,6 +1870,19 @@ type VariableCommand struct {
 	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
 }
 
+// VariableType is the type of a variable value
+type VariableType string
+
+// List of values that type can take
+const (
+	VariableTypeString VariableType = "string"
+	VariableTypeInt    VariableType = "int"
+	VariableTypeBool   VariableType = "bool"
+	VariableTypeList   VariableType = "list"
+	VariableTypeObject VariableType = "object"
+	VariableTypeEnum   VariableType = "enum"
+)
+
 // VariableSource is type of a variable source
 type VariableSource string
 
@@ -1632,6 +1893,7 @@ const (
 	VariableSourceEnv     VariableSource = "env"
 	VariableSourceInput   VariableSource = "input"
 	VariableSourceCommand VariableSource = "command"
+	VariableSourceSecret  VariableSource = "secret"
 	VariableSourceNone    VariableSource = "none"
 )
 
@@ -2,6 +2,7 @@ package versions
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"strings"
 	"unicode"
@@ -35,6 +36,23 @@ func ValidContainerArch(arch latest.ContainerArchitecture) bool {
 		arch == latest.ContainerArchitectureArm64
 }
 
+// ValidPortProtocol checks if the port protocol is valid
+func ValidPortProtocol(protocol latest.PortProtocol) bool {
+	return protocol == "" ||
+		protocol == latest.PortProtocolTCP ||
+		protocol == latest.PortProtocolUDP
+}
+
+// ValidPortTarget checks if the port target is either a host:port address or a unix socket
+func ValidPortTarget(target string) bool {
+	if strings.HasPrefix(target, "unix://") {
+		return len(target) > len("unix://")
+	}
+
+	_, port, err := net.SplitHostPort(target)
+	return err == nil && port != ""
+}
+
 func Validate(config *latest.Config) error {
 	if config.Name == "" {
 		return fmt.Errorf("you need to specify a name for your devspace.yaml")
@@ -129,6 +147,18 @@ func validateVars(vars map[string]*latest.Variable) error {
 		if encoding.IsUnsafeUpperName(v.Name) {
 			return fmt.Errorf("vars.%s has to match the following regex: %v", i, encoding.UnsafeUpperNameRegEx.String())
 		}
+		switch v.Type {
+		case "", latest.VariableTypeString, latest.VariableTypeInt, latest.VariableTypeBool, latest.VariableTypeList, latest.VariableTypeObject:
+		case latest.VariableTypeEnum:
+			if len(v.Options) == 0 {
+				return fmt.Errorf("vars.%s.options are required for type enum", i)
+			}
+		default:
+			return fmt.Errorf("vars.%s.type %s is invalid, expected one of string, int, bool, list, object or enum", i, v.Type)
+		}
+		if v.Minimum != nil && v.Maximum != nil && *v.Minimum > *v.Maximum {
+			return fmt.Errorf("vars.%s.minimum cannot be greater than vars.%s.maximum", i, i)
+		}
 	}
 
 	return nil
@@ -174,8 +204,11 @@ func validateDependencies(config *latest.Config) error {
 		if dep.Source == nil {
 			return errors.Errorf("dependencies.%s.source is required", name)
 		}
-		if dep.Source.Git == "" && dep.Source.Path == "" {
-			return errors.Errorf("dependencies.%s.git or dependencies[%s].path is required", name, name)
+		if dep.Source.Git == "" && dep.Source.Path == "" && dep.Source.OCI == "" && dep.Source.URL == "" {
+			return errors.Errorf("dependencies.%s.git, dependencies.%s.path, dependencies.%s.oci or dependencies.%s.url is required", name, name, name, name)
+		}
+		if dep.Source.URL != "" && dep.Source.SHA256 == "" {
+			return errors.Errorf("dependencies.%s.sha256 is required for url sources", name)
 		}
 	}
 
@@ -267,6 +300,15 @@ func validateDeployments(config *latest.Config) error {
 		if deployConfig.Kubectl != nil && deployConfig.Helm != nil {
 			return errors.Errorf("deployments[%s].kubectl and deployments[%s].helm cannot be used together", index, index)
 		}
+		if deployConfig.Helm != nil && deployConfig.Helm.Engine != "" && deployConfig.Helm.Engine != latest.HelmEngineCLI && deployConfig.Helm.Engine != latest.HelmEngineSDK {
+			return errors.Errorf("deployments[%s].helm.engine has to be either %s or %s", index, latest.HelmEngineCLI, latest.HelmEngineSDK)
+		}
+		if deployConfig.Kubectl != nil && deployConfig.Kubectl.ApplyMode != "" && deployConfig.Kubectl.ApplyMode != latest.KubectlApplyModeClient && deployConfig.Kubectl.ApplyMode != latest.KubectlApplyModeServerSide {
+			return errors.Errorf("deployments[%s].kubectl.applyMode has to be either %s or %s", index, latest.KubectlApplyModeClient, latest.KubectlApplyModeServerSide)
+		}
+		if deployConfig.Kubectl != nil && deployConfig.Kubectl.ApplyMode == latest.KubectlApplyModeServerSide && len(deployConfig.Kubectl.ApplyArgs) > 0 {
+			return errors.Errorf("deployments[%s].kubectl.applyArgs cannot be used with applyMode %s", index, latest.KubectlApplyModeServerSide)
+		}
 		if deployConfig.Kubectl != nil && deployConfig.Kubectl.Patches != nil {
 			for patch := range deployConfig.Kubectl.Patches {
 				if deployConfig.Kubectl.Patches[patch].Target.Name == "" {
@@ -344,6 +386,12 @@ func validateImages(config *latest.Config) error {
 		if imageConf.RebuildStrategy != "" && imageConf.RebuildStrategy != latest.RebuildStrategyDefault && imageConf.RebuildStrategy != latest.RebuildStrategyAlways && imageConf.RebuildStrategy != latest.RebuildStrategyIgnoreContextChanges {
 			return errors.Errorf("images.%s.rebuildStrategy %s is invalid. Please choose one of %v", imageConfigName, string(imageConf.RebuildStrategy), []latest.RebuildStrategy{latest.RebuildStrategyAlways, latest.RebuildStrategyIgnoreContextChanges})
 		}
+		if len(imageConf.Platforms) > 0 {
+			err := validatePlatforms(imageConfigName, imageConf)
+			if err != nil {
+				return err
+			}
+		}
 		if imageConf.Kaniko != nil && imageConf.Kaniko.EnvFrom != nil {
 			for _, v := range imageConf.Kaniko.EnvFrom {
 				o, err := yaml.Marshal(v)
@@ -363,6 +411,21 @@ func validateImages(config *latest.Config) error {
 	return nil
 }
 
+func validatePlatforms(imageConfigName string, imageConf *latest.Image) error {
+	if imageConf.Custom != nil || (imageConf.BuildKit == nil && (imageConf.Kaniko == nil || imageConf.Docker != nil)) {
+		return errors.Errorf("images.%s.platforms is only supported for buildKit and kaniko builds", imageConfigName)
+	}
+
+	for index, platform := range imageConf.Platforms {
+		parts := strings.Split(platform, "/")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return errors.Errorf("images.%s.platforms[%d] '%s' is invalid, expected os/arch[/variant] such as linux/amd64", imageConfigName, index, platform)
+		}
+	}
+
+	return nil
+}
+
 func validateDev(config *latest.Config) error {
 	for devPodName, devPod := range config.Dev {
 		devPodName = strings.TrimSpace(devPodName)
@@ -384,6 +447,27 @@ func validateDev(config *latest.Config) error {
 			return errors.Errorf("dev.%s: image selector and label selector cannot be used together", devPodName)
 		}
 
+		for index, port := range devPod.Ports {
+			if port.Port == "" && port.LocalSocket == "" {
+				return errors.Errorf("dev.%s.ports[%d].port is required", devPodName, index)
+			} else if port.Port == "" && port.Target == "" {
+				return errors.Errorf("dev.%s.ports[%d]: localSocket needs either target or port to forward to", devPodName, index)
+			}
+			err := validatePortMapping(fmt.Sprintf("dev.%s.ports[%d]", devPodName, index), port)
+			if err != nil {
+				return err
+			}
+		}
+
+		if devPod.Intercept != nil {
+			if devPod.Intercept.Port <= 0 {
+				return errors.Errorf("dev.%s.intercept.port is required", devPodName)
+			}
+			if len(devPod.Intercept.Headers) == 0 {
+				return errors.Errorf("dev.%s.intercept.headers needs at least one header", devPodName)
+			}
+		}
+
 		err := validateDevContainer(fmt.Sprintf("dev.%s", devPodName), &devPod.DevContainer, devPod, false)
 		if err != nil {
 			return err
@@ -448,6 +532,13 @@ func validateDevContainer(path string, devContainer *latest.DevContainer, devPod
 		if port.Port == "" {
 			return errors.Errorf("%s.reversePorts[%d].port is required", path, index)
 		}
+		if port.LocalSocket != "" {
+			return errors.Errorf("%s.reversePorts[%d].localSocket is not supported, please use target instead", path, index)
+		}
+		err := validatePortMapping(fmt.Sprintf("%s.reversePorts[%d]", path, index), port)
+		if err != nil {
+			return err
+		}
 	}
 	for j, p := range devContainer.PersistPaths {
 		if p.Path == "" {
@@ -458,6 +549,20 @@ func validateDevContainer(path string, devContainer *latest.DevContainer, devPod
 	return nil
 }
 
+func validatePortMapping(path string, port *latest.PortMapping) error {
+	if !ValidPortProtocol(port.Protocol) {
+		return errors.Errorf("%s.protocol is not valid '%s', please use either %s or %s", path, port.Protocol, latest.PortProtocolTCP, latest.PortProtocolUDP)
+	}
+	if port.Target != "" && !ValidPortTarget(port.Target) {
+		return errors.Errorf("%s.target is not valid '%s', please use either host:port or unix:///path/to/socket", path, port.Target)
+	}
+	if port.Protocol == latest.PortProtocolUDP && (port.LocalSocket != "" || strings.HasPrefix(port.Target, "unix://")) {
+		return errors.Errorf("%s: unix sockets are not supported for protocol %s", path, latest.PortProtocolUDP)
+	}
+
+	return nil
+}
+
 func validatePodContainerDuplicates(path string, devContainer *latest.DevContainer, devPod *latest.DevPod) error {
 	if devContainer.Container == "" {
 		return nil
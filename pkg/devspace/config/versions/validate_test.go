@@ -173,3 +173,69 @@ func TestValidateDev(t *testing.T) {
 	err = validateDev(config)
 	assert.Error(t, err, "dev.somename.reversePorts will be overwritten by dev.somename.containers[test], please specify dev.somename.containers[test].reversePorts instead")
 }
+
+func TestValidatePortTargets(t *testing.T) {
+	devPod := func(ports, reversePorts []*latest.PortMapping) *latest.Config {
+		return &latest.Config{
+			Dev: map[string]*latest.DevPod{
+				"test": {
+					Name:          "test",
+					LabelSelector: map[string]string{"app": "test"},
+					Ports:         ports,
+					DevContainer: latest.DevContainer{
+						ReversePorts: reversePorts,
+					},
+				},
+			},
+		}
+	}
+
+	err := validateDev(devPod([]*latest.PortMapping{
+		{LocalSocket: "./docker.sock", Target: "unix:///var/run/docker.sock"},
+	}, []*latest.PortMapping{
+		{Port: "5432", Target: "db.local:5432"},
+		{Port: "6000", Target: "unix:///tmp/app.sock"},
+	}))
+	assert.NilError(t, err)
+
+	err = validateDev(devPod([]*latest.PortMapping{{Target: "unix:///var/run/docker.sock"}}, nil))
+	assert.Error(t, err, "dev.test.ports[0].port is required")
+
+	err = validateDev(devPod([]*latest.PortMapping{{LocalSocket: "./docker.sock"}}, nil))
+	assert.Error(t, err, "dev.test.ports[0]: localSocket needs either target or port to forward to")
+
+	err = validateDev(devPod([]*latest.PortMapping{{LocalSocket: "./db.sock", Port: "5432"}}, nil))
+	assert.NilError(t, err)
+
+	err = validateDev(devPod(nil, []*latest.PortMapping{{Port: "5432", Target: "db.local"}}))
+	assert.Error(t, err, "dev.test.reversePorts[0].target is not valid 'db.local', please use either host:port or unix:///path/to/socket")
+
+	err = validateDev(devPod(nil, []*latest.PortMapping{{Port: "5432", LocalSocket: "./db.sock"}}))
+	assert.Error(t, err, "dev.test.reversePorts[0].localSocket is not supported, please use target instead")
+
+	err = validateDev(devPod([]*latest.PortMapping{{LocalSocket: "./dns.sock", Port: "53", Protocol: latest.PortProtocolUDP}}, nil))
+	assert.Error(t, err, "dev.test.ports[0]: unix sockets are not supported for protocol udp")
+}
+
+func TestValidateImagePlatforms(t *testing.T) {
+	image := func(image *latest.Image) *latest.Config {
+		image.Image = "registry.com/api"
+		return &latest.Config{
+			Images: map[string]*latest.Image{
+				"api": image,
+			},
+		}
+	}
+
+	err := validateImages(image(&latest.Image{Platforms: []string{"linux/amd64", "linux/arm64/v8"}, BuildKit: &latest.BuildKitConfig{}}))
+	assert.NilError(t, err)
+
+	err = validateImages(image(&latest.Image{Platforms: []string{"linux/amd64"}, Kaniko: &latest.KanikoConfig{}}))
+	assert.NilError(t, err)
+
+	err = validateImages(image(&latest.Image{Platforms: []string{"linux/amd64"}}))
+	assert.Error(t, err, "images.api.platforms is only supported for buildKit and kaniko builds")
+
+	err = validateImages(image(&latest.Image{Platforms: []string{"linux"}, BuildKit: &latest.BuildKitConfig{}}))
+	assert.Error(t, err, "images.api.platforms[0] 'linux' is invalid, expected os/arch[/variant] such as linux/amd64")
+}
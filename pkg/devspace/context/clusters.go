@@ -0,0 +1,86 @@
+package context
+
+import (
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/kubeconfig"
+	"github.com/pkg/errors"
+)
+
+// clusters holds the kube clients and remote caches of all clusters that were
+// used during a run. It is shared between all contexts derived from the same
+// root context, so that every cluster only has a single client and cache.
+type clusters struct {
+	m sync.Mutex
+
+	clients map[string]kubectl.Client
+	caches  map[string]remotecache.Cache
+
+	// newClient creates the kube client for the given kube config and context
+	newClient func(kubeConfig, kubeContext string) (kubectl.Client, error)
+}
+
+func newClusters() *clusters {
+	return &clusters{
+		clients: map[string]kubectl.Client{},
+		caches:  map[string]remotecache.Cache{},
+		newClient: func(kubeConfig, kubeContext string) (kubectl.Client, error) {
+			return kubectl.NewClientFromContext(kubeContext, "", false, kubeconfig.NewLoaderFromPath(kubeConfig))
+		},
+	}
+}
+
+func (c *context) WithKubeContext(kubeConfig, kubeContext string) (Context, error) {
+	if c == nil {
+		return nil, nil
+	} else if kubeConfig == "" && (kubeContext == "" || (c.kubeClient != nil && c.kubeClient.CurrentContext() == kubeContext)) {
+		return c, nil
+	}
+
+	if kubeConfig != "" {
+		kubeConfig = c.ResolvePath(kubeConfig)
+	}
+	if c.clusters == nil {
+		c.clusters = newClusters()
+	}
+
+	c.clusters.m.Lock()
+	defer c.clusters.m.Unlock()
+
+	key := kubeConfig + "#" + kubeContext
+	client, ok := c.clusters.clients[key]
+	if !ok {
+		var err error
+		client, err = c.clusters.newClient(kubeConfig, kubeContext)
+		if err != nil {
+			return nil, errors.Wrapf(err, "create kube client for context %s", kubeContext)
+		}
+
+		c.clusters.clients[key] = client
+	}
+
+	n := *c
+	n.kubeClient = client
+	if c.config == nil || c.config.Config() == nil {
+		return &n, nil
+	}
+
+	// every cluster has its own remote cache
+	cacheKey := key + "#" + c.config.Config().Name
+	remoteCache, ok := c.clusters.caches[cacheKey]
+	if !ok {
+		var err error
+		remoteCache, err = remotecache.NewCacheLoader(c.config.Config().Name).Load(c.Context(), client)
+		if err != nil {
+			return nil, errors.Wrapf(err, "load remote cache of context %s", client.CurrentContext())
+		}
+
+		c.clusters.caches[cacheKey] = remoteCache
+	}
+
+	n.config = config.WithRemoteCache(c.config, remoteCache)
+	return &n, nil
+}
@@ -0,0 +1,60 @@
+package context
+
+import (
+	context2 "context"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWithKubeContext(t *testing.T) {
+	rootCache := remotecache.NewCache("test", "devspace-cache-test")
+	conf := config.NewConfig(map[string]interface{}{}, map[string]interface{}{}, &latest.Config{Name: "test"}, &localcache.LocalCache{}, rootCache, map[string]interface{}{}, constants.DefaultConfigPath)
+	ctx := NewContext(context2.TODO(), nil, log.Discard).WithKubeClient(&fakekube.Client{Client: fake.NewSimpleClientset(), Context: "default"}).WithConfig(conf)
+
+	// create fake clients for the clusters
+	created := []string{}
+	ctx.(*context).clusters.newClient = func(kubeConfig, kubeContext string) (kubectl.Client, error) {
+		created = append(created, kubeConfig+"#"+kubeContext)
+		return &fakekube.Client{Client: fake.NewSimpleClientset(), Context: kubeContext}, nil
+	}
+
+	// the current cluster is used as is
+	for _, kubeContext := range []string{"", "default"} {
+		clusterCtx, err := ctx.WithKubeContext("", kubeContext)
+		assert.NilError(t, err)
+		assert.Equal(t, clusterCtx, ctx)
+	}
+
+	// other clusters get their own client and remote cache
+	otherCtx, err := ctx.WithKubeContext("", "other")
+	assert.NilError(t, err)
+	assert.Equal(t, otherCtx.KubeClient().CurrentContext(), "other")
+	assert.Assert(t, otherCtx.Config().RemoteCache() != rootCache)
+	assert.Equal(t, ctx.Config().RemoteCache(), rootCache)
+
+	// clients and remote caches are cached per kube config and context, also in derived contexts
+	cachedCtx, err := ctx.WithLogger(log.Discard).WithKubeContext("", "other")
+	assert.NilError(t, err)
+	assert.Equal(t, cachedCtx.KubeClient(), otherCtx.KubeClient())
+	assert.Equal(t, cachedCtx.Config().RemoteCache(), otherCtx.Config().RemoteCache())
+
+	kubeConfigCtx, err := ctx.WithKubeContext("kube.yaml", "other")
+	assert.NilError(t, err)
+	assert.Assert(t, kubeConfigCtx.KubeClient() != otherCtx.KubeClient())
+	assert.Assert(t, kubeConfigCtx.Config().RemoteCache() != otherCtx.Config().RemoteCache())
+
+	_, err = ctx.WithKubeContext("kube.yaml", "other")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, created, []string{"#other", filepath.Join(ctx.WorkingDir(), "kube.yaml") + "#other"})
+}
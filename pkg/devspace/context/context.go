@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/env"
@@ -35,6 +36,7 @@ func NewContext(ctx context2.Context, variables map[string]interface{}, log log.
 		runID:      strings.ToLower(randutil.GenerateRandomString(12)),
 		environ:    env.NewVariableEnvProvider(expand.ListEnviron(os.Environ()...), env.ConvertMap(variables)),
 		log:        log,
+		clusters:   newClusters(),
 	}
 }
 
@@ -87,6 +89,10 @@ type Context interface {
 
 	WithNewTomb() (Context, *tomb.Tomb)
 	WithKubeClient(client kubectl.Client) Context
+	// WithKubeContext returns a context that uses the kube client and the remote cache
+	// of the given kube context and kube config. If both are empty, the context is returned
+	// unchanged.
+	WithKubeContext(kubeConfig, kubeContext string) (Context, error)
 	WithWorkingDir(workingDir string) Context
 	WithConfig(conf config.Config) Context
 	WithDependencies(dependencies []types.Dependency) Context
@@ -124,6 +130,9 @@ type context struct {
 
 	// log is the currently used logger
 	log log.Logger
+
+	// clusters are the clients and caches of all used clusters
+	clusters *clusters
 }
 
 func (c *context) Environ() expand.Environ {
@@ -131,6 +140,15 @@ func (c *context) Environ() expand.Environ {
 }
 
 func (c *context) Context() context2.Context {
+	// remote sources that are resolved during the pipeline, e.g. helm charts,
+	// are locked in the lockfile of the config
+	if c.context != nil && c.config != nil {
+		lock := c.config.Lockfile()
+		if lock != nil && lockfile.FromContext(c.context) != lock {
+			return lockfile.WithLockfile(c.context, lock)
+		}
+	}
+
 	return c.context
 }
 
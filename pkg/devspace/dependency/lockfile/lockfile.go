@@ -0,0 +1,210 @@
+package lockfile
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/util/yamlutil"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the lockfile that is written next to the devspace.yaml
+const FileName = "devspace.lock"
+
+// Version is the current version of the lockfile format
+const Version = "v1"
+
+// updateCommand is the command that refreshes the lockfile
+const updateCommand = "devspace update dependencies"
+
+// Lockfile records the resolved revisions of remote imports, dependencies and profile parents
+type Lockfile struct {
+	Version string             `yaml:"version"`
+	Sources map[string]*Source `yaml:"sources,omitempty"`
+
+	path    string
+	options Options
+	changed bool
+	used    map[string]bool
+	m       sync.Mutex
+}
+
+// Source is the locked state of a single remote source
+type Source struct {
+	// Revision is the resolved commit of a git source
+	Revision string `yaml:"revision,omitempty"`
+	// Digest is the content digest of an oci, url or path source
+	Digest string `yaml:"digest,omitempty"`
+}
+
+// Options define how the locked revisions are used
+type Options struct {
+	// Frozen fails instead of changing the lockfile
+	Frozen bool
+	// Update ignores the locked revisions and resolves every source again
+	Update bool
+}
+
+// Load reads the lockfile at the given path. A missing lockfile is treated as empty.
+func Load(path string, options Options) (*Lockfile, error) {
+	lockfile := &Lockfile{
+		Version: Version,
+		Sources: map[string]*Source{},
+		path:    path,
+		options: options,
+		used:    map[string]bool{},
+	}
+
+	out, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return lockfile, nil
+		}
+
+		return nil, errors.Wrap(err, "read lockfile")
+	}
+
+	err = yamlutil.Unmarshal(out, lockfile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse lockfile %s", path)
+	} else if lockfile.Version != Version {
+		return nil, errors.Errorf("unsupported lockfile version %s in %s, expected %s", lockfile.Version, path, Version)
+	}
+	if lockfile.Sources == nil {
+		lockfile.Sources = map[string]*Source{}
+	}
+
+	return lockfile, nil
+}
+
+// Path returns the path of the lockfile
+func (l *Lockfile) Path() string {
+	if l == nil {
+		return ""
+	}
+
+	return l.path
+}
+
+// Updating returns true if the locked revisions should be refreshed
+func (l *Lockfile) Updating() bool {
+	return l != nil && l.options.Update
+}
+
+// Get returns the locked state of the source or nil if the source is not locked or
+// the lockfile is refreshed
+func (l *Lockfile) Get(key string) *Source {
+	if l == nil {
+		return nil
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.used[key] = true
+	if l.options.Update {
+		return nil
+	}
+
+	source, ok := l.Sources[key]
+	if !ok {
+		return nil
+	}
+
+	copied := *source
+	return &copied
+}
+
+// Set records the resolved state of the source. If the lockfile is frozen, Set
+// returns an error if the resolved state differs from the locked one.
+func (l *Lockfile) Set(key string, source Source) error {
+	if l == nil {
+		return nil
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.used[key] = true
+	locked, ok := l.Sources[key]
+	if ok && *locked == source {
+		return nil
+	} else if l.options.Frozen {
+		if !ok {
+			return errors.Errorf("%s is out of date: %s is not locked, please run '%s'", FileName, key, updateCommand)
+		}
+
+		return errors.Errorf("%s is out of date: %s is locked to %s but resolved to %s, please run '%s'", FileName, key, locked.String(), source.String(), updateCommand)
+	}
+
+	l.Sources[key] = &source
+	l.changed = true
+	return nil
+}
+
+// Prune removes all sources that were not used since the lockfile was loaded
+func (l *Lockfile) Prune() {
+	if l == nil {
+		return
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	for key := range l.Sources {
+		if !l.used[key] {
+			delete(l.Sources, key)
+			l.changed = true
+		}
+	}
+}
+
+// Save writes the lockfile if it has changed
+func (l *Lockfile) Save() error {
+	if l == nil {
+		return nil
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if !l.changed {
+		return nil
+	}
+
+	out, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(l.path, out, 0644)
+	if err != nil {
+		return errors.Wrap(err, "write lockfile")
+	}
+
+	l.changed = false
+	return nil
+}
+
+func (s *Source) String() string {
+	if s.Revision != "" {
+		return s.Revision
+	}
+
+	return s.Digest
+}
+
+type lockfileKey struct{}
+
+// WithLockfile returns a copy of the context that holds the lockfile
+func WithLockfile(ctx context.Context, lockfile *Lockfile) context.Context {
+	return context.WithValue(ctx, lockfileKey{}, lockfile)
+}
+
+// FromContext returns the lockfile of the context or nil if there is none
+func FromContext(ctx context.Context) *Lockfile {
+	lockfile, _ := ctx.Value(lockfileKey{}).(*Lockfile)
+	return lockfile
+}
@@ -0,0 +1,66 @@
+package lockfile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestLockfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	lockfile, err := Load(path, Options{})
+	assert.NilError(t, err)
+	assert.Assert(t, lockfile.Get("git:https://github.com/org/repo@main") == nil)
+
+	// nothing is written as long as nothing was locked
+	assert.NilError(t, lockfile.Save())
+	_, err = os.Stat(path)
+	assert.Assert(t, os.IsNotExist(err))
+
+	assert.NilError(t, lockfile.Set("git:https://github.com/org/repo@main", Source{Revision: "1111"}))
+	assert.NilError(t, lockfile.Set("oci:ghcr.io/org/config:v1", Source{Digest: "sha256:2222"}))
+	assert.NilError(t, lockfile.Save())
+
+	out, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), `version: v1
+sources:
+    git:https://github.com/org/repo@main:
+        revision: "1111"
+    oci:ghcr.io/org/config:v1:
+        digest: sha256:2222
+`)
+
+	// locked sources are returned
+	lockfile, err = Load(path, Options{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, lockfile.Get("git:https://github.com/org/repo@main"), &Source{Revision: "1111"})
+
+	// frozen lockfiles fail for changed or missing sources
+	frozen, err := Load(path, Options{Frozen: true})
+	assert.NilError(t, err)
+	assert.NilError(t, frozen.Set("git:https://github.com/org/repo@main", Source{Revision: "1111"}))
+	err = frozen.Set("git:https://github.com/org/repo@main", Source{Revision: "3333"})
+	assert.Error(t, err, "devspace.lock is out of date: git:https://github.com/org/repo@main is locked to 1111 but resolved to 3333, please run 'devspace update dependencies'")
+	err = frozen.Set("url:https://example.com/config.tar.gz", Source{Digest: "sha256:4444"})
+	assert.Error(t, err, "devspace.lock is out of date: url:https://example.com/config.tar.gz is not locked, please run 'devspace update dependencies'")
+
+	// updating ignores the locked sources and prunes unused ones
+	update, err := Load(path, Options{Update: true})
+	assert.NilError(t, err)
+	assert.Assert(t, update.Get("git:https://github.com/org/repo@main") == nil)
+	assert.NilError(t, update.Set("git:https://github.com/org/repo@main", Source{Revision: "3333"}))
+	update.Prune()
+	assert.NilError(t, update.Save())
+
+	lockfile, err = Load(path, Options{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, lockfile.Sources, map[string]*Source{"git:https://github.com/org/repo@main": {Revision: "3333"}})
+
+	// the lockfile is passed through the context
+	assert.Assert(t, FromContext(context.Background()) == nil)
+	assert.Equal(t, FromContext(WithLockfile(context.Background(), lockfile)), lockfile)
+}
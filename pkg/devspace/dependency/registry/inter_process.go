@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+
+	"github.com/loft-sh/devspace/pkg/devspace/services/sync"
+	"github.com/pkg/errors"
 )
 
 type ExcludePayload struct {
@@ -27,6 +30,9 @@ type InterProcess interface {
 
 	// ExcludeDependency tells the remote server to exclude a certain dependency
 	ExcludeDependency(ctx context.Context, server string, excludePayload *ExcludePayload) (bool, error)
+
+	// SyncStatus retrieves the status of the syncs that are running in the remote server
+	SyncStatus(ctx context.Context, server string, payload *PingPayload) ([]*sync.Status, error)
 }
 
 func NewInterProcessCommunicator() InterProcess {
@@ -78,3 +84,32 @@ func (d *requester) ExcludeDependency(ctx context.Context, server string, exclud
 	}
 	return true, nil
 }
+
+func (d *requester) SyncStatus(ctx context.Context, server string, payload *PingPayload) ([]*sync.Status, error) {
+	out, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", server+"/api/sync/status", bytes.NewReader(out))
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	statuses := []*sync.Status{}
+	err = json.NewDecoder(response.Body).Decode(&statuses)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode sync status")
+	}
+
+	return statuses, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/graph"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,6 +31,50 @@ type ownership struct {
 	RunID  string `yaml:"runID,omitempty" json:"runID,omitempty"`
 }
 
+// Instance is a running DevSpace process that has locked a dependency
+type Instance struct {
+	// Server is the address of the ui server of the process
+	Server string
+
+	// RunID is the run id of the process
+	RunID string
+}
+
+// FindInstance looks up the process that has locked the dependency with the given name and pings
+// it to check if it is still running. Returns nil if there is no such process.
+func FindInstance(ctx context.Context, client kubectl.Client, name string, interProcess InterProcess) (*Instance, error) {
+	configMap, err := client.KubeClient().CoreV1().ConfigMaps(client.Namespace()).Get(ctx, configMapName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	} else if configMap.Data[name] == "" {
+		return nil, nil
+	}
+
+	payload := &ownership{}
+	err = yaml.Unmarshal([]byte(configMap.Data[name]), payload)
+	if err != nil || payload.Server == "" || payload.RunID == "" {
+		return nil, nil
+	}
+
+	pingCtx, pingCancel := context.WithTimeout(ctx, time.Second*2)
+	defer pingCancel()
+	pinged, err := interProcess.Ping(pingCtx, payload.Server, &PingPayload{
+		RunID: payload.RunID,
+	})
+	if !pinged || err != nil {
+		return nil, nil
+	}
+
+	return &Instance{
+		Server: payload.Server,
+		RunID:  payload.RunID,
+	}, nil
+}
+
 type DependencyRegistry interface {
 	// TryLockDependencies tries to lock the given dependencies and returns the dependencies that were locked
 	TryLockDependencies(ctx devspacecontext.Context, fromDependency string, dependencyNames []string, forceLeader bool) (map[string]LockType, error)
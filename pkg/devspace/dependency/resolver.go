@@ -10,6 +10,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/graph"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
 	"github.com/loft-sh/devspace/pkg/util/kubeconfig"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config"
@@ -18,6 +19,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/util"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/util/stringutil"
 	"github.com/pkg/errors"
 )
@@ -63,11 +65,27 @@ func NewResolver(ctx devspacecontext.Context, configOptions *loader.ConfigOption
 
 // Resolve implements interface
 func (r *resolver) Resolve(ctx devspacecontext.Context, options ResolveOptions) ([]types.Dependency, error) {
+	spanCtx, span := tracing.Start(ctx.Context(), tracing.CategoryDependency, "resolve dependencies")
+	defer span.End()
+	ctx = ctx.WithContext(spanCtx)
+
 	currentWorkingDirectory, err := os.Getwd()
 	if err != nil {
 		return nil, errors.Wrap(err, "get current working directory")
 	}
 
+	// dependencies are locked in the devspace.lock of the root config
+	lock := lockfile.FromContext(ctx.Context())
+	ownLock := lock == nil
+	if ownLock {
+		lock, err = lockfile.Load(filepath.Join(filepath.Dir(ctx.Config().Path()), lockfile.FileName), lockfile.Options{Frozen: r.ConfigOptions != nil && r.ConfigOptions.FrozenLockfile})
+		if err != nil {
+			return nil, err
+		}
+
+		ctx = ctx.WithContext(lockfile.WithLockfile(ctx.Context(), lock))
+	}
+
 	// r.DependencyGraph.Root.ID == name here
 	err = r.resolveRecursive(ctx, currentWorkingDirectory, r.DependencyGraph.Root.ID, nil, transformMap(r.BaseConfig.Dependencies), options)
 	if err != nil {
@@ -78,6 +96,13 @@ func (r *resolver) Resolve(ctx devspacecontext.Context, options ResolveOptions)
 		return nil, err
 	}
 
+	if ownLock {
+		err = lock.Save()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Save local cache
 	err = r.BaseCache.Save()
 	if err != nil {
@@ -130,7 +155,10 @@ func (r *resolver) resolveRecursive(ctx devspacecontext.Context, basePath, paren
 			continue
 		}
 
+		_, downloadSpan := tracing.Start(ctx.Context(), tracing.CategoryDependency, "download dependency "+dependencyConfig.Name)
 		dependencyConfigPath, err := util.DownloadDependency(ctx.Context(), basePath, dependencyConfig.Source, ctx.Log())
+		downloadSpan.SetError(err)
+		downloadSpan.End()
 		if err != nil {
 			return err
 		}
@@ -154,7 +182,10 @@ func (r *resolver) resolveRecursive(ctx devspacecontext.Context, basePath, paren
 				ctx.Log().Debugf(err.Error())
 			}
 		} else {
-			child, err = r.resolveDependency(ctx, dependencyConfigPath, dependencyConfig.Name, dependencyConfig)
+			loadCtx, loadSpan := tracing.Start(ctx.Context(), tracing.CategoryDependency, "load dependency "+dependencyConfig.Name)
+			child, err = r.resolveDependency(ctx.WithContext(loadCtx), dependencyConfigPath, dependencyConfig.Name, dependencyConfig)
+			loadSpan.SetError(err)
+			loadSpan.End()
 			if err != nil {
 				return err
 			}
@@ -220,13 +251,19 @@ func (r *resolver) resolveDependency(ctx devspacecontext.Context, dependencyConf
 		cloned.Vars = append(cloned.Vars, strings.TrimSpace(k)+"="+strings.TrimSpace(v))
 	}
 
+	// the dependency might target another cluster
+	clusterCtx, err := ctx.WithKubeContext(dependency.KubeConfig, dependency.KubeContext)
+	if err != nil {
+		return nil, err
+	}
+
 	// recreate client if necessary
-	client := ctx.KubeClient()
+	client := clusterCtx.KubeClient()
 	if dependency.Namespace != "" {
-		if ctx.KubeClient() == nil {
+		if client == nil {
 			client, err = kubectl.NewClientFromContext("", dependency.Namespace, false, kubeconfig.NewLoader())
 		} else {
-			client, err = kubectl.NewClientFromContext(client.CurrentContext(), dependency.Namespace, false, ctx.KubeClient().KubeConfigLoader())
+			client, err = kubectl.NewClientFromContext(client.CurrentContext(), dependency.Namespace, false, client.KubeConfigLoader())
 		}
 		if err != nil {
 			return nil, errors.Wrap(err, "create new client")
@@ -0,0 +1,184 @@
+package util
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// URLFolder is the folder within the dependency folder that holds the downloaded archives by checksum
+const URLFolder = "url"
+
+// urlPath returns the folder the archive with the checksum is extracted to
+func urlPath(source *latest.SourceConfig) (string, error) {
+	checksum := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(source.SHA256), "sha256:"))
+	if checksum == "" {
+		return "", errors.Errorf("sha256 is required for url %s", source.URL)
+	} else if len(checksum) != sha256.Size*2 {
+		return "", errors.Errorf("sha256 '%s' of url %s is not a valid sha256 checksum", source.SHA256, source.URL)
+	}
+
+	return filepath.Join(DependencyFolderPath, URLFolder, "sha256-"+checksum), nil
+}
+
+// downloadURL downloads and extracts the archive into the dependency folder and returns the local path.
+// Archives are stored by checksum, so an archive that was downloaded once is never downloaded again.
+func downloadURL(ctx context.Context, source *latest.SourceConfig, log log.Logger) (string, error) {
+	localPath, err := urlPath(source)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = os.Stat(localPath)
+	if err == nil {
+		return localPath, nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(localPath), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	archive, err := os.CreateTemp(filepath.Dir(localPath), ".archive-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", errors.Wrapf(err, "request %s", source.URL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("request %s: unexpected status code %d", source.URL, resp.StatusCode)
+	}
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(archive, hash), resp.Body)
+	if err != nil {
+		return "", errors.Wrapf(err, "download %s", source.URL)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if "sha256-"+checksum != filepath.Base(localPath) {
+		return "", errors.Errorf("checksum mismatch for %s: expected sha256 %s, but got %s", source.URL, strings.TrimPrefix(filepath.Base(localPath), "sha256-"), checksum)
+	}
+
+	_, err = archive.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", err
+	}
+
+	// extract into a temporary folder first, so that we never use a partially extracted archive
+	tempDir, err := os.MkdirTemp(filepath.Dir(localPath), ".download-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	err = extractArchive(archive, tempDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "extract %s", source.URL)
+	}
+
+	err = os.Rename(tempDir, localPath)
+	if err != nil {
+		return "", err
+	}
+
+	log.Debugf("Downloaded %s", source.URL)
+	return localPath, nil
+}
+
+// extractArchive extracts a tar or gzip compressed tar archive into dir. Only directories and
+// regular files are extracted.
+func extractArchive(reader io.Reader, dir string) error {
+	buffered := bufio.NewReader(reader)
+	magic, _ := buffered.Peek(2)
+
+	var tarReader *tar.Reader
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+
+		tarReader = tar.NewReader(gzipReader)
+	} else {
+		tarReader = tar.NewReader(buffered)
+	}
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		target, err := archivePath(dir, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+			if err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			err = os.MkdirAll(filepath.Dir(target), 0755)
+			if err != nil {
+				return err
+			}
+
+			err = extractFile(tarReader, target, header.FileInfo().Mode().Perm()|0600)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractFile(reader io.Reader, target string, mode os.FileMode) error {
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, reader)
+	return err
+}
+
+// archivePath returns the path of the archive entry within dir and makes sure it does not leave dir
+func archivePath(dir, name string) (string, error) {
+	target := filepath.Join(dir, filepath.FromSlash(name))
+	if target != dir && !strings.HasPrefix(target, dir+string(filepath.Separator)) {
+		return "", errors.Errorf("illegal path %s in archive", name)
+	}
+
+	return target, nil
+}
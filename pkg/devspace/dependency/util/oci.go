@@ -0,0 +1,209 @@
+package util
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
+	"github.com/loft-sh/devspace/pkg/util/encoding"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// OCIFolder is the folder within the dependency folder that holds the OCI artifacts by digest
+const OCIFolder = "oci"
+
+// ociTitleAnnotation is the annotation that holds the file name of an artifact layer
+const ociTitleAnnotation = "org.opencontainers.image.title"
+
+// ociPath returns the folder the OCI artifact with the digest is extracted to
+func ociPath(digest string) string {
+	return filepath.Join(DependencyFolderPath, OCIFolder, strings.Replace(digest, ":", "-", 1))
+}
+
+// ociReferenceFile returns the file that holds the last resolved digest of an OCI tag
+func ociReferenceFile(reference string) string {
+	return filepath.Join(DependencyFolderPath, OCIFolder, encoding.Convert(reference)+".digest")
+}
+
+// getOCIPath returns the local path of an already downloaded OCI artifact
+func getOCIPath(source *latest.SourceConfig) (string, error) {
+	ref, err := name.ParseReference(source.OCI)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse oci reference %s", source.OCI)
+	} else if digest, ok := ref.(name.Digest); ok {
+		return ociPath(digest.DigestStr()), nil
+	}
+
+	digest, err := os.ReadFile(ociReferenceFile(source.OCI))
+	if err != nil {
+		return "", errors.Errorf("oci artifact %s was not downloaded yet", source.OCI)
+	}
+
+	return ociPath(string(digest)), nil
+}
+
+// resolveOCIDigest returns the digest of the OCI artifact. Tags are resolved in the registry, unless pulling is
+// disabled and the tag was resolved before.
+func resolveOCIDigest(ctx context.Context, source *latest.SourceConfig, log log.Logger) (name.Digest, error) {
+	ref, err := name.ParseReference(source.OCI)
+	if err != nil {
+		return name.Digest{}, errors.Wrapf(err, "parse oci reference %s", source.OCI)
+	} else if digest, ok := ref.(name.Digest); ok {
+		return digest, nil
+	}
+
+	referenceFile := ociReferenceFile(source.OCI)
+	previous, _ := os.ReadFile(referenceFile)
+	if source.DisablePull && len(previous) > 0 {
+		return ref.Context().Digest(string(previous)), nil
+	}
+
+	descriptor, err := remote.Head(ref, ociRemoteOptions(ctx)...)
+	if err != nil {
+		if len(previous) > 0 {
+			log.Warnf("Error resolving oci artifact %s, will use previously resolved digest %s: %v", source.OCI, string(previous), err)
+			return ref.Context().Digest(string(previous)), nil
+		}
+
+		return name.Digest{}, errors.Wrapf(err, "resolve oci artifact %s", source.OCI)
+	}
+
+	err = os.MkdirAll(filepath.Dir(referenceFile), 0755)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	err = os.WriteFile(referenceFile, []byte(descriptor.Digest.String()), 0644)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	return ref.Context().Digest(descriptor.Digest.String()), nil
+}
+
+// downloadOCI pulls the OCI artifact into the dependency folder and returns the local path and the digest.
+// Artifacts are stored by digest, so a digest that was downloaded once is never pulled again.
+func downloadOCI(ctx context.Context, source *latest.SourceConfig, log log.Logger) (string, string, error) {
+	digest, err := resolveOCIDigest(ctx, source, log)
+	if err != nil {
+		return "", "", err
+	}
+
+	localPath := ociPath(digest.DigestStr())
+	_, err = os.Stat(localPath)
+	if err == nil {
+		return localPath, digest.DigestStr(), nil
+	}
+
+	image, err := remote.Image(digest, ociRemoteOptions(ctx)...)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "pull oci artifact %s", source.OCI)
+	}
+
+	manifest, err := image.Manifest()
+	if err != nil {
+		return "", "", errors.Wrapf(err, "pull oci artifact %s", source.OCI)
+	}
+
+	err = os.MkdirAll(filepath.Dir(localPath), 0755)
+	if err != nil {
+		return "", "", err
+	}
+
+	// extract into a temporary folder first, so that we never use a partially extracted artifact
+	tempDir, err := os.MkdirTemp(filepath.Dir(localPath), ".download-")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	for _, descriptor := range manifest.Layers {
+		err = extractOCILayer(image, descriptor, tempDir)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "extract layer %s of oci artifact %s", descriptor.Digest.String(), source.OCI)
+		}
+	}
+
+	err = os.Rename(tempDir, localPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	log.Debugf("Pulled %s", digest.String())
+	return localPath, digest.DigestStr(), nil
+}
+
+// lockedOCISource returns a copy of the source that references the locked digest instead of the tag
+func lockedOCISource(source *latest.SourceConfig, locked *lockfile.Source) *latest.SourceConfig {
+	if locked == nil || locked.Digest == "" {
+		return source
+	}
+
+	ref, err := name.ParseReference(source.OCI)
+	if err != nil {
+		return source
+	} else if _, ok := ref.(name.Digest); ok {
+		return source
+	}
+
+	copied := *source
+	copied.OCI = ref.Context().Digest(locked.Digest).String()
+	return &copied
+}
+
+func extractOCILayer(image v1.Image, descriptor v1.Descriptor, dir string) error {
+	layer, err := image.LayerByDigest(descriptor.Digest)
+	if err != nil {
+		return err
+	}
+
+	reader, err := layer.Compressed()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if strings.Contains(string(descriptor.MediaType), "tar") {
+		return extractArchive(reader, dir)
+	}
+
+	title := descriptor.Annotations[ociTitleAnnotation]
+	if title == "" {
+		return errors.Errorf("layer is neither a tar archive nor has a %s annotation", ociTitleAnnotation)
+	}
+
+	target, err := archivePath(dir, title)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, reader)
+	return err
+}
+
+func ociRemoteOptions(ctx context.Context) []remote.Option {
+	return []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
 	"github.com/loft-sh/devspace/pkg/util/git"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/mitchellh/go-homedir"
@@ -36,16 +38,30 @@ func init() {
 // downloadMutex makes sure we only download a single dependency at a time
 var downloadMutex = sync.Mutex{}
 
-func GetDependencyPath(workingDirectory string, source *latest.SourceConfig) (configPath string, err error) {
+// GetDependencyPath returns the config path of a dependency that was downloaded with DownloadDependency before.
+// Remote sources that are pinned in the lockfile of the context resolve to the path of the locked revision.
+func GetDependencyPath(ctx context.Context, workingDirectory string, source *latest.SourceConfig) (configPath string, err error) {
 	ID, err := GetDependencyID(source)
 	if err != nil {
 		return "", err
 	}
 
+	locked := lockfile.FromContext(ctx).Get(getLockKey(source))
+
 	// Resolve source
 	var localPath string
 	if source.Git != "" {
 		localPath = filepath.Join(DependencyFolderPath, ID)
+	} else if source.OCI != "" {
+		localPath, err = getOCIPath(lockedOCISource(source, locked))
+		if err != nil {
+			return "", err
+		}
+	} else if source.URL != "" {
+		localPath, err = urlPath(source)
+		if err != nil {
+			return "", err
+		}
 	} else if source.Path != "" {
 		if isURL(source.Path) {
 			localPath = filepath.Join(DependencyFolderPath, ID)
@@ -87,6 +103,11 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 		return "", err
 	}
 
+	// remote sources are pinned to the revisions of the lockfile
+	lock := lockfile.FromContext(ctx)
+	lockKey := getLockKey(source)
+	locked := lock.Get(lockKey)
+
 	// Resolve source
 	var localPath string
 	if source.Git != "" {
@@ -94,11 +115,27 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 
 		_ = os.MkdirAll(DependencyFolderPath, 0755)
 		localPath = filepath.Join(DependencyFolderPath, ID)
+		if locked != nil && locked.Revision != "" {
+			err = checkoutRevision(ctx, localPath, gitPath, source, locked.Revision)
+			if err != nil {
+				return "", err
+			}
+
+			return getDependencyConfigPath(localPath, source)
+		}
+
+		// Update dependency, branches are cloned again if the lockfile is refreshed,
+		// as the existing clone might be checked out at a previously locked revision
+		refresh := lock.Updating() && source.Tag == "" && source.Revision == ""
+		if refresh && !source.DisablePull {
+			err = os.RemoveAll(localPath)
+			if err != nil {
+				return "", errors.Wrapf(err, "remove clone of %s", gitPath)
+			}
+		}
 
 		// Check if dependency exists
 		_, statErr := os.Stat(localPath)
-
-		// Update dependency
 		if !source.DisablePull && statErr != nil {
 			repo, err := git.NewGitCLIRepository(ctx, localPath)
 			if err != nil {
@@ -144,6 +181,39 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 			}
 			log.Debugf("Pulled %s", gitPath)
 		}
+
+		if lock != nil {
+			revision, err := git.GetHash(ctx, localPath)
+			if err != nil {
+				return "", errors.Wrapf(err, "get revision of %s", gitPath)
+			}
+
+			err = lock.Set(lockKey, lockfile.Source{Revision: revision})
+			if err != nil {
+				return "", err
+			}
+		}
+	} else if source.OCI != "" {
+		var digest string
+		localPath, digest, err = downloadOCI(ctx, lockedOCISource(source, locked), log)
+		if err != nil {
+			return "", err
+		}
+
+		err = lock.Set(lockKey, lockfile.Source{Digest: digest})
+		if err != nil {
+			return "", err
+		}
+	} else if source.URL != "" {
+		localPath, err = downloadURL(ctx, source, log)
+		if err != nil {
+			return "", err
+		}
+
+		err = lock.Set(lockKey, lockfile.Source{Digest: "sha256:" + strings.ToLower(source.SHA256)})
+		if err != nil {
+			return "", err
+		}
 	} else if source.Path != "" {
 		if isURL(source.Path) {
 			localPath = filepath.Join(DependencyFolderPath, ID)
@@ -153,6 +223,14 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 			configPath := filepath.Join(localPath, constants.DefaultConfigPath)
 			_, statErr := os.Stat(configPath)
 
+			// the downloaded file is reused as long as it matches the locked digest
+			if locked != nil && locked.Digest != "" && statErr == nil {
+				digest, err := fileDigest(configPath)
+				if err == nil && digest == locked.Digest {
+					return getDependencyConfigPath(localPath, source)
+				}
+			}
+
 			if !source.DisablePull || statErr != nil {
 				// Create the file
 				out, err := os.Create(configPath)
@@ -189,6 +267,20 @@ func DownloadDependency(ctx context.Context, workingDirectory string, source *la
 					return "", errors.Wrapf(err, "download %s", source.Path)
 				}
 			}
+
+			if lock != nil {
+				digest, err := fileDigest(configPath)
+				if err != nil {
+					return "", err
+				}
+
+				err = lock.Set(lockKey, lockfile.Source{Digest: digest})
+				if err != nil {
+					return "", err
+				} else if locked != nil && locked.Digest != digest {
+					log.Warnf("Content of %s has changed since it was locked in %s", source.Path, lockfile.FileName)
+				}
+			}
 		} else {
 			if filepath.IsAbs(source.Path) {
 				localPath = source.Path
@@ -236,11 +328,83 @@ func GetDependencyID(source *latest.SourceConfig) (string, error) {
 		}
 
 		return encoding.Convert(id), nil
+	} else if source.OCI != "" {
+		return encoding.Convert(source.OCI), nil
+	} else if source.URL != "" {
+		return encoding.Convert(source.URL), nil
 	} else if source.Path != "" {
 		return source.Path, nil
 	}
 
-	return "", fmt.Errorf("unexpected dependency config, both source.git and source.path are missing")
+	return "", fmt.Errorf("unexpected dependency config, source.git, source.path, source.oci and source.url are missing")
+}
+
+// getLockKey returns the key of the source in the lockfile or an empty string for local sources
+func getLockKey(source *latest.SourceConfig) string {
+	if source == nil {
+		return ""
+	} else if source.Git != "" {
+		key := "git:" + strings.TrimSpace(source.Git)
+		if source.Branch != "" {
+			key += "@" + source.Branch
+		} else if source.Tag != "" {
+			key += "@tag:" + source.Tag
+		} else if source.Revision != "" {
+			key += "@revision:" + source.Revision
+		}
+
+		return key
+	} else if source.OCI != "" {
+		return "oci:" + source.OCI
+	} else if source.URL != "" {
+		return "url:" + source.URL
+	} else if isURL(source.Path) {
+		return "path:" + source.Path
+	}
+
+	return ""
+}
+
+// checkoutRevision makes sure the clone of the git repository at the local path is checked out
+// at the given revision. A clone at another revision is replaced.
+func checkoutRevision(ctx context.Context, localPath, gitPath string, source *latest.SourceConfig, revision string) error {
+	hash, err := git.GetHash(ctx, localPath)
+	if err == nil && hash == revision {
+		return nil
+	}
+
+	err = os.RemoveAll(localPath)
+	if err != nil {
+		return errors.Wrapf(err, "remove clone of %s", gitPath)
+	}
+
+	repo, err := git.NewGitCLIRepository(ctx, localPath)
+	if err != nil {
+		return err
+	}
+
+	err = repo.Clone(ctx, git.CloneOptions{
+		URL:    gitPath,
+		Tag:    source.Tag,
+		Branch: source.Branch,
+		Commit: revision,
+		Args:   source.CloneArgs,
+	})
+	if err != nil {
+		_ = os.RemoveAll(localPath)
+		return errors.Wrapf(err, "clone locked revision %s of %s", revision, gitPath)
+	}
+
+	return nil
+}
+
+func fileDigest(path string) (string, error) {
+	out, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(out)), nil
 }
 
 func isURL(path string) bool {
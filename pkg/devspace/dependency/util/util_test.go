@@ -1,8 +1,30 @@
 package util
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
+	"github.com/loft-sh/devspace/pkg/util/log"
 	"gotest.tools/assert"
 )
 
@@ -13,3 +35,227 @@ func TestSwitchURLType(t *testing.T) {
 	assert.Equal(t, sshURL, switchURLType(httpURL))
 	assert.Equal(t, httpURL, switchURLType(sshURL))
 }
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		assert.NilError(t, err)
+		_, err = tarWriter.Write([]byte(content))
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, tarWriter.Close())
+	assert.NilError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func TestDownloadOCI(t *testing.T) {
+	defer func(path string) { DependencyFolderPath = path }(DependencyFolderPath)
+	DependencyFolderPath = t.TempDir()
+	server := httptest.NewServer(registry.New())
+
+	artifact, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:     static.NewLayer(tarGz(t, map[string]string{"base/devspace.yaml": "version: v2beta1\nname: base\n"}), types.OCILayer),
+		MediaType: types.OCILayer,
+	}, mutate.Addendum{
+		Layer:       static.NewLayer([]byte("replicas: 1\n"), "application/vnd.devspace.values.v1+yaml"),
+		MediaType:   "application/vnd.devspace.values.v1+yaml",
+		Annotations: map[string]string{ociTitleAnnotation: "base/values.yaml"},
+	})
+	assert.NilError(t, err)
+
+	reference := strings.TrimPrefix(server.URL, "http://") + "/configs/base:v1"
+	ref, err := name.ParseReference(reference)
+	assert.NilError(t, err)
+	assert.NilError(t, remote.Write(ref, artifact))
+	digest, err := artifact.Digest()
+	assert.NilError(t, err)
+
+	source := &latest.SourceConfig{OCI: reference, SubPath: "base"}
+	configPath, err := DownloadDependency(context.Background(), "", source, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, configPath, filepath.Join(DependencyFolderPath, OCIFolder, "sha256-"+digest.Hex, "base", "devspace.yaml"))
+
+	out, err := os.ReadFile(configPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "version: v2beta1\nname: base\n")
+	out, err = os.ReadFile(filepath.Join(filepath.Dir(configPath), "values.yaml"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "replicas: 1\n")
+
+	dependencyPath, err := GetDependencyPath(context.Background(), "", source)
+	assert.NilError(t, err)
+	assert.Equal(t, dependencyPath, configPath)
+
+	// pinned digests and tags with disabled pull are served from the cache
+	server.Close()
+	pinned := &latest.SourceConfig{OCI: strings.TrimSuffix(reference, ":v1") + "@" + digest.String(), SubPath: "base"}
+	configPath, err = DownloadDependency(context.Background(), "", pinned, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, configPath, dependencyPath)
+
+	source.DisablePull = true
+	configPath, err = DownloadDependency(context.Background(), "", source, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, configPath, dependencyPath)
+}
+
+func TestDownloadURL(t *testing.T) {
+	defer func(path string) { DependencyFolderPath = path }(DependencyFolderPath)
+	DependencyFolderPath = t.TempDir()
+	archive := tarGz(t, map[string]string{"devspace.yaml": "version: v2beta1\nname: vendor\n"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	checksum := sha256.Sum256(archive)
+	source := &latest.SourceConfig{URL: server.URL + "/vendor.tar.gz", SHA256: hex.EncodeToString(checksum[:])}
+	configPath, err := DownloadDependency(context.Background(), "", source, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, configPath, filepath.Join(DependencyFolderPath, URLFolder, "sha256-"+source.SHA256, "devspace.yaml"))
+
+	out, err := os.ReadFile(configPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "version: v2beta1\nname: vendor\n")
+
+	source = &latest.SourceConfig{URL: server.URL + "/vendor.tar.gz", SHA256: strings.Repeat("0", 64)}
+	_, err = DownloadDependency(context.Background(), "", source, log.Discard)
+	assert.ErrorContains(t, err, "checksum mismatch")
+
+	source = &latest.SourceConfig{URL: server.URL + "/vendor.tar.gz"}
+	_, err = DownloadDependency(context.Background(), "", source, log.Discard)
+	assert.ErrorContains(t, err, "sha256 is required")
+}
+
+func TestExtractArchiveIllegalPath(t *testing.T) {
+	err := extractArchive(bytes.NewReader(tarGz(t, map[string]string{"../evil": "evil"})), t.TempDir())
+	assert.ErrorContains(t, err, "illegal path ../evil in archive")
+}
+
+func TestDownloadOCILocked(t *testing.T) {
+	defer func(path string) { DependencyFolderPath = path }(DependencyFolderPath)
+	DependencyFolderPath = t.TempDir()
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	reference := strings.TrimPrefix(server.URL, "http://") + "/configs/base:v1"
+	ref, err := name.ParseReference(reference)
+	assert.NilError(t, err)
+	push := func(content string) string {
+		artifact, err := mutate.Append(empty.Image, mutate.Addendum{
+			Layer:     static.NewLayer(tarGz(t, map[string]string{"devspace.yaml": content}), types.OCILayer),
+			MediaType: types.OCILayer,
+		})
+		assert.NilError(t, err)
+		assert.NilError(t, remote.Write(ref, artifact))
+		digest, err := artifact.Digest()
+		assert.NilError(t, err)
+		return digest.String()
+	}
+
+	first := push("version: v2beta1\nname: first\n")
+	lock, err := lockfile.Load(filepath.Join(t.TempDir(), lockfile.FileName), lockfile.Options{})
+	assert.NilError(t, err)
+	ctx := lockfile.WithLockfile(context.Background(), lock)
+	source := &latest.SourceConfig{OCI: reference}
+	_, err = DownloadDependency(ctx, "", source, log.Discard)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, lock.Get("oci:"+reference), &lockfile.Source{Digest: first})
+
+	// the tag moved, but the locked digest is used
+	second := push("version: v2beta1\nname: second\n")
+	lockedPath, err := DownloadDependency(ctx, "", source, log.Discard)
+	assert.NilError(t, err)
+	out, err := os.ReadFile(lockedPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "version: v2beta1\nname: first\n")
+
+	// frozen lockfiles fail for sources that are not locked
+	frozen, err := lockfile.Load(filepath.Join(t.TempDir(), lockfile.FileName), lockfile.Options{Frozen: true})
+	assert.NilError(t, err)
+	_, err = DownloadDependency(lockfile.WithLockfile(context.Background(), frozen), "", source, log.Discard)
+	assert.ErrorContains(t, err, "devspace.lock is out of date")
+
+	// updating resolves the tag again
+	update, err := lockfile.Load(filepath.Join(t.TempDir(), lockfile.FileName), lockfile.Options{Update: true})
+	assert.NilError(t, err)
+	configPath, err := DownloadDependency(lockfile.WithLockfile(context.Background(), update), "", source, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, update.Sources["oci:"+reference].Digest, second)
+	out, err = os.ReadFile(configPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "version: v2beta1\nname: second\n")
+
+	// the dependency path is the one of the locked digest
+	dependencyPath, err := GetDependencyPath(ctx, "", source)
+	assert.NilError(t, err)
+	assert.Equal(t, dependencyPath, lockedPath)
+	dependencyPath, err = GetDependencyPath(context.Background(), "", source)
+	assert.NilError(t, err)
+	assert.Equal(t, dependencyPath, configPath)
+}
+
+func TestDownloadGitLocked(t *testing.T) {
+	_, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not installed")
+	}
+
+	defer func(path string) { DependencyFolderPath = path }(DependencyFolderPath)
+	DependencyFolderPath = t.TempDir()
+	repository := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repository
+		out, err := cmd.CombinedOutput()
+		assert.NilError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(content string) string {
+		assert.NilError(t, os.WriteFile(filepath.Join(repository, "devspace.yaml"), []byte(content), 0644))
+		git("add", "devspace.yaml")
+		git("commit", "-m", content)
+		return git("rev-parse", "HEAD")
+	}
+	git("init", "-b", "main")
+	first := commit("version: v2beta1\nname: first\n")
+
+	lock, err := lockfile.Load(filepath.Join(t.TempDir(), lockfile.FileName), lockfile.Options{})
+	assert.NilError(t, err)
+	source := &latest.SourceConfig{Git: "file://" + filepath.ToSlash(repository), Branch: "main"}
+	_, err = DownloadDependency(lockfile.WithLockfile(context.Background(), lock), "", source, log.Discard)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, lock.Get(getLockKey(source)), &lockfile.Source{Revision: first})
+
+	// a fresh machine checks out the locked revision, even though the branch moved
+	commit("version: v2beta1\nname: second\n")
+	DependencyFolderPath = t.TempDir()
+	configPath, err := DownloadDependency(lockfile.WithLockfile(context.Background(), lock), "", source, log.Discard)
+	assert.NilError(t, err)
+	out, err := os.ReadFile(configPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "version: v2beta1\nname: first\n")
+
+	// the dependency path is the one of the locked revision
+	dependencyPath, err := GetDependencyPath(lockfile.WithLockfile(context.Background(), lock), "", source)
+	assert.NilError(t, err)
+	assert.Equal(t, dependencyPath, configPath)
+
+	// the locked revision is checked out in the single clone of the repository
+	entries, err := os.ReadDir(DependencyFolderPath)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+
+	// refreshing the lockfile checks out the branch again
+	updateLock, err := lockfile.Load(filepath.Join(t.TempDir(), lockfile.FileName), lockfile.Options{Update: true})
+	assert.NilError(t, err)
+	configPath, err = DownloadDependency(lockfile.WithLockfile(context.Background(), updateLock), "", source, log.Discard)
+	assert.NilError(t, err)
+	out, err = os.ReadFile(configPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "version: v2beta1\nname: second\n")
+	assert.Equal(t, updateLock.Sources[getLockKey(source)].Revision, git("rev-parse", "HEAD"))
+}
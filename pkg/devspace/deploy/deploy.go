@@ -3,8 +3,10 @@ package deploy
 import (
 	"fmt"
 	"io"
+	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
@@ -12,9 +14,15 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/rollout"
+	"github.com/loft-sh/devspace/pkg/devspace/events"
 	helmclient "github.com/loft-sh/devspace/pkg/devspace/helm"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	kubectlclient "github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
+	"github.com/loft-sh/devspace/pkg/util/stringutil"
 	"github.com/mgutz/ansi"
 	"github.com/pkg/errors"
 )
@@ -25,10 +33,18 @@ type Options struct {
 	ForceDeploy bool `long:"force-redeploy" description:"Forces redeployment"`
 	Sequential  bool `long:"sequential" description:"Sequentially deploys the deployments"`
 
+	Wait    bool  `long:"wait" description:"If true, will wait until all Deployments, StatefulSets, DaemonSets and Jobs of the deployments are ready"`
+	Timeout int64 `long:"timeout" description:"The timeout in seconds to wait for the deployments to become ready. Defaults to 5 minutes"`
+
 	Render       bool `long:"render" description:"If true, prints the rendered manifests to the stdout instead of deploying them"`
 	RenderWriter io.Writer
 }
 
+// DiffOptions describe how the differences of the deployments should be shown
+type DiffOptions struct {
+	Writer io.Writer
+}
+
 type PurgeOptions struct {
 	ForcePurge bool `long:"force-purge" description:"Forces purging of deployments even though they might be still in use by other DevSpace projects"`
 }
@@ -37,6 +53,8 @@ type PurgeOptions struct {
 type Controller interface {
 	Deploy(ctx devspacecontext.Context, deployments []string, options *Options) error
 	Purge(ctx devspacecontext.Context, deployments []string, options *PurgeOptions) error
+	Rollback(ctx devspacecontext.Context, deployments []string) error
+	Diff(ctx devspacecontext.Context, deployments []string, options *DiffOptions) (bool, error)
 }
 
 type controller struct{}
@@ -159,18 +177,30 @@ func (c *controller) Deploy(ctx devspacecontext.Context, deployments []string, o
 	return nil
 }
 
-func (c *controller) deployOne(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig, options *Options) (bool, error) {
+func (c *controller) deployOne(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig, options *Options) (_ bool, err error) {
 	event := "deploy"
 	if options.Render {
 		event = "render"
 	}
 
+	spanCtx, span := tracing.Start(ctx.Context(), tracing.CategoryDeploy, event+" "+deployConfig.Name)
+	ctx = ctx.WithContext(spanCtx)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	var (
 		deployClient deployer.Interface
-		err          error
 		method       string
 	)
 
+	// use the client and cache of the cluster the deployment targets
+	ctx, err = ctx.WithKubeContext(deployConfig.KubeConfig, deployConfig.KubeContext)
+	if err != nil {
+		return true, errors.Wrapf(err, "deployment %s", deployConfig.Name)
+	}
+
 	if !options.Render && deployConfig.Namespace != "" {
 		err = kubectlclient.EnsureNamespace(ctx.Context(), ctx.KubeClient(), deployConfig.Namespace, ctx.Log())
 		if err != nil {
@@ -178,29 +208,11 @@ func (c *controller) deployOne(ctx devspacecontext.Context, deployConfig *latest
 		}
 	}
 
-	if deployConfig.Kubectl != nil {
-		deployClient, err = kubectl.New(ctx, deployConfig)
-		if err != nil {
-			return true, errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
-		}
-
-		method = "kubectl"
-	} else if deployConfig.Helm != nil {
-		// Get helm client
-		helmClient, err := helmclient.NewClient(ctx.Log())
-		if err != nil {
-			return true, err
-		}
-
-		deployClient, err = helm.New(helmClient, deployConfig)
-		if err != nil {
-			return true, errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
-		}
-
-		method = "helm"
-	} else {
-		return true, errors.Errorf("error deploying: deployment %s has no deployment method", deployConfig.Name)
+	deployClient, method, err = newDeployer(ctx, deployConfig)
+	if err != nil {
+		return true, err
 	}
+
 	// Execute before deployment deploy hook
 	err = hook.ExecuteHooks(ctx, map[string]interface{}{
 		"DEPLOY_NAME":   deployConfig.Name,
@@ -226,9 +238,43 @@ func (c *controller) deployOne(ctx devspacecontext.Context, deployConfig *latest
 			return true, hookErr
 		}
 
+		if !options.Render {
+			emitDeployResult(ctx, deployConfig, nil, method, false, err)
+		}
 		return true, errors.Errorf("error deploying %s: %v", deployConfig.Name, err)
 	}
 
+	if !options.Render && shouldWait(deployConfig, options) {
+		waitCtx, waitSpan := tracing.Start(ctx.Context(), tracing.CategoryDeploy, "wait "+deployConfig.Name)
+		err = c.waitForRollout(ctx.WithContext(waitCtx), deployConfig, options)
+		waitSpan.SetError(err)
+		waitSpan.End()
+		if err != nil {
+			hookErr := hook.ExecuteHooks(ctx, map[string]interface{}{
+				"DEPLOY_NAME":   deployConfig.Name,
+				"DEPLOY_CONFIG": deployConfig,
+				"ERROR":         err,
+			}, hook.EventsForSingle("error:"+event, deployConfig.Name)...)
+			if hookErr != nil {
+				return true, hookErr
+			}
+
+			emitDeployResult(ctx, deployConfig, deployClient, method, wasDeployed, err)
+			return true, errors.Errorf("error deploying %s: %v", deployConfig.Name, err)
+		}
+	}
+	if !options.Render {
+		emitDeployResult(ctx, deployConfig, deployClient, method, wasDeployed, nil)
+
+		// the cache of the current cluster is saved after all deployments are done
+		if deployConfig.KubeConfig != "" || deployConfig.KubeContext != "" {
+			err = ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+			if err != nil {
+				return true, errors.Wrapf(err, "save cache of context %s", ctx.KubeClient().CurrentContext())
+			}
+		}
+	}
+
 	if wasDeployed {
 		ctx.Log().Donef("Successfully deployed %s with %s", ansi.Color(deployConfig.Name, "white+b"), ansi.Color(method, "white+b"))
 		// Execute after deployment deploy hook
@@ -253,6 +299,219 @@ func (c *controller) deployOne(ctx devspacecontext.Context, deployConfig *latest
 	return false, nil
 }
 
+// newDeployer creates the deployer for the deployment method of the deployment
+func newDeployer(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig) (deployer.Interface, string, error) {
+	if deployConfig.Kubectl != nil {
+		deployClient, err := kubectl.New(ctx, deployConfig)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "kubectl", nil
+	} else if deployConfig.Helm != nil {
+		// Get helm client
+		helmClient, err := helmclient.NewClientForEngine(deployConfig.Helm.Engine, ctx.Log())
+		if err != nil {
+			return nil, "", err
+		}
+
+		deployClient, err := helm.New(helmClient, deployConfig)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "helm", nil
+	}
+
+	return nil, "", errors.Errorf("error deploying: deployment %s has no deployment method", deployConfig.Name)
+}
+
+// emitDeployResult emits a deploy result event with the status reported by the deployer
+func emitDeployResult(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig, deployClient deployer.Interface, method string, deployed bool, deployErr error) {
+	if !events.Enabled() {
+		return
+	}
+
+	result := &events.Deploy{
+		Name:     deployConfig.Name,
+		Type:     method,
+		Deployed: deployed,
+		Error:    events.ErrorString(deployErr),
+	}
+	if deployClient != nil {
+		status, err := deployClient.Status(ctx)
+		if err != nil {
+			ctx.Log().Debugf("Error retrieving status of deployment %s: %v", deployConfig.Name, err)
+		} else if status != nil {
+			result.Type = status.Type
+			result.Target = status.Target
+			result.Status = status.Status
+		}
+	}
+
+	events.Emit(events.DeployResult, result)
+}
+
+func shouldWait(deployConfig *latest.DeploymentConfig, options *Options) bool {
+	if options.Wait {
+		return true
+	}
+
+	return deployConfig.Wait != nil && (deployConfig.Wait.Enabled == nil || *deployConfig.Wait.Enabled)
+}
+
+// waitForRollout waits until all workloads of the deployment are ready
+func (c *controller) waitForRollout(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig, options *Options) error {
+	timeout := rollout.DefaultTimeout
+	if options.Timeout > 0 {
+		timeout = time.Duration(options.Timeout) * time.Second
+	} else if deployConfig.Wait != nil && deployConfig.Wait.Timeout > 0 {
+		timeout = time.Duration(deployConfig.Wait.Timeout) * time.Second
+	}
+
+	var helmClient helmtypes.Client
+	if deployConfig.Helm != nil {
+		var err error
+		helmClient, err = helmclient.NewClientForEngine(deployConfig.Helm.Engine, ctx.Log())
+		if err != nil {
+			return err
+		}
+	}
+
+	workloads, err := rollout.Workloads(ctx, deployConfig, helmClient)
+	if err != nil {
+		return err
+	}
+
+	return rollout.Wait(ctx, workloads, timeout)
+}
+
+// Diff prints the differences between the desired and the live state of all deployments or a
+// set of deployments and returns true if there are any
+func (c *controller) Diff(ctx devspacecontext.Context, deployments []string, options *DiffOptions) (bool, error) {
+	if options == nil || options.Writer == nil {
+		options = &DiffOptions{Writer: os.Stdout}
+	}
+
+	config := ctx.Config().Config()
+	if len(deployments) == 0 {
+		for name := range config.Deployments {
+			deployments = append(deployments, name)
+		}
+	}
+	sort.Strings(deployments)
+
+	changed := 0
+	for _, name := range deployments {
+		deployConfig, ok := config.Deployments[name]
+		if !ok {
+			return false, fmt.Errorf("couldn't find deployment %v", name)
+		}
+
+		changes, err := c.diffOne(ctx.WithLogger(ctx.Log().WithPrefix("diff:"+name+" ")), deployConfig)
+		if err != nil {
+			return false, errors.Wrapf(err, "diff deployment %s", name)
+		} else if len(changes) == 0 {
+			ctx.Log().Donef("Deployment %s is up to date", name)
+			continue
+		}
+
+		err = diff.Print(options.Writer, changes)
+		if err != nil {
+			return false, err
+		}
+
+		changed++
+		ctx.Log().Infof("Deployment %s has %d changed resource(s)", name, len(changes))
+	}
+
+	return changed > 0, nil
+}
+
+func (c *controller) diffOne(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig) ([]*diff.Change, error) {
+	ctx, err := ctx.WithKubeContext(deployConfig.KubeConfig, deployConfig.KubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	deployClient, _, err := newDeployer(ctx, deployConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return deployClient.Diff(ctx)
+}
+
+// Rollback restores the state before the last deploy of all deployments or a set of deployments
+func (c *controller) Rollback(ctx devspacecontext.Context, deployments []string) error {
+	if deployments != nil && len(deployments) == 0 {
+		deployments = nil
+	}
+
+	clusters := ClusterContexts(ctx)
+	for i := len(clusters) - 1; i >= 0; i-- {
+		err := c.rollbackCluster(clusters[i], deployments)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *controller) rollbackCluster(ctx devspacecontext.Context, deployments []string) error {
+	// Reverse them
+	deploymentCaches := ctx.Config().RemoteCache().ListDeployments()
+	for i := len(deploymentCaches) - 1; i >= 0; i-- {
+		deploymentCache := deploymentCaches[i]
+		if deployments != nil && !stringutil.Contains(deployments, deploymentCache.Name) {
+			continue
+		}
+		ctx := ctx.WithLogger(ctx.Log().WithPrefix("rollback:" + deploymentCache.Name + " "))
+
+		var (
+			rolledBack bool
+			err        error
+		)
+		ctx.Log().Info("Rolling back deployment " + deploymentCache.Name + "...")
+		if deploymentCache.Kubectl != nil {
+			rolledBack, err = kubectl.Rollback(ctx, deploymentCache.Name)
+		} else if deploymentCache.Helm != nil {
+			rolledBack, err = helm.Rollback(ctx, deploymentCache.Name)
+		} else {
+			ctx.Log().Errorf("error rolling back: deployment %s has no deployment method", deploymentCache.Name)
+			continue
+		}
+		if err != nil {
+			return errors.Errorf("error rolling back %s: %v", deploymentCache.Name, err)
+		} else if !rolledBack {
+			ctx.Log().Infof("Skip rolling back deployment %s, because there is no previous revision", deploymentCache.Name)
+			continue
+		}
+
+		ctx.Log().Donef("Successfully rolled back deployment %s", deploymentCache.Name)
+	}
+
+	return ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+}
+
+// Revisions returns an identifier of the currently deployed revision for each deployment in the
+// remote cache. Comparing the revisions before and after a deploy shows which deployments have changed.
+func Revisions(ctx devspacecontext.Context) map[string]string {
+	revisions := map[string]string{}
+	for _, cluster := range ClusterContexts(ctx) {
+		for _, deploymentCache := range cluster.Config().RemoteCache().ListDeployments() {
+			if deploymentCache.Kubectl != nil {
+				revisions[deploymentCache.Name] = deploymentCache.Kubectl.AppliedManifestsHash
+			} else if deploymentCache.Helm != nil {
+				revisions[deploymentCache.Name] = deploymentCache.Helm.ReleaseRevision
+			}
+		}
+	}
+
+	return revisions
+}
+
 // Purge removes all deployments or a set of deployments from the cluster
 func (c *controller) Purge(ctx devspacecontext.Context, deployments []string, options *PurgeOptions) error {
 	if options == nil {
@@ -274,6 +533,27 @@ func (c *controller) Purge(ctx devspacecontext.Context, deployments []string, op
 		options.ForcePurge = true
 	}
 
+	// purge the deployments of every cluster
+	clusters := ClusterContexts(ctx)
+	for i := len(clusters) - 1; i >= 0; i-- {
+		err = c.purgeCluster(clusters[i], deployments, rootName, options)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Execute after deployments purge hook
+	err = hook.ExecuteHooks(ctx, nil, "after:purge")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *controller) purgeCluster(ctx devspacecontext.Context, deployments []string, rootName string, options *PurgeOptions) error {
+	var err error
+
 	// Reverse them
 	deploymentCaches := ctx.Config().RemoteCache().ListDeployments()
 	for i := len(deploymentCaches) - 1; i >= 0; i-- {
@@ -360,16 +640,47 @@ func (c *controller) Purge(ctx devspacecontext.Context, deployments []string, op
 		ctx.Config().RemoteCache().DeleteDeployment(deploymentCache.Name)
 	}
 
-	err = ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
-	if err != nil {
-		return err
+	return ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+}
+
+// ClusterContexts returns a context for the current cluster and for every other cluster a
+// deployment of the config targets. Clusters that cannot be reached are skipped with a warning.
+func ClusterContexts(ctx devspacecontext.Context) []devspacecontext.Context {
+	contexts := []devspacecontext.Context{ctx}
+	if ctx.Config() == nil || ctx.Config().Config() == nil {
+		return contexts
 	}
 
-	// Execute after deployments purge hook
-	err = hook.ExecuteHooks(ctx, nil, "after:purge")
-	if err != nil {
-		return err
+	// sort the deployments to return the clusters in a stable order
+	deployConfigs := []*latest.DeploymentConfig{}
+	for _, deployConfig := range ctx.Config().Config().Deployments {
+		deployConfigs = append(deployConfigs, deployConfig)
 	}
+	sort.Slice(deployConfigs, func(i, j int) bool {
+		return deployConfigs[i].Name < deployConfigs[j].Name
+	})
 
-	return nil
+	seen := map[string]bool{}
+	for _, deployConfig := range deployConfigs {
+		clusterCtx, err := ctx.WithKubeContext(deployConfig.KubeConfig, deployConfig.KubeContext)
+		if err != nil {
+			ctx.Log().Warnf("Skip context %s of deployment %s: %v", deployConfig.KubeContext, deployConfig.Name, err)
+			continue
+		} else if clusterCtx.KubeClient() == ctx.KubeClient() {
+			continue
+		}
+
+		key := ctx.ResolvePath(deployConfig.KubeConfig) + "#" + clusterCtx.KubeClient().CurrentContext()
+		if deployConfig.KubeConfig == "" {
+			key = "#" + clusterCtx.KubeClient().CurrentContext()
+		}
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		contexts = append(contexts, clusterCtx)
+	}
+
+	return contexts
 }
@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
@@ -11,6 +12,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"gotest.tools/assert"
@@ -277,3 +279,129 @@ func TestPurge(t *testing.T) {
 		assert.NilError(t, err)
 	}
 }
+
+// baseContext is embedded under another name, because the context has a method called Context
+type baseContext = devspacecontext.Context
+
+// clustersContext returns the contexts of the given clusters instead of creating new kube clients
+type clustersContext struct {
+	baseContext
+
+	clusters map[string]devspacecontext.Context
+}
+
+func (c *clustersContext) WithKubeContext(kubeConfig, kubeContext string) (devspacecontext.Context, error) {
+	if kubeContext == "" || kubeContext == c.KubeClient().CurrentContext() {
+		return c, nil
+	}
+
+	cluster, ok := c.clusters[kubeContext]
+	if !ok {
+		return nil, fmt.Errorf("context %s does not exist", kubeContext)
+	}
+
+	return cluster, nil
+}
+
+// deleteRecordingClient records the deleted objects of a cluster
+type deleteRecordingClient struct {
+	*fakekube.Client
+
+	deleted *[]string
+}
+
+func (c *deleteRecordingClient) GenericRequest(ctx context.Context, options *kubectl.GenericRequestOptions) (string, error) {
+	if options.Method == "delete" {
+		*c.deleted = append(*c.deleted, c.Context+": "+options.Kind+"/"+options.Name)
+	}
+
+	return "", nil
+}
+
+func newClustersContext(deployed map[string][]string, deleted *[]string) *clustersContext {
+	config := &latest.Config{
+		Name: "test",
+		Deployments: map[string]*latest.DeploymentConfig{
+			"api":    {Name: "api"},
+			"db":     {Name: "db", KubeContext: "other"},
+			"worker": {Name: "worker", KubeContext: "other"},
+			"broken": {Name: "broken", KubeContext: "unknown"},
+		},
+	}
+
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard)
+	contexts := map[string]devspacecontext.Context{}
+	for kubeContext, deployments := range deployed {
+		cache := remotecache.NewCache("test", "devspace-cache-test")
+		for _, name := range deployments {
+			cache.SetDeployment(name, remotecache.DeploymentCache{
+				Name: name,
+				Kubectl: &remotecache.KubectlCache{
+					Objects: []remotecache.KubectlObject{{APIVersion: "apps/v1", Kind: "Deployment", Name: name}},
+				},
+			})
+		}
+
+		conf := config2.NewConfig(map[string]interface{}{}, map[string]interface{}{}, config, &localcache.LocalCache{}, cache, map[string]interface{}{}, constants.DefaultConfigPath)
+		client := &deleteRecordingClient{
+			Client:  &fakekube.Client{Client: fake.NewSimpleClientset(), Context: kubeContext},
+			deleted: deleted,
+		}
+		contexts[kubeContext] = ctx.WithKubeClient(client).WithConfig(conf)
+	}
+
+	return &clustersContext{
+		baseContext: contexts["default"],
+		clusters:    contexts,
+	}
+}
+
+func savedDeployments(t *testing.T, ctx devspacecontext.Context) []string {
+	cache, err := remotecache.NewCacheFromSecret(context.TODO(), ctx.KubeClient(), "devspace-cache-test")
+	assert.NilError(t, err)
+
+	deployments := []string{}
+	for _, deployment := range cache.ListDeployments() {
+		deployments = append(deployments, deployment.Name)
+	}
+	return deployments
+}
+
+func TestClusterContexts(t *testing.T) {
+	ctx := newClustersContext(map[string][]string{"default": {"api"}, "other": {"db", "worker"}}, &[]string{})
+
+	clusters := ClusterContexts(ctx)
+	assert.Equal(t, len(clusters), 2)
+	assert.Equal(t, clusters[0], devspacecontext.Context(ctx))
+	assert.Equal(t, clusters[1], ctx.clusters["other"])
+}
+
+func TestPurgeClusters(t *testing.T) {
+	deleted := []string{}
+	ctx := newClustersContext(map[string][]string{"default": {"api"}, "other": {"db", "worker"}}, &deleted)
+
+	// purge a single deployment of another cluster
+	err := NewController().Purge(ctx, []string{"db"}, &PurgeOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, deleted, []string{"other: Deployment/db"})
+	assert.DeepEqual(t, savedDeployments(t, ctx.clusters["other"]), []string{"worker"})
+	assert.DeepEqual(t, savedDeployments(t, ctx.clusters["default"]), []string{"api"})
+
+	// purge all deployments, the other clusters first
+	deleted = []string{}
+	err = NewController().Purge(ctx, nil, &PurgeOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, deleted, []string{"other: Deployment/worker", "default: Deployment/api"})
+	assert.DeepEqual(t, savedDeployments(t, ctx.clusters["other"]), []string{})
+	assert.DeepEqual(t, savedDeployments(t, ctx.clusters["default"]), []string{})
+}
+
+func TestRollbackClusters(t *testing.T) {
+	ctx := newClustersContext(map[string][]string{"default": {"api"}, "other": {"db", "worker"}}, &[]string{})
+
+	// the remote cache of every cluster is saved to its own cluster
+	err := NewController().Rollback(ctx, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, savedDeployments(t, ctx.clusters["other"]), []string{"db", "worker"})
+	assert.DeepEqual(t, savedDeployments(t, ctx.clusters["default"]), []string{"api"})
+}
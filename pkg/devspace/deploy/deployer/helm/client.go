@@ -80,9 +80,9 @@ func Delete(ctx devspacecontext.Context, deploymentName string) error {
 		return nil
 	}
 
-	helmClient, err := helm.NewClient(ctx.Log())
+	helmClient, err := clientForDeployment(ctx, deploymentName)
 	if err != nil {
-		return errors.Wrap(err, "new helm client")
+		return err
 	}
 
 	err = helmClient.DeleteRelease(ctx, deploymentCache.Helm.Release, deploymentCache.Helm.ReleaseNamespace)
@@ -92,3 +92,63 @@ func Delete(ctx devspacecontext.Context, deploymentName string) error {
 
 	return nil
 }
+
+// Rollback rolls back the release of the deployment to the revision before the last upgrade.
+// Returns false if there is no previous revision to roll back to.
+func Rollback(ctx devspacecontext.Context, deploymentName string) (bool, error) {
+	deploymentCache, ok := ctx.Config().RemoteCache().GetDeployment(deploymentName)
+	if !ok || deploymentCache.Helm == nil || deploymentCache.Helm.Release == "" || deploymentCache.Helm.PreviousReleaseRevision == "" {
+		return false, nil
+	}
+
+	helmClient, err := clientForDeployment(ctx, deploymentName)
+	if err != nil {
+		return false, err
+	}
+
+	helmCache := *deploymentCache.Helm
+	err = helmClient.RollbackRelease(ctx, helmCache.Release, helmCache.ReleaseNamespace, helmCache.PreviousReleaseRevision)
+	if err != nil {
+		return false, err
+	}
+
+	// a rollback creates a new revision
+	releases, err := helmClient.ListReleases(ctx, helmCache.ReleaseNamespace)
+	if err != nil {
+		return false, err
+	}
+	for _, release := range releases {
+		if release.Name == helmCache.Release {
+			helmCache.ReleaseRevision = release.Revision
+			break
+		}
+	}
+
+	// make sure the next deploy upgrades the release again
+	helmCache.PreviousReleaseRevision = ""
+	helmCache.ChartHash = ""
+	helmCache.ValuesHash = ""
+	helmCache.OverridesHash = ""
+	deploymentCache.Helm = &helmCache
+	deploymentCache.DeploymentConfigHash = ""
+	ctx.Config().RemoteCache().SetDeployment(deploymentName, deploymentCache)
+	return true, nil
+}
+
+// clientForDeployment returns a helm client with the same engine the deployment
+// was deployed with if it's still configured
+func clientForDeployment(ctx devspacecontext.Context, deploymentName string) (helmtypes.Client, error) {
+	engine := latest.HelmEngineCLI
+	if ctx.Config() != nil && ctx.Config().Config() != nil {
+		if deployConfig, ok := ctx.Config().Config().Deployments[deploymentName]; ok && deployConfig.Helm != nil {
+			engine = deployConfig.Helm.Engine
+		}
+	}
+
+	helmClient, err := helm.NewClientForEngine(engine, ctx.Log())
+	if err != nil {
+		return nil, errors.Wrap(err, "new helm client")
+	}
+
+	return helmClient, nil
+}
@@ -51,6 +51,12 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, forceDeploy bool) (bo
 			return false, errors.Wrap(err, "download chart")
 		}
 		chartPath = downloadPath
+
+		// record the resolved chart source in the lockfile
+		err = ctx.Config().Lockfile().Save()
+		if err != nil {
+			return false, err
+		}
 	}
 
 	// Hash the chart directory if there is any
@@ -96,7 +102,7 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, forceDeploy bool) (bo
 
 	// Get HelmClient if necessary
 	if d.Helm == nil {
-		d.Helm, err = helm.NewClient(ctx.Log())
+		d.Helm, err = helm.NewClientForEngine(d.DeploymentConfig.Helm.Engine, ctx.Log())
 		if err != nil {
 			return false, errors.Errorf("Error creating helm client: %v", err)
 		}
@@ -151,6 +157,10 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, forceDeploy bool) (bo
 		helmCache.ValuesHash = deployValuesHash
 		helmCache.OverridesHash = helmOverridesHash
 		if release != nil {
+			// remember the previous revision to be able to roll back
+			if helmCache.ReleaseRevision != "" && helmCache.ReleaseRevision != release.Revision {
+				helmCache.PreviousReleaseRevision = helmCache.ReleaseRevision
+			}
 			helmCache.ReleaseRevision = release.Revision
 		}
 
@@ -0,0 +1,60 @@
+package helm
+
+import (
+	"bytes"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// hookAnnotation marks helm hooks, which are not part of the release manifest
+const hookAnnotation = "helm.sh/hook"
+
+// Diff compares the rendered chart with the manifest of the deployed release
+func (d *DeployConfig) Diff(ctx devspacecontext.Context) ([]*diff.Change, error) {
+	releaseName := d.DeploymentConfig.Name
+	if d.DeploymentConfig.Helm.ReleaseName != "" {
+		releaseName = d.DeploymentConfig.Helm.ReleaseName
+	}
+	releaseNamespace := ctx.KubeClient().Namespace()
+	if d.DeploymentConfig.Namespace != "" {
+		releaseNamespace = d.DeploymentConfig.Namespace
+	}
+
+	rendered := &bytes.Buffer{}
+	err := d.Render(ctx, rendered)
+	if err != nil {
+		return nil, errors.Wrap(err, "render chart")
+	}
+
+	manifest, err := d.Helm.GetManifest(ctx, releaseName, releaseNamespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get manifest of release %s", releaseName)
+	}
+
+	current, err := diff.Parse(manifest, releaseNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse release manifest")
+	}
+	desired, err := diff.Parse(rendered.String(), releaseNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse rendered chart")
+	}
+
+	return diff.Objects(current, withoutHooks(desired))
+}
+
+func withoutHooks(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+	retObjects := []*unstructured.Unstructured{}
+	for _, obj := range objects {
+		if _, ok := obj.GetAnnotations()[hookAnnotation]; ok {
+			continue
+		}
+
+		retObjects = append(retObjects, obj)
+	}
+
+	return retObjects
+}
@@ -0,0 +1,125 @@
+package helm
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
+	dependencyutil "github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	fakehelm "github.com/loft-sh/devspace/pkg/devspace/helm/testing"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	helmv3 "github.com/loft-sh/devspace/pkg/devspace/helm/v3"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// downloadingClient downloads charts like the real helm client and fakes everything else
+type downloadingClient struct {
+	*fakehelm.Client
+
+	downloader helmtypes.Client
+}
+
+func (c *downloadingClient) DownloadChart(ctx devspacecontext.Context, helmConfig *latest.HelmConfig) (string, error) {
+	return c.downloader.DownloadChart(ctx, helmConfig)
+}
+
+func TestDeployLockedChart(t *testing.T) {
+	_, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not installed")
+	}
+
+	defer func(path string) { dependencyutil.DependencyFolderPath = path }(dependencyutil.DependencyFolderPath)
+	dependencyutil.DependencyFolderPath = t.TempDir()
+
+	// the chart is served by a local git repository
+	repository := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repository
+		out, err := cmd.CombinedOutput()
+		assert.NilError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(version string) string {
+		assert.NilError(t, os.MkdirAll(filepath.Join(repository, "chart"), 0755))
+		assert.NilError(t, os.WriteFile(filepath.Join(repository, "chart", "Chart.yaml"), []byte("apiVersion: v2\nname: test\nversion: "+version+"\n"), 0644))
+		git("add", "chart")
+		git("commit", "-m", version)
+		return git("rev-parse", "HEAD")
+	}
+	git("init", "-b", "main")
+	first := commit("0.1.0")
+
+	projectDir := t.TempDir()
+	configPath := filepath.Join(projectDir, "devspace.yaml")
+	err = os.WriteFile(configPath, []byte(`version: v2beta1
+name: test
+deployments:
+  chart:
+    helm:
+      chart:
+        git: file://`+filepath.ToSlash(repository)+`
+        branch: main
+        subPath: chart
+`), 0644)
+	assert.NilError(t, err)
+
+	downloader, err := helmv3.NewClient(log.Discard)
+	assert.NilError(t, err)
+	kubeClient := &fakekube.Client{
+		Client: fake.NewSimpleClientset(),
+	}
+	deploy := func(options *loader.ConfigOptions) error {
+		configLoader, err := loader.NewConfigLoader(configPath)
+		assert.NilError(t, err)
+		conf, err := configLoader.Load(context.Background(), kubeClient, options, log.Discard)
+		assert.NilError(t, err)
+
+		deployer, err := New(&downloadingClient{Client: &fakehelm.Client{}, downloader: downloader}, conf.Config().Deployments["chart"])
+		assert.NilError(t, err)
+		ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).
+			WithWorkingDir(projectDir).
+			WithKubeClient(kubeClient).
+			WithConfig(conf)
+		_, err = deployer.Deploy(ctx, true)
+		return err
+	}
+	source := &latest.SourceConfig{Git: "file://" + filepath.ToSlash(repository), Branch: "main", SubPath: "chart"}
+	chartVersion := func() string {
+		ID, err := dependencyutil.GetDependencyID(source)
+		assert.NilError(t, err)
+		out, err := os.ReadFile(filepath.Join(dependencyutil.DependencyFolderPath, ID, "chart", "Chart.yaml"))
+		assert.NilError(t, err)
+		return strings.TrimPrefix(strings.Split(strings.TrimSpace(string(out)), "\n")[2], "version: ")
+	}
+
+	// deploying the chart locks its revision
+	err = deploy(&loader.ConfigOptions{})
+	assert.NilError(t, err)
+	lock, err := lockfile.Load(filepath.Join(projectDir, lockfile.FileName), lockfile.Options{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, lock.Sources, map[string]*lockfile.Source{"git:file://" + filepath.ToSlash(repository) + "@main": {Revision: first}})
+
+	// a fresh machine deploys the locked revision, even though the branch moved
+	commit("0.2.0")
+	dependencyutil.DependencyFolderPath = t.TempDir()
+	err = deploy(&loader.ConfigOptions{FrozenLockfile: true})
+	assert.NilError(t, err)
+	assert.Equal(t, chartVersion(), "0.1.0")
+
+	// a frozen lockfile that misses the chart fails the deployment
+	assert.NilError(t, os.Remove(filepath.Join(projectDir, lockfile.FileName)))
+	err = deploy(&loader.ConfigOptions{FrozenLockfile: true})
+	assert.ErrorContains(t, err, "devspace.lock is out of date")
+}
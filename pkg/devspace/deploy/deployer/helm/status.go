@@ -18,7 +18,7 @@ func (d *DeployConfig) Status(ctx devspacecontext.Context) (*deployer.StatusResu
 
 	if d.Helm == nil {
 		// Get HelmClient
-		d.Helm, err = helm.NewClient(ctx.Log())
+		d.Helm, err = helm.NewClientForEngine(d.DeploymentConfig.Helm.Engine, ctx.Log())
 		if err != nil {
 			return nil, err
 		}
@@ -1,8 +1,10 @@
 package deployer
 
 import (
-	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"io"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
 )
 
 // Interface defines the common interface used for the deployment methods
@@ -10,6 +12,7 @@ type Interface interface {
 	Status(ctx devspacecontext.Context) (*StatusResult, error)
 	Deploy(ctx devspacecontext.Context, forceDeploy bool) (bool, error)
 	Render(ctx devspacecontext.Context, out io.Writer) error
+	Diff(ctx devspacecontext.Context) ([]*diff.Change, error)
 }
 
 // StatusResult holds the status of a deployment
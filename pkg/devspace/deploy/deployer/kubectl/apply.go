@@ -0,0 +1,196 @@
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// FieldManager is the field manager DevSpace uses for server-side apply
+const FieldManager = "devspace"
+
+// serverSideApplier applies manifests in-process via server-side apply
+type serverSideApplier struct {
+	client    kubectl.Client
+	namespace string
+	log       log.Logger
+
+	mapper meta.RESTMapper
+}
+
+func newServerSideApplier(client kubectl.Client, namespace string, log log.Logger) (*serverSideApplier, error) {
+	if client == nil {
+		return nil, errors.New("server-side apply needs a kube client")
+	} else if client.DynamicClient() == nil {
+		return nil, errors.New("server-side apply needs a dynamic kube client")
+	}
+
+	return &serverSideApplier{
+		client:    client,
+		namespace: namespace,
+		log:       log,
+	}, nil
+}
+
+// Apply applies the given objects with the devspace field manager. Objects that could not be
+// applied because of field conflicts with other managers are collected and returned as a single error.
+func (s *serverSideApplier) Apply(ctx context.Context, objects []*unstructured.Unstructured) error {
+	conflicts := []string{}
+	for _, obj := range objects {
+		if obj.Object == nil {
+			continue
+		}
+
+		resource, err := s.resourceFor(obj.GroupVersionKind(), obj.GetNamespace())
+		if err != nil {
+			return errors.Wrapf(err, "apply %s %s", obj.GetKind(), obj.GetName())
+		}
+
+		// the namespace is ignored for cluster scoped resources
+		if resource.namespace == "" {
+			obj.SetNamespace("")
+		}
+
+		_, err = resource.client.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{FieldManager: FieldManager})
+		if err != nil {
+			if kerrors.IsConflict(err) {
+				conflicts = append(conflicts, formatConflict(obj, err))
+				continue
+			}
+
+			return errors.Wrapf(err, "apply %s %s", obj.GetKind(), obj.GetName())
+		}
+
+		s.log.Donef("%s %s applied", strings.ToLower(obj.GetKind()), obj.GetName())
+	}
+
+	if len(conflicts) > 0 {
+		return errors.Errorf("server-side apply with field manager %s failed because of conflicts:\n%s\nPlease make sure the conflicting fields are not managed by other tools or remove them from the manifests", FieldManager, strings.Join(conflicts, "\n"))
+	}
+
+	return nil
+}
+
+// Prune deletes all objects from the old objects that are not part of the new objects anymore
+func (s *serverSideApplier) Prune(ctx context.Context, oldObjects, newObjects []remotecache.KubectlObject) error {
+	existing := map[string]bool{}
+	for _, obj := range newObjects {
+		existing[objectKey(obj)] = true
+	}
+
+	for _, obj := range oldObjects {
+		if existing[objectKey(obj)] {
+			continue
+		}
+
+		gv, err := schema.ParseGroupVersion(obj.APIVersion)
+		if err != nil {
+			s.log.Warnf("Skip pruning %s %s: %v", obj.Kind, obj.Name, err)
+			continue
+		}
+
+		resource, err := s.resourceFor(gv.WithKind(obj.Kind), obj.Namespace)
+		if err != nil {
+			s.log.Warnf("Skip pruning %s %s: %v", obj.Kind, obj.Name, err)
+			continue
+		}
+
+		propagationPolicy := metav1.DeletePropagationBackground
+		err = resource.client.Delete(ctx, obj.Name, metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+
+			return errors.Wrapf(err, "prune %s %s", obj.Kind, obj.Name)
+		}
+
+		s.log.Donef("%s %s pruned", strings.ToLower(obj.Kind), obj.Name)
+	}
+
+	return nil
+}
+
+type resourceClient struct {
+	client    dynamic.ResourceInterface
+	namespace string
+}
+
+func (s *serverSideApplier) resourceFor(gvk schema.GroupVersionKind, namespace string) (*resourceClient, error) {
+	mapping, err := s.restMapping(gvk, false)
+	if meta.IsNoMatchError(err) {
+		// the resource might have been created by a previous object, e.g. a crd
+		mapping, err = s.restMapping(gvk, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return &resourceClient{
+			client: s.client.DynamicClient().Resource(mapping.Resource),
+		}, nil
+	}
+
+	if namespace == "" {
+		namespace = s.namespace
+	}
+	return &resourceClient{
+		client:    s.client.DynamicClient().Resource(mapping.Resource).Namespace(namespace),
+		namespace: namespace,
+	}, nil
+}
+
+func (s *serverSideApplier) restMapping(gvk schema.GroupVersionKind, refresh bool) (*meta.RESTMapping, error) {
+	if s.mapper == nil || refresh {
+		groupResources, err := restmapper.GetAPIGroupResources(s.client.KubeClient().Discovery())
+		if err != nil {
+			return nil, errors.Wrap(err, "discover api resources")
+		}
+
+		s.mapper = restmapper.NewDiscoveryRESTMapper(groupResources)
+	}
+
+	return s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+func formatConflict(obj *unstructured.Unstructured, err error) string {
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+
+	lines := []string{fmt.Sprintf("%s %s:", obj.GetKind(), name)}
+	if status, ok := err.(kerrors.APIStatus); ok && status.Status().Details != nil && len(status.Status().Details.Causes) > 0 {
+		for _, cause := range status.Status().Details.Causes {
+			lines = append(lines, fmt.Sprintf("  - %s: %s", cause.Field, cause.Message))
+		}
+	} else {
+		lines = append(lines, "  - "+err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// objectKey identifies an object independent of its api version, so that
+// changing the version of a resource does not prune it
+func objectKey(obj remotecache.KubectlObject) string {
+	group := ""
+	if gv, err := schema.ParseGroupVersion(obj.APIVersion); err == nil {
+		group = gv.Group
+	}
+
+	return strings.Join([]string{group, obj.Kind, obj.Namespace, obj.Name}, "/")
+}
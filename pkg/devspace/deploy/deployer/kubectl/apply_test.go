@@ -0,0 +1,132 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestApplier(t *testing.T, objects ...runtime.Object) (*serverSideApplier, *dynamicfake.FakeDynamicClient) {
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: "rbac.authorization.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "clusterroles", Kind: "ClusterRole", Namespaced: false},
+			},
+		},
+	}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+	applier, err := newServerSideApplier(&fakekube.Client{
+		Client:  kubeClient,
+		Dynamic: dynamicClient,
+	}, "test", log.Discard)
+	assert.NilError(t, err)
+	return applier, dynamicClient
+}
+
+func newTestObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestServerSideApply(t *testing.T) {
+	applier, dynamicClient := newTestApplier(t)
+
+	patches := []k8stesting.PatchAction{}
+	dynamicClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		patches = append(patches, patch)
+		return true, newTestObject("v1", "ConfigMap", patch.GetNamespace(), patch.GetName()), nil
+	})
+
+	err := applier.Apply(context.Background(), []*unstructured.Unstructured{
+		newTestObject("v1", "ConfigMap", "", "config"),
+		newTestObject("rbac.authorization.k8s.io/v1", "ClusterRole", "test", "role"),
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(patches), 2)
+
+	assert.Equal(t, patches[0].GetPatchType(), types.ApplyPatchType)
+	assert.Equal(t, patches[0].GetNamespace(), "test")
+	assert.Equal(t, patches[0].GetResource().Resource, "configmaps")
+	assert.Equal(t, patches[0].GetName(), "config")
+
+	// cluster scoped resources are applied without namespace
+	assert.Equal(t, patches[1].GetNamespace(), "")
+	assert.Equal(t, patches[1].GetResource().Resource, "clusterroles")
+}
+
+func TestServerSideApplyConflicts(t *testing.T) {
+	applier, dynamicClient := newTestApplier(t)
+	dynamicClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewApplyConflict([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Message: `conflict with "kubectl-edit" using v1`,
+				Field:   ".data.key",
+			},
+		}, "Apply failed with 1 conflict")
+	})
+
+	err := applier.Apply(context.Background(), []*unstructured.Unstructured{
+		newTestObject("v1", "ConfigMap", "test", "first"),
+		newTestObject("v1", "ConfigMap", "test", "second"),
+	})
+	assert.ErrorContains(t, err, "ConfigMap test/first:\n  - .data.key: conflict with \"kubectl-edit\" using v1")
+	assert.ErrorContains(t, err, "ConfigMap test/second:")
+}
+
+func TestServerSidePrune(t *testing.T) {
+	applier, dynamicClient := newTestApplier(t,
+		newTestObject("v1", "ConfigMap", "test", "kept"),
+		newTestObject("v1", "ConfigMap", "test", "removed"),
+	)
+
+	deleted := []string{}
+	dynamicClient.PrependReactor("delete", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		deleted = append(deleted, action.(k8stesting.DeleteAction).GetName())
+		return false, nil, nil
+	})
+
+	err := applier.Prune(context.Background(), []remotecache.KubectlObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "kept"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "removed"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "already-gone"},
+		{APIVersion: "example.com/v1", Kind: "Unknown", Namespace: "test", Name: "unknown"},
+	}, []remotecache.KubectlObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "kept"},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, deleted, []string{"removed", "already-gone"})
+
+	list, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test").List(context.Background(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	assert.Equal(t, list.Items[0].GetName(), "kept")
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -165,6 +168,87 @@ func (k *kubectlBuilder) Build(ctx context.Context, environ expand.Environ, dir,
 	return stringToUnstructuredArray(string(output))
 }
 
+type fileBuilder struct{}
+
+// NewFileBuilder creates a new manifest builder that reads the manifests in-process
+// without the need of a kubectl binary
+func NewFileBuilder() Builder {
+	return &fileBuilder{}
+}
+
+func (f *fileBuilder) Build(ctx context.Context, environ expand.Environ, dir, manifest string) ([]*unstructured.Unstructured, error) {
+	if strings.HasPrefix(manifest, "http://") || strings.HasPrefix(manifest, "https://") {
+		out, err := readManifestURL(ctx, manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		return stringToUnstructuredArray(string(out))
+	}
+
+	if !filepath.IsAbs(manifest) {
+		manifest = filepath.Join(dir, manifest)
+	}
+	stat, err := os.Stat(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{manifest}
+	if stat.IsDir() {
+		entries, err := os.ReadDir(manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		files = []string{}
+		for _, entry := range entries {
+			switch filepath.Ext(entry.Name()) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(manifest, entry.Name()))
+				}
+			}
+		}
+	}
+
+	objects := []*unstructured.Unstructured{}
+	for _, file := range files {
+		out, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := stringToUnstructuredArray(string(out))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse %s", file)
+		}
+
+		objects = append(objects, parsed...)
+	}
+
+	return objects, nil
+}
+
+func readManifestURL(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("get %s: unexpected status code %d", url, resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
 var diffSeparator = regexp.MustCompile(`\n---`)
 
 // stringToUnstructuredArray splits a YAML file into unstructured objects. Returns a list of all unstructured objects
@@ -7,7 +7,7 @@ import (
 
 func Delete(ctx devspacecontext.Context, deploymentName string) error {
 	deploymentCache, ok := ctx.Config().RemoteCache().GetDeployment(deploymentName)
-	if !ok || deploymentCache.Kubectl == nil || len(deploymentCache.Kubectl.Objects) == 0 {
+	if !ok || deploymentCache.Kubectl == nil {
 		return nil
 	}
 
@@ -23,5 +23,9 @@ func Delete(ctx devspacecontext.Context, deploymentName string) error {
 			ctx.Log().Errorf("error deleting %s %s: %v", resource.Kind, resource.Name, err)
 		}
 	}
-	return nil
+
+	if deploymentCache.Kubectl.AppliedManifestsHash == "" {
+		return nil
+	}
+	return deleteManifests(ctx, deploymentName)
 }
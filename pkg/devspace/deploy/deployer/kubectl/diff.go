@@ -0,0 +1,151 @@
+package kubectl
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Diff compares the manifests with the live objects in the cluster
+func (d *DeployConfig) Diff(ctx devspacecontext.Context) ([]*diff.Change, error) {
+	applier, err := newServerSideApplier(ctx.KubeClient(), d.Namespace, ctx.Log())
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []*unstructured.Unstructured{}
+	kubeObjects := []remotecache.KubectlObject{}
+	addManifest := func(inline bool, manifest string) error {
+		_, replacedManifest, parsedObjects, err := d.getReplacedManifest(ctx, inline, manifest)
+		if err != nil {
+			return errors.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
+		}
+
+		parsed, err := stringToUnstructuredArray(replacedManifest)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, parsed...)
+		kubeObjects = append(kubeObjects, parsedObjects...)
+		return nil
+	}
+	for _, manifest := range d.Manifests {
+		err = addManifest(false, manifest)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if d.InlineManifest != "" {
+		resolvedInlineManifest, err := runtime.NewRuntimeResolver(ctx.WorkingDir(), false).FillRuntimeVariablesAsString(ctx.Context(), d.InlineManifest, ctx.Config(), ctx.Dependencies())
+		if err != nil {
+			return nil, err
+		}
+
+		err = addManifest(true, resolvedInlineManifest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// objects that are not part of the manifests anymore are pruned by server-side apply
+	pruned := []remotecache.KubectlObject{}
+	deployCache, _ := ctx.Config().RemoteCache().GetDeployment(d.DeploymentConfig.Name)
+	if d.serverSide() && deployCache.Kubectl != nil {
+		existing := map[string]bool{}
+		for _, obj := range kubeObjects {
+			existing[objectKey(obj)] = true
+		}
+		for _, obj := range deployCache.Kubectl.Objects {
+			if !existing[objectKey(obj)] {
+				pruned = append(pruned, obj)
+			}
+		}
+	}
+
+	return applier.Diff(ctx.Context(), objects, pruned)
+}
+
+// Diff compares the objects with their live state. The result of the apply is predicted
+// with a server-side dry run. If that is not possible, only the fields of the objects are compared.
+func (s *serverSideApplier) Diff(ctx context.Context, objects []*unstructured.Unstructured, pruned []remotecache.KubectlObject) ([]*diff.Change, error) {
+	live := []*unstructured.Unstructured{}
+	desired := []*unstructured.Unstructured{}
+	for _, obj := range objects {
+		if obj.Object == nil {
+			continue
+		}
+
+		resource, err := s.resourceFor(obj.GroupVersionKind(), obj.GetNamespace())
+		if err != nil {
+			return nil, errors.Wrapf(err, "diff %s %s", obj.GetKind(), obj.GetName())
+		}
+
+		// the namespace is ignored for cluster scoped resources
+		obj = obj.DeepCopy()
+		if resource.namespace == "" {
+			obj.SetNamespace("")
+		} else if obj.GetNamespace() == "" {
+			obj.SetNamespace(resource.namespace)
+		}
+
+		current, err := resource.client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return nil, errors.Wrapf(err, "get %s %s", obj.GetKind(), obj.GetName())
+			}
+
+			desired = append(desired, obj)
+			continue
+		}
+
+		predicted, err := resource.client.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+			FieldManager: FieldManager,
+			Force:        true,
+			DryRun:       []string{metav1.DryRunAll},
+		})
+		if err != nil || predicted == nil || predicted.Object == nil {
+			s.log.Debugf("Dry run of %s %s failed, compare only the fields of the manifest: %v", obj.GetKind(), obj.GetName(), err)
+			live = append(live, diff.Prune(current, obj))
+			desired = append(desired, obj)
+			continue
+		}
+
+		live = append(live, current)
+		desired = append(desired, predicted)
+	}
+
+	for _, obj := range pruned {
+		gv, err := schema.ParseGroupVersion(obj.APIVersion)
+		if err != nil {
+			continue
+		}
+
+		resource, err := s.resourceFor(gv.WithKind(obj.Kind), obj.Namespace)
+		if err != nil {
+			s.log.Debugf("Skip diff of pruned %s %s: %v", obj.Kind, obj.Name, err)
+			continue
+		}
+
+		current, err := resource.client.Get(ctx, obj.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, errors.Wrapf(err, "get %s %s", obj.Kind, obj.Name)
+		}
+
+		live = append(live, current)
+	}
+
+	return diff.Objects(live, desired)
+}
@@ -0,0 +1,48 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestServerSideDiff(t *testing.T) {
+	unchanged := newTestObject("v1", "ConfigMap", "test", "unchanged")
+	unchanged.Object["data"] = map[string]interface{}{"key": "value"}
+	changed := newTestObject("v1", "ConfigMap", "test", "changed")
+	changed.Object["data"] = map[string]interface{}{"key": "old"}
+	changed.SetResourceVersion("12")
+	pruned := newTestObject("v1", "ConfigMap", "test", "pruned")
+	applier, _ := newTestApplier(t, unchanged, changed, pruned)
+
+	desiredUnchanged := newTestObject("v1", "ConfigMap", "", "unchanged")
+	desiredUnchanged.Object["data"] = map[string]interface{}{"key": "value"}
+	desiredChanged := newTestObject("v1", "ConfigMap", "", "changed")
+	desiredChanged.Object["data"] = map[string]interface{}{"key": "new"}
+
+	changes, err := applier.Diff(context.Background(), []*unstructured.Unstructured{
+		desiredUnchanged,
+		desiredChanged,
+		newTestObject("v1", "ConfigMap", "", "created"),
+		newTestObject("rbac.authorization.k8s.io/v1", "ClusterRole", "test", "role"),
+	}, []remotecache.KubectlObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "pruned"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "already-gone"},
+	})
+	assert.NilError(t, err)
+
+	actions := map[string]string{}
+	for _, change := range changes {
+		actions[change.Resource] = change.Action()
+	}
+	assert.DeepEqual(t, actions, map[string]string{
+		"ConfigMap test/changed":                     diff.ActionUpdate,
+		"ConfigMap test/created":                     diff.ActionCreate,
+		"ConfigMap test/pruned":                      diff.ActionDelete,
+		"ClusterRole.rbac.authorization.k8s.io role": diff.ActionCreate,
+	})
+}
@@ -41,6 +41,8 @@ type DeployConfig struct {
 	Manifests      []string
 
 	DeploymentConfig *latest.DeploymentConfig
+
+	applier *serverSideApplier
 }
 
 // New creates a new deploy config for kubectl
@@ -58,7 +60,8 @@ func New(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig) (de
 	)
 	if deployConfig.Kubectl.KubectlBinaryPath != "" {
 		cmdPath = deployConfig.Kubectl.KubectlBinaryPath
-	} else {
+	} else if deployConfig.Kubectl.ApplyMode != latest.KubectlApplyModeServerSide || (deployConfig.Kubectl.Kustomize != nil && *deployConfig.Kubectl.Kustomize) {
+		// server-side apply only needs kubectl as a fallback to build kustomizations
 		cmdPath, err = downloader.NewDownloader(commands.NewKubectlCommand(), ctx.Log(), constants.DefaultHomeDevSpaceFolder).EnsureCommand(ctx.Context())
 		if err != nil {
 			return nil, err
@@ -170,15 +173,28 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, _ bool) (bool, error)
 	// forceDeploy = forceDeploy || deployCache.KubectlManifestsHash != manifestsHash || deployCache.DeploymentConfigHash != deploymentConfigHash
 	forceDeploy := true
 
-	ctx.Log().Info("Applying manifests with kubectl...")
+	if d.serverSide() {
+		d.applier, err = newServerSideApplier(ctx.KubeClient(), d.Namespace, ctx.Log())
+		if err != nil {
+			return false, err
+		}
+
+		ctx.Log().Info("Applying manifests with server-side apply...")
+	} else {
+		ctx.Log().Info("Applying manifests with kubectl...")
+	}
 	wasDeployed := false
 	kubeObjects := []remotecache.KubectlObject{}
+	appliedManifests := []string{}
 
 	for _, manifest := range d.Manifests {
-		wasDeployed, kubeObjects, err = d.applyManifest(ctx, kubeObjects, forceDeploy, false, manifest)
+		var appliedManifest string
+		wasDeployed, kubeObjects, appliedManifest, err = d.applyManifest(ctx, kubeObjects, forceDeploy, false, manifest)
 		if err != nil {
 			return false, err
 		}
+
+		appliedManifests = append(appliedManifests, appliedManifest)
 	}
 
 	// Special case for inline manifests
@@ -189,16 +205,53 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, _ bool) (bool, error)
 			return false, err
 		}
 		// proceed with regular apply
-		wasDeployed, kubeObjects, err = d.applyManifest(ctx, kubeObjects, forceDeploy, true, resolvedInlineManifest)
+		var appliedManifest string
+		wasDeployed, kubeObjects, appliedManifest, err = d.applyManifest(ctx, kubeObjects, forceDeploy, true, resolvedInlineManifest)
 		if err != nil {
 			return false, err
 		}
+
+		appliedManifests = append(appliedManifests, appliedManifest)
 	}
 
-	deployCache.Kubectl = &remotecache.KubectlCache{
-		Objects:       kubeObjects,
-		ManifestsHash: manifestsHash,
+	// remove objects that are not part of the manifests anymore
+	if d.serverSide() && deployCache.Kubectl != nil {
+		err = d.applier.Prune(ctx.Context(), deployCache.Kubectl.Objects, kubeObjects)
+		if err != nil {
+			return false, err
+		}
 	}
+
+	applied := strings.Join(appliedManifests, "\n---\n")
+	newCache := &remotecache.KubectlCache{
+		Objects:              kubeObjects,
+		ManifestsHash:        manifestsHash,
+		AppliedManifestsHash: hash.String(applied),
+	}
+
+	// remember the previous manifests to be able to roll back
+	if deployCache.Kubectl == nil || deployCache.Kubectl.AppliedManifestsHash != newCache.AppliedManifestsHash {
+		previous := ""
+		if deployCache.Kubectl != nil && deployCache.Kubectl.AppliedManifestsHash != "" {
+			previous, _, err = loadManifests(ctx, d.DeploymentConfig.Name)
+			if err != nil {
+				return false, err
+			}
+			if previous != "" {
+				newCache.PreviousManifestsHash = deployCache.Kubectl.AppliedManifestsHash
+				newCache.PreviousObjects = deployCache.Kubectl.Objects
+			}
+		}
+
+		err = saveManifests(ctx, d.DeploymentConfig.Name, applied, previous)
+		if err != nil {
+			return false, err
+		}
+	} else {
+		newCache.PreviousManifestsHash = deployCache.Kubectl.PreviousManifestsHash
+		newCache.PreviousObjects = deployCache.Kubectl.PreviousObjects
+	}
+	deployCache.Kubectl = newCache
 	deployCache.DeploymentConfigHash = deploymentConfigHash
 	if rootName, ok := values.RootNameFrom(ctx.Context()); ok && !stringutil.Contains(deployCache.Projects, rootName) {
 		deployCache.Projects = append(deployCache.Projects, rootName)
@@ -207,30 +260,40 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, _ bool) (bool, error)
 	return wasDeployed, nil
 }
 
-func (d *DeployConfig) applyManifest(ctx devspacecontext.Context, kubeObjects []remotecache.KubectlObject, forceDeploy, inline bool, manifest string) (bool, []remotecache.KubectlObject, error) {
+func (d *DeployConfig) applyManifest(ctx devspacecontext.Context, kubeObjects []remotecache.KubectlObject, forceDeploy, inline bool, manifest string) (bool, []remotecache.KubectlObject, string, error) {
 	shouldRedeploy, replacedManifest, parsedObjects, err := d.getReplacedManifest(ctx, inline, manifest)
 	if err != nil {
-		return false, nil, errors.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
+		return false, nil, "", errors.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
 	}
 	writer := ctx.Log().Writer(logrus.InfoLevel, false)
 	defer writer.Close()
 
 	kubeObjects = append(kubeObjects, parsedObjects...)
-	if shouldRedeploy || forceDeploy {
+	if (shouldRedeploy || forceDeploy) && d.serverSide() {
+		objects, err := stringToUnstructuredArray(replacedManifest)
+		if err != nil {
+			return false, nil, "", err
+		}
+
+		err = d.applier.Apply(ctx.Context(), objects)
+		if err != nil {
+			return false, nil, "", errors.Wrapf(err, "apply manifest %s", manifest)
+		}
+	} else if shouldRedeploy || forceDeploy {
 		args := d.getCmdArgs("apply", "--force")
 		args = append(args, d.DeploymentConfig.Kubectl.ApplyArgs...)
 
 		stdErrBuffer := &bytes.Buffer{}
 		err = command.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, io.MultiWriter(writer, stdErrBuffer), strings.NewReader(replacedManifest), d.CmdPath, args...)
 		if err != nil {
-			return false, nil, errors.Errorf("%v %v\nPlease make sure the command `kubectl apply` does work locally with manifest `%s`", stdErrBuffer.String(), err, manifest)
+			return false, nil, "", errors.Errorf("%v %v\nPlease make sure the command `kubectl apply` does work locally with manifest `%s`", stdErrBuffer.String(), err, manifest)
 		}
 
 	} else {
 		ctx.Log().Infof("Skipping manifest %s", manifest)
 	}
 
-	return true, kubeObjects, nil
+	return true, kubeObjects, replacedManifest, nil
 }
 
 func (d *DeployConfig) getReplacedManifest(ctx devspacecontext.Context, inline bool, manifest string) (bool, string, []remotecache.KubectlObject, error) {
@@ -324,6 +387,11 @@ func (d *DeployConfig) buildManifests(ctx devspacecontext.Context, manifest stri
 		return NewKustomizeBuilder(kustomizePath, d.DeploymentConfig, ctx.Log()).Build(ctx.Context(), ctx.Environ(), ctx.WorkingDir(), manifest)
 	}
 
+	// Read manifests in-process if we don't use kubectl
+	if d.serverSide() && !(d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize) {
+		return NewFileBuilder().Build(ctx.Context(), ctx.Environ(), ctx.WorkingDir(), manifest)
+	}
+
 	raw, err := ctx.KubeClient().KubeConfigLoader().LoadRawConfig()
 	if err != nil {
 		return nil, errors.Errorf("get raw config")
@@ -337,6 +405,10 @@ func (d *DeployConfig) buildManifests(ctx devspacecontext.Context, manifest stri
 	return NewKubectlBuilder(d.CmdPath, d.DeploymentConfig, *copied).Build(ctx.Context(), ctx.Environ(), ctx.WorkingDir(), manifest)
 }
 
+func (d *DeployConfig) serverSide() bool {
+	return d.DeploymentConfig.Kubectl.ApplyMode == latest.KubectlApplyModeServerSide
+}
+
 func (d *DeployConfig) isKustomizeInstalled(ctx context.Context, dir, path string) bool {
 	err := command.Command(ctx, dir, expand.ListEnviron(os.Environ()...), nil, nil, nil, path, "version")
 	return err == nil
@@ -0,0 +1,269 @@
+package kubectl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/encoding"
+	"github.com/loft-sh/utils/pkg/command"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ManifestsSecretType is the type of the secrets that hold the applied manifests of kubectl deployments
+	ManifestsSecretType = "devspace.sh/kubectl-manifests"
+
+	appliedManifestsKey  = "applied"
+	previousManifestsKey = "previous"
+)
+
+// Rollback applies the manifests that were applied before the last deploy of the deployment again
+// and removes the objects that were added by the last deploy. Returns false if there is nothing to roll back.
+func Rollback(ctx devspacecontext.Context, deploymentName string) (bool, error) {
+	deployCache, ok := ctx.Config().RemoteCache().GetDeployment(deploymentName)
+	if !ok || deployCache.Kubectl == nil || deployCache.Kubectl.PreviousManifestsHash == "" {
+		return false, nil
+	}
+
+	_, previousManifests, err := loadManifests(ctx, deploymentName)
+	if err != nil {
+		return false, err
+	} else if previousManifests == "" {
+		return false, errors.Errorf("couldn't find the previous manifests of deployment %s", deploymentName)
+	}
+
+	// use the current deployment config for the apply mode and kubectl settings
+	var deployConfig *latest.DeploymentConfig
+	if ctx.Config().Config() != nil && ctx.Config().Config().Deployments != nil {
+		deployConfig = ctx.Config().Config().Deployments[deploymentName]
+	}
+	if deployConfig == nil || deployConfig.Kubectl == nil {
+		deployConfig = &latest.DeploymentConfig{
+			Name:    deploymentName,
+			Kubectl: &latest.KubectlConfig{},
+		}
+	}
+	kubectlConfig := *deployConfig.Kubectl
+	kubectlConfig.InlineManifest = previousManifests
+	kubectlConfig.Manifests = nil
+	rollbackConfig := *deployConfig
+	rollbackConfig.Kubectl = &kubectlConfig
+
+	deployer, err := New(ctx, &rollbackConfig)
+	if err != nil {
+		return false, err
+	}
+
+	err = deployer.(*DeployConfig).rollback(ctx, &deployCache, previousManifests)
+	if err != nil {
+		return false, err
+	}
+
+	err = saveManifests(ctx, deploymentName, previousManifests, "")
+	if err != nil {
+		return false, err
+	}
+
+	deployCache.Kubectl = &remotecache.KubectlCache{
+		Objects:              deployCache.Kubectl.PreviousObjects,
+		AppliedManifestsHash: deployCache.Kubectl.PreviousManifestsHash,
+	}
+	deployCache.DeploymentConfigHash = ""
+	ctx.Config().RemoteCache().SetDeployment(deploymentName, deployCache)
+	return true, nil
+}
+
+func (d *DeployConfig) rollback(ctx devspacecontext.Context, deployCache *remotecache.DeploymentCache, manifests string) error {
+	if d.serverSide() {
+		applier, err := newServerSideApplier(ctx.KubeClient(), d.Namespace, ctx.Log())
+		if err != nil {
+			return err
+		}
+
+		objects, err := stringToUnstructuredArray(manifests)
+		if err != nil {
+			return err
+		}
+
+		err = applier.Apply(ctx.Context(), objects)
+		if err != nil {
+			return err
+		}
+
+		return applier.Prune(ctx.Context(), deployCache.Kubectl.Objects, deployCache.Kubectl.PreviousObjects)
+	}
+
+	writer := ctx.Log().Writer(logrus.InfoLevel, false)
+	defer writer.Close()
+
+	stdErrBuffer := &bytes.Buffer{}
+	err := command.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, io.MultiWriter(writer, stdErrBuffer), strings.NewReader(manifests), d.CmdPath, d.getCmdArgs("apply", "--force")...)
+	if err != nil {
+		return errors.Errorf("%v %v", stdErrBuffer.String(), err)
+	}
+
+	// delete the objects that were added by the last deploy
+	previous := map[string]bool{}
+	for _, obj := range deployCache.Kubectl.PreviousObjects {
+		previous[objectKey(obj)] = true
+	}
+	for _, obj := range deployCache.Kubectl.Objects {
+		if previous[objectKey(obj)] {
+			continue
+		}
+
+		_, err := ctx.KubeClient().GenericRequest(ctx.Context(), &kubectl.GenericRequestOptions{
+			Kind:       obj.Kind,
+			APIVersion: obj.APIVersion,
+			Name:       obj.Name,
+			Namespace:  obj.Namespace,
+			Method:     "delete",
+		})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "delete %s %s", obj.Kind, obj.Name)
+		}
+	}
+
+	return nil
+}
+
+// manifestsSecretName returns the name of the secret that holds the applied manifests of the deployment
+func manifestsSecretName(ctx devspacecontext.Context, deploymentName string) string {
+	configName := ""
+	if ctx.Config().Config() != nil {
+		configName = ctx.Config().Config().Name
+	}
+
+	return encoding.Convert("devspace-manifests-" + configName + "-" + deploymentName)
+}
+
+// loadManifests returns the manifests of the last deploy and the ones applied before
+func loadManifests(ctx devspacecontext.Context, deploymentName string) (string, string, error) {
+	secret, err := ctx.KubeClient().KubeClient().CoreV1().Secrets(ctx.KubeClient().Namespace()).Get(ctx.Context(), manifestsSecretName(ctx, deploymentName), metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return "", "", nil
+		}
+
+		return "", "", errors.Wrap(err, "get manifests secret")
+	}
+
+	applied, err := decompressManifests(secret.Data[appliedManifestsKey])
+	if err != nil {
+		return "", "", errors.Wrap(err, "decompress applied manifests")
+	}
+
+	previous, err := decompressManifests(secret.Data[previousManifestsKey])
+	if err != nil {
+		return "", "", errors.Wrap(err, "decompress previous manifests")
+	}
+
+	return applied, previous, nil
+}
+
+// saveManifests stores the manifests of the deployment in a secret of its own, as they
+// would quickly exceed the size limit of the remote cache secret
+func saveManifests(ctx devspacecontext.Context, deploymentName, applied, previous string) error {
+	data := map[string][]byte{}
+	for key, manifests := range map[string]string{appliedManifestsKey: applied, previousManifestsKey: previous} {
+		if manifests == "" {
+			continue
+		}
+
+		compressed, err := compressManifests(manifests)
+		if err != nil {
+			return err
+		}
+
+		data[key] = compressed
+	}
+
+	secrets := ctx.KubeClient().KubeClient().CoreV1().Secrets(ctx.KubeClient().Namespace())
+	secret, err := secrets.Get(ctx.Context(), manifestsSecretName(ctx, deploymentName), metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrap(err, "get manifests secret")
+		}
+
+		_, err = secrets.Create(ctx.Context(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: manifestsSecretName(ctx, deploymentName),
+				Labels: map[string]string{
+					"owner":      "devspace",
+					"deployment": deploymentName,
+				},
+			},
+			Type: ManifestsSecretType,
+			Data: data,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return errors.Wrap(err, "create manifests secret")
+		}
+
+		return nil
+	}
+
+	secret.Data = data
+	_, err = secrets.Update(ctx.Context(), secret, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "update manifests secret")
+	}
+
+	return nil
+}
+
+// deleteManifests removes the secret that holds the manifests of the deployment
+func deleteManifests(ctx devspacecontext.Context, deploymentName string) error {
+	err := ctx.KubeClient().KubeClient().CoreV1().Secrets(ctx.KubeClient().Namespace()).Delete(ctx.Context(), manifestsSecretName(ctx, deploymentName), metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, "delete manifests secret")
+	}
+
+	return nil
+}
+
+// compressManifests gzips the manifests to keep the manifests secret small
+func compressManifests(manifests string) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	_, err := writer.Write([]byte(manifests))
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func decompressManifests(compressed []byte) (string, error) {
+	if len(compressed) == 0 {
+		return "", nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,161 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRollbackServerSide(t *testing.T) {
+	applier, dynamicClient := newTestApplier(t,
+		newTestObject("v1", "ConfigMap", "test", "kept"),
+		newTestObject("v1", "ConfigMap", "test", "added"),
+	)
+
+	applied := []string{}
+	dynamicClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		applied = append(applied, patch.GetName())
+		return true, newTestObject("v1", "ConfigMap", patch.GetNamespace(), patch.GetName()), nil
+	})
+	deleted := []string{}
+	dynamicClient.PrependReactor("delete", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		deleted = append(deleted, action.(k8stesting.DeleteAction).GetName())
+		return false, nil, nil
+	})
+
+	cache := remotecache.NewCache("", "test")
+	cache.SetDeployment("test", remotecache.DeploymentCache{
+		Name: "test",
+		Kubectl: &remotecache.KubectlCache{
+			Objects: []remotecache.KubectlObject{
+				{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "kept"},
+				{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "added"},
+			},
+			AppliedManifestsHash:  "current",
+			PreviousManifestsHash: "previous",
+			PreviousObjects: []remotecache.KubectlObject{
+				{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "kept"},
+			},
+		},
+	})
+
+	conf := config.NewConfig(nil, nil, &latest.Config{
+		Deployments: map[string]*latest.DeploymentConfig{
+			"test": {
+				Name: "test",
+				Kubectl: &latest.KubectlConfig{
+					Manifests: []string{"manifests/"},
+					ApplyMode: latest.KubectlApplyModeServerSide,
+				},
+			},
+		},
+	}, nil, cache, nil, "")
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).
+		WithConfig(conf).
+		WithKubeClient(applier.client)
+	previousManifests := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: kept\n  namespace: test\n"
+	assert.NilError(t, saveManifests(ctx, "test", "current manifests", previousManifests))
+
+	rolledBack, err := Rollback(ctx, "test")
+	assert.NilError(t, err)
+	assert.Equal(t, rolledBack, true)
+	assert.DeepEqual(t, applied, []string{"kept"})
+	assert.DeepEqual(t, deleted, []string{"added"})
+
+	deployCache, _ := cache.GetDeployment("test")
+	assert.Equal(t, deployCache.Kubectl.AppliedManifestsHash, "previous")
+	assert.Equal(t, deployCache.Kubectl.PreviousManifestsHash, "")
+	assert.Equal(t, len(deployCache.Kubectl.Objects), 1)
+	appliedManifests, rollbackManifests, err := loadManifests(ctx, "test")
+	assert.NilError(t, err)
+	assert.Equal(t, appliedManifests, previousManifests)
+	assert.Equal(t, rollbackManifests, "")
+
+	// a second rollback has nothing to do
+	rolledBack, err = Rollback(ctx, "test")
+	assert.NilError(t, err)
+	assert.Equal(t, rolledBack, false)
+}
+
+// failingDeleteClient fails to delete any object
+type failingDeleteClient struct {
+	*fakekube.Client
+}
+
+func (c *failingDeleteClient) GenericRequest(ctx context.Context, options *kubectl.GenericRequestOptions) (string, error) {
+	return "", errors.New("forbidden")
+}
+
+func TestRollbackDeleteError(t *testing.T) {
+	cache := remotecache.NewCache("", "test")
+	cache.SetDeployment("test", remotecache.DeploymentCache{
+		Name: "test",
+		Kubectl: &remotecache.KubectlCache{
+			Objects: []remotecache.KubectlObject{
+				{APIVersion: "v1", Kind: "ConfigMap", Namespace: "test", Name: "added"},
+			},
+			AppliedManifestsHash:  "current",
+			PreviousManifestsHash: "previous",
+		},
+	})
+	conf := config.NewConfig(nil, nil, &latest.Config{
+		Deployments: map[string]*latest.DeploymentConfig{
+			"test": {
+				Name: "test",
+				Kubectl: &latest.KubectlConfig{
+					Manifests:         []string{"manifests/"},
+					KubectlBinaryPath: "true",
+				},
+			},
+		},
+	}, nil, cache, nil, "")
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).
+		WithConfig(conf).
+		WithKubeClient(&failingDeleteClient{Client: &fakekube.Client{Client: fake.NewSimpleClientset()}})
+	assert.NilError(t, saveManifests(ctx, "test", "current manifests", "previous manifests"))
+
+	// the objects added by the last deploy have to be removed for the rollback to succeed
+	_, err := Rollback(ctx, "test")
+	assert.Error(t, err, "delete ConfigMap added: forbidden")
+
+	deployCache, _ := cache.GetDeployment("test")
+	assert.Equal(t, deployCache.Kubectl.AppliedManifestsHash, "current")
+}
+
+func TestSaveManifests(t *testing.T) {
+	conf := config.NewConfig(nil, nil, &latest.Config{Name: "project"}, nil, remotecache.NewCache("", "test"), nil, "")
+	kubeClient := &fakekube.Client{Client: fake.NewSimpleClientset()}
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).
+		WithConfig(conf).
+		WithKubeClient(kubeClient)
+
+	assert.NilError(t, saveManifests(ctx, "my_deployment", "applied manifests", ""))
+	assert.NilError(t, saveManifests(ctx, "my_deployment", "new manifests", "applied manifests"))
+	secret, err := kubeClient.Client.CoreV1().Secrets(kubeClient.Namespace()).Get(context.Background(), "devspace-manifests-project-mydeployment", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, string(secret.Type), ManifestsSecretType)
+
+	applied, previous, err := loadManifests(ctx, "my_deployment")
+	assert.NilError(t, err)
+	assert.Equal(t, applied, "new manifests")
+	assert.Equal(t, previous, "applied manifests")
+
+	assert.NilError(t, deleteManifests(ctx, "my_deployment"))
+	applied, _, err = loadManifests(ctx, "my_deployment")
+	assert.NilError(t, err)
+	assert.Equal(t, applied, "")
+}
@@ -0,0 +1,279 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// List of values that Change.Action can take
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Change is the change of a single resource between its current and its desired state
+type Change struct {
+	// Resource identifies the resource, e.g. Deployment default/api
+	Resource string
+	// Old is the current state as yaml or empty if the resource does not exist yet
+	Old string
+	// New is the desired state as yaml or empty if the resource will be deleted
+	New string
+}
+
+// Action returns if the resource will be created, updated or deleted
+func (c *Change) Action() string {
+	if c.Old == "" {
+		return ActionCreate
+	} else if c.New == "" {
+		return ActionDelete
+	}
+
+	return ActionUpdate
+}
+
+// Unified returns the change as unified diff
+func (c *Change) Unified() string {
+	return unified(c.Old, c.New, "live/"+c.Resource, "desired/"+c.Resource)
+}
+
+// Print writes the unified diffs of all changes to out and colors added and removed lines
+func Print(out io.Writer, changes []*Change) error {
+	for _, change := range changes {
+		buf := &bytes.Buffer{}
+		for _, line := range strings.SplitAfter(change.Unified(), "\n") {
+			if line == "" {
+				continue
+			}
+
+			color := ""
+			switch {
+			case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+				color = "white+b"
+			case strings.HasPrefix(line, "@@"):
+				color = "cyan"
+			case strings.HasPrefix(line, "+"):
+				color = "green"
+			case strings.HasPrefix(line, "-"):
+				color = "red"
+			}
+			if color == "" {
+				buf.WriteString(line)
+			} else {
+				buf.WriteString(ansi.Color(strings.TrimSuffix(line, "\n"), color) + "\n")
+			}
+		}
+
+		_, err := out.Write(buf.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Manifests compares two sets of manifests, e.g. a rendered chart with the manifest
+// of the deployed release. Objects without namespace are placed into the default namespace.
+func Manifests(oldManifests, newManifests, defaultNamespace string) ([]*Change, error) {
+	oldObjects, err := Parse(oldManifests, defaultNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse current manifests")
+	}
+	newObjects, err := Parse(newManifests, defaultNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse desired manifests")
+	}
+
+	return Objects(oldObjects, newObjects)
+}
+
+// Objects compares the current with the desired objects and returns the changes sorted by resource
+func Objects(oldObjects, newObjects []*unstructured.Unstructured) ([]*Change, error) {
+	oldByKey := map[string]*unstructured.Unstructured{}
+	for _, obj := range oldObjects {
+		oldByKey[Key(obj)] = obj
+	}
+	newByKey := map[string]*unstructured.Unstructured{}
+	for _, obj := range newObjects {
+		newByKey[Key(obj)] = obj
+	}
+
+	changes := []*Change{}
+	for key, newObj := range newByKey {
+		change, err := changeOf(key, oldByKey[key], newObj)
+		if err != nil {
+			return nil, err
+		} else if change != nil {
+			changes = append(changes, change)
+		}
+	}
+	for key, oldObj := range oldByKey {
+		if newByKey[key] != nil {
+			continue
+		}
+
+		change, err := changeOf(key, oldObj, nil)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Resource < changes[j].Resource
+	})
+	return changes, nil
+}
+
+// Parse parses multiple yaml documents into objects
+func Parse(manifests, defaultNamespace string) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+	decoder := kyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifests), 4096)
+	for {
+		obj := map[string]interface{}{}
+		err := decoder.Decode(&obj)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		} else if len(obj) == 0 {
+			continue
+		}
+
+		u := &unstructured.Unstructured{Object: obj}
+		if u.GetNamespace() == "" && defaultNamespace != "" {
+			u.SetNamespace(defaultNamespace)
+		}
+		objects = append(objects, u)
+	}
+
+	return objects, nil
+}
+
+// Key returns the identifier of an object that is used in the diff
+func Key(obj *unstructured.Unstructured) string {
+	kind := obj.GetKind()
+	if group := obj.GroupVersionKind().Group; group != "" {
+		kind += "." + group
+	}
+	if obj.GetNamespace() == "" {
+		return kind + " " + obj.GetName()
+	}
+
+	return kind + " " + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// Clean removes the fields that are managed by the server and would only add noise to the diff
+func Clean(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	if obj == nil {
+		return nil
+	}
+
+	obj = obj.DeepCopy()
+	delete(obj.Object, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations != nil {
+		delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		delete(annotations, "deployment.kubernetes.io/revision")
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+	}
+
+	return obj
+}
+
+// Prune removes all fields from the live object that are not part of the desired object, which
+// approximates the diff if the server cannot predict the result of the apply
+func Prune(live, desired *unstructured.Unstructured) *unstructured.Unstructured {
+	if live == nil || desired == nil {
+		return live
+	}
+
+	pruned, _ := pruneValue(live.Object, desired.Object).(map[string]interface{})
+	return &unstructured.Unstructured{Object: pruned}
+}
+
+func pruneValue(live, desired interface{}) interface{} {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+
+		pruned := map[string]interface{}{}
+		for key, value := range d {
+			if liveValue, ok := l[key]; ok {
+				pruned[key] = pruneValue(liveValue, value)
+			}
+		}
+		return pruned
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+
+		pruned := make([]interface{}, 0, len(l))
+		for i, value := range l {
+			if i < len(d) {
+				pruned = append(pruned, pruneValue(value, d[i]))
+			} else {
+				pruned = append(pruned, value)
+			}
+		}
+		return pruned
+	}
+
+	return live
+}
+
+func changeOf(key string, oldObj, newObj *unstructured.Unstructured) (*Change, error) {
+	oldYaml, err := toYaml(Clean(oldObj))
+	if err != nil {
+		return nil, err
+	}
+	newYaml, err := toYaml(Clean(newObj))
+	if err != nil {
+		return nil, err
+	}
+	if oldYaml == newYaml {
+		return nil, nil
+	}
+
+	return &Change{
+		Resource: key,
+		Old:      oldYaml,
+		New:      newYaml,
+	}, nil
+}
+
+func toYaml(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("marshal %s: %v", Key(obj), err)
+	}
+
+	return string(out), nil
+}
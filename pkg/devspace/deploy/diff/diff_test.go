@@ -0,0 +1,147 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mgutz/ansi"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const currentManifests = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  resourceVersion: "12"
+data:
+  a: "1"
+  b: "2"
+  c: "3"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: removed
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: other
+spec:
+  replicas: 1
+`
+
+const desiredManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: other
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  a: "1"
+  b: "changed"
+  c: "3"
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: added
+`
+
+func TestManifests(t *testing.T) {
+	changes, err := Manifests(currentManifests, desiredManifests, "default")
+	assert.NilError(t, err)
+	assert.Equal(t, len(changes), 3)
+
+	assert.Equal(t, changes[0].Resource, "ConfigMap default/config")
+	assert.Equal(t, changes[0].Action(), ActionUpdate)
+	assert.Equal(t, changes[0].Unified(), `--- live/ConfigMap default/config
++++ desired/ConfigMap default/config
+@@ -1,7 +1,7 @@
+ apiVersion: v1
+ data:
+   a: "1"
+-  b: "2"
++  b: changed
+   c: "3"
+ kind: ConfigMap
+ metadata:
+`)
+
+	assert.Equal(t, changes[1].Resource, "Secret default/removed")
+	assert.Equal(t, changes[1].Action(), ActionDelete)
+	assert.Equal(t, changes[2].Resource, "Service default/added")
+	assert.Equal(t, changes[2].Action(), ActionCreate)
+	assert.Equal(t, changes[2].Unified(), `--- live/Service default/added
++++ desired/Service default/added
+@@ -0,0 +1,5 @@
++apiVersion: v1
++kind: Service
++metadata:
++  name: added
++  namespace: default
+`)
+}
+
+func TestPrint(t *testing.T) {
+	ansi.DisableColors(true)
+	defer ansi.DisableColors(false)
+
+	out := &bytes.Buffer{}
+	err := Print(out, []*Change{{Resource: "ConfigMap default/config", Old: "a: 1\n", New: "a: 2\n"}})
+	assert.NilError(t, err)
+	assert.Equal(t, out.String(), `--- live/ConfigMap default/config
++++ desired/ConfigMap default/config
+@@ -1 +1 @@
+-a: 1
++a: 2
+`)
+}
+
+func TestPrune(t *testing.T) {
+	live, err := Parse(`apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  uid: abc
+spec:
+  containers:
+  - name: app
+    image: app:v1
+    imagePullPolicy: IfNotPresent
+  nodeName: node
+`, "")
+	assert.NilError(t, err)
+	desired, err := Parse(`apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    image: app:v2
+`, "")
+	assert.NilError(t, err)
+
+	changes, err := Objects([]*unstructured.Unstructured{Prune(live[0], desired[0])}, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, len(changes), 1)
+	assert.Equal(t, changes[0].Unified(), `--- live/Pod pod
++++ desired/Pod pod
+@@ -4,5 +4,5 @@
+   name: pod
+ spec:
+   containers:
+-  - image: app:v1
++  - image: app:v2
+     name: app
+`)
+}
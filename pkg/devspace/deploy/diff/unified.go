@@ -0,0 +1,165 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around a change
+const contextLines = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// unified returns a unified diff of a and b with the given file names
+func unified(a, b, fromFile, toFile string) string {
+	ops := lineOps(splitLines(a), splitLines(b))
+
+	// find the ranges of changed operations including their context
+	type hunk struct{ start, end int }
+	hunks := []hunk{}
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+
+		start, end := i-contextLines, i+contextLines+1
+		if start < 0 {
+			start = 0
+		}
+		if end > len(ops) {
+			end = len(ops)
+		}
+		if len(hunks) > 0 && start <= hunks[len(hunks)-1].end {
+			hunks[len(hunks)-1].end = end
+		} else {
+			hunks = append(hunks, hunk{start: start, end: end})
+		}
+	}
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	out := &strings.Builder{}
+	out.WriteString("--- " + fromFile + "\n")
+	out.WriteString("+++ " + toFile + "\n")
+
+	// line numbers of a and b at the beginning of each operation
+	aLine, bLine := 1, 1
+	next := 0
+	for _, h := range hunks {
+		for ; next < h.start; next++ {
+			aLine, bLine = advance(ops[next], aLine, bLine)
+		}
+
+		aCount, bCount := 0, 0
+		for _, o := range ops[h.start:h.end] {
+			if o.kind != opInsert {
+				aCount++
+			}
+			if o.kind != opDelete {
+				bCount++
+			}
+		}
+
+		fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aLine, aCount), hunkRange(bLine, bCount))
+		for _, o := range ops[h.start:h.end] {
+			switch o.kind {
+			case opEqual:
+				out.WriteString(" " + o.line + "\n")
+			case opDelete:
+				out.WriteString("-" + o.line + "\n")
+			case opInsert:
+				out.WriteString("+" + o.line + "\n")
+			}
+		}
+		for ; next < h.end; next++ {
+			aLine, bLine = advance(ops[next], aLine, bLine)
+		}
+	}
+
+	return out.String()
+}
+
+func advance(o op, aLine, bLine int) (int, int) {
+	if o.kind != opInsert {
+		aLine++
+	}
+	if o.kind != opDelete {
+		bLine++
+	}
+	return aLine, bLine
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// an empty range starts at the line before
+		return fmt.Sprintf("%d,0", start-1)
+	} else if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// lineOps computes the operations that turn a into b based on the longest common subsequence
+func lineOps(a, b []string) []op {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{kind: opEqual, line: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{kind: opDelete, line: a[i]})
+			i++
+		default:
+			ops = append(ops, op{kind: opInsert, line: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{kind: opDelete, line: a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{kind: opInsert, line: b[j]})
+	}
+
+	return ops
+}
@@ -0,0 +1,291 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/analyze"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DefaultTimeout is the default time to wait for workloads to become ready
+const DefaultTimeout = 300 * time.Second
+
+// maxPollInterval is the longest interval the workloads are checked in
+const maxPollInterval = time.Second
+
+// Wait waits until all given workloads are ready. If a workload fails or does not become ready
+// within the timeout, an error with a diagnostic of the workload namespaces is returned.
+func Wait(ctx devspacecontext.Context, workloads []Workload, timeout time.Duration) error {
+	if len(workloads) == 0 {
+		return nil
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	ctx.Log().Infof("Waiting for %d workload(s) to become ready...", len(workloads))
+	pending := map[Workload]string{}
+	for _, workload := range workloads {
+		pending[workload] = "not checked yet"
+	}
+
+	// short timeouts are checked more often, so that they are not exceeded by a whole interval
+	interval := timeout / 10
+	if interval > maxPollInterval {
+		interval = maxPollInterval
+	}
+
+	var failed error
+	waitErr := wait.PollUntilContextTimeout(ctx.Context(), interval, timeout, true, func(context.Context) (bool, error) {
+		for workload := range pending {
+			ready, reason, err := Check(ctx.Context(), ctx.KubeClient(), workload)
+			if err != nil {
+				if _, ok := err.(*workloadFailedError); ok {
+					failed = errors.Errorf("%s failed: %v", workload, err)
+					return true, nil
+				}
+
+				return false, err
+			} else if ready {
+				ctx.Log().Donef("%s is ready", workload)
+				delete(pending, workload)
+				continue
+			}
+
+			pending[workload] = reason
+		}
+
+		return len(pending) == 0, nil
+	})
+	if failed == nil && waitErr == nil {
+		return nil
+	} else if failed == nil && (!wait.Interrupted(waitErr) || ctx.Context().Err() != nil) {
+		return waitErr
+	}
+
+	message := ""
+	if failed != nil {
+		message = failed.Error()
+	} else {
+		lines := []string{}
+		for workload, reason := range pending {
+			lines = append(lines, fmt.Sprintf("  - %s: %s", workload, reason))
+		}
+		sort.Strings(lines)
+		message = fmt.Sprintf("timed out after %s waiting for workloads to become ready:\n%s", timeout, strings.Join(lines, "\n"))
+	}
+
+	return errors.New(message + diagnose(ctx, workloads))
+}
+
+// diagnose creates a report about the problems in the namespaces of the given workloads
+func diagnose(ctx devspacecontext.Context, workloads []Workload) string {
+	namespaces := []string{}
+	for _, workload := range workloads {
+		found := false
+		for _, namespace := range namespaces {
+			if namespace == workload.Namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			namespaces = append(namespaces, workload.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+
+	analyzer := analyze.NewAnalyzer(ctx.KubeClient(), ctx.Log().ErrorStreamOnly())
+	report := []*analyze.ReportItem{}
+	for _, namespace := range namespaces {
+		namespaceReport, err := analyzer.CreateReport(namespace, analyze.Options{})
+		if err != nil {
+			ctx.Log().Debugf("Error analyzing namespace %s: %v", namespace, err)
+			continue
+		}
+
+		report = append(report, namespaceReport...)
+	}
+	if len(report) == 0 {
+		return ""
+	}
+
+	return "\n" + stripANSI(analyze.ReportToString(report))
+}
+
+var ansiRegex = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func stripANSI(s string) string {
+	return ansiRegex.ReplaceAllString(s, "")
+}
+
+type workloadFailedError struct {
+	reason string
+}
+
+func (w *workloadFailedError) Error() string {
+	return w.reason
+}
+
+// Check checks if the given workload is ready. If the workload is not ready the reason is returned,
+// if the workload has failed and will not become ready anymore an error is returned.
+func Check(ctx context.Context, client kubectl.Client, workload Workload) (bool, string, error) {
+	var (
+		ready  bool
+		reason string
+		err    error
+	)
+	switch workload.Kind {
+	case "Deployment":
+		var obj *appsv1.Deployment
+		obj, err = client.KubeClient().AppsV1().Deployments(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			ready, reason, err = deploymentReady(obj)
+		}
+	case "StatefulSet":
+		var obj *appsv1.StatefulSet
+		obj, err = client.KubeClient().AppsV1().StatefulSets(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			ready, reason = statefulSetReady(obj)
+		}
+	case "DaemonSet":
+		var obj *appsv1.DaemonSet
+		obj, err = client.KubeClient().AppsV1().DaemonSets(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			ready, reason = daemonSetReady(obj)
+		}
+	case "Job":
+		var obj *batchv1.Job
+		obj, err = client.KubeClient().BatchV1().Jobs(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			ready, reason, err = jobReady(obj)
+		}
+	default:
+		return false, "", errors.Errorf("unsupported workload kind %s", workload.Kind)
+	}
+	if kerrors.IsNotFound(err) {
+		return false, "not found", nil
+	}
+
+	return ready, reason, err
+}
+
+func deploymentReady(deployment *appsv1.Deployment) (bool, string, error) {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return false, "waiting for the deployment spec update to be observed", nil
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return false, "", &workloadFailedError{reason: fmt.Sprintf("deployment exceeded its progress deadline: %s", condition.Message)}
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.UpdatedReplicas < replicas {
+		return false, fmt.Sprintf("%d out of %d new replicas have been updated", deployment.Status.UpdatedReplicas, replicas), nil
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return false, fmt.Sprintf("%d old replicas are pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas), nil
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return false, fmt.Sprintf("%d of %d updated replicas are available", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas), nil
+	}
+
+	return true, "", nil
+}
+
+func statefulSetReady(statefulSet *appsv1.StatefulSet) (bool, string) {
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return true, ""
+	}
+	if statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return false, "waiting for the statefulset spec update to be observed"
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return false, fmt.Sprintf("%d of %d replicas are ready", statefulSet.Status.ReadyReplicas, replicas)
+	}
+
+	partition := int32(0)
+	if statefulSet.Spec.UpdateStrategy.RollingUpdate != nil && statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		partition = *statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition
+	}
+	if partition > 0 {
+		if statefulSet.Status.UpdatedReplicas < replicas-partition {
+			return false, fmt.Sprintf("%d of %d replicas have been updated", statefulSet.Status.UpdatedReplicas, replicas-partition)
+		}
+	} else if statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+		return false, fmt.Sprintf("%d of %d replicas have been updated", statefulSet.Status.UpdatedReplicas, replicas)
+	}
+
+	return true, ""
+}
+
+func daemonSetReady(daemonSet *appsv1.DaemonSet) (bool, string) {
+	if daemonSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		return true, ""
+	}
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return false, "waiting for the daemonset spec update to be observed"
+	}
+	if daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("%d of %d pods have been updated", daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled)
+	}
+	if daemonSet.Status.NumberAvailable < daemonSet.Status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("%d of %d updated pods are available", daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)
+	}
+
+	return true, ""
+}
+
+func jobReady(job *batchv1.Job) (bool, string, error) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, "", nil
+		case batchv1.JobFailed:
+			return false, "", &workloadFailedError{reason: fmt.Sprintf("job failed: %s %s", condition.Reason, condition.Message)}
+		}
+	}
+
+	return false, fmt.Sprintf("job has %d active, %d succeeded and %d failed pods", job.Status.Active, job.Status.Succeeded, job.Status.Failed), nil
+}
+
+var manifestSeparator = regexp.MustCompile(`(?m)^---`)
+
+func splitManifests(manifest string) []string {
+	manifests := []string{}
+	for _, part := range manifestSeparator.Split(manifest, -1) {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		manifests = append(manifests, part)
+	}
+
+	return manifests
+}
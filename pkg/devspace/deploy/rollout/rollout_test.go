@@ -0,0 +1,153 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	fakehelm "github.com/loft-sh/devspace/pkg/devspace/helm/testing"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestContext(cache remotecache.Cache, objects ...runtime.Object) devspacecontext.Context {
+	conf := config.NewConfig(nil, nil, latest.NewRaw(), nil, cache, nil, "")
+	return devspacecontext.NewContext(context.Background(), nil, log.Discard).
+		WithConfig(conf).
+		WithKubeClient(&fakekube.Client{
+			Client: fake.NewSimpleClientset(objects...),
+		})
+}
+
+func TestDeploymentReady(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.Int32(2)},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+		},
+	}
+
+	ready, reason, err := deploymentReady(deployment)
+	assert.NilError(t, err)
+	assert.Equal(t, ready, false)
+	assert.Equal(t, reason, "waiting for the deployment spec update to be observed")
+
+	deployment.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: 2,
+		Replicas:           3,
+		UpdatedReplicas:    2,
+		AvailableReplicas:  2,
+	}
+	ready, reason, _ = deploymentReady(deployment)
+	assert.Equal(t, ready, false)
+	assert.Equal(t, reason, "1 old replicas are pending termination")
+
+	deployment.Status.Replicas = 2
+	ready, _, _ = deploymentReady(deployment)
+	assert.Equal(t, ready, true)
+
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{
+		{
+			Type:    appsv1.DeploymentProgressing,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ProgressDeadlineExceeded",
+			Message: "ReplicaSet has timed out progressing.",
+		},
+	}
+	_, _, err = deploymentReady(deployment)
+	assert.ErrorContains(t, err, "deployment exceeded its progress deadline")
+}
+
+func TestJobReady(t *testing.T) {
+	job := &batchv1.Job{
+		Status: batchv1.JobStatus{Active: 1},
+	}
+	ready, reason, err := jobReady(job)
+	assert.NilError(t, err)
+	assert.Equal(t, ready, false)
+	assert.Equal(t, reason, "job has 1 active, 0 succeeded and 0 failed pods")
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	ready, _, err = jobReady(job)
+	assert.NilError(t, err)
+	assert.Equal(t, ready, true)
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}}
+	_, _, err = jobReady(job)
+	assert.ErrorContains(t, err, "job failed: BackoffLimitExceeded")
+}
+
+func TestWorkloadsFromHelmRelease(t *testing.T) {
+	manifest := `---
+# Source: test/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: test
+---
+# Source: test/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  namespace: other
+`
+	cache := remotecache.NewCache("", "test")
+	cache.SetDeployment("test", remotecache.DeploymentCache{
+		Name: "test",
+		Helm: &remotecache.HelmCache{
+			Release:          "test",
+			ReleaseNamespace: "test",
+			ReleaseRevision:  "1",
+		},
+	})
+
+	helmClient := &fakehelm.Client{Manifests: map[string]string{"test": manifest}}
+	workloads, err := Workloads(newTestContext(cache), &latest.DeploymentConfig{Name: "test", Helm: &latest.HelmConfig{}}, helmClient)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, workloads, []Workload{
+		{Kind: "Deployment", Name: "test", Namespace: "test"},
+		{Kind: "Job", Name: "migrate", Namespace: "other"},
+	})
+}
+
+func TestWaitTimeout(t *testing.T) {
+	ready := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "test"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.Int32(1)},
+		Status:     appsv1.StatefulSetStatus{ReadyReplicas: 1},
+	}
+	pending := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "test"},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 2,
+			UpdatedNumberScheduled: 2,
+			NumberAvailable:        1,
+		},
+	}
+
+	ctx := newTestContext(remotecache.NewCache("", "test"), ready, pending)
+	err := Wait(ctx, []Workload{
+		{Kind: "StatefulSet", Name: "ready", Namespace: "test"},
+		{Kind: "DaemonSet", Name: "pending", Namespace: "test"},
+	}, time.Millisecond*50)
+	assert.ErrorContains(t, err, "timed out after 50ms waiting for workloads to become ready:\n  - DaemonSet test/pending: 1 of 2 updated pods are available")
+}
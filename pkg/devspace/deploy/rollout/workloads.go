@@ -0,0 +1,117 @@
+package rollout
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	"github.com/loft-sh/devspace/pkg/util/yamlutil"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Workload is a kubernetes workload that is tracked during a rollout
+type Workload struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+func (w Workload) String() string {
+	return w.Kind + " " + w.Namespace + "/" + w.Name
+}
+
+// trackedKinds are the workload kinds that are tracked mapped to their api group
+var trackedKinds = map[string]string{
+	"Deployment":  "apps",
+	"StatefulSet": "apps",
+	"DaemonSet":   "apps",
+	"Job":         "batch",
+}
+
+// Workloads returns the workloads that were created by the given deployment. The objects of
+// helm releases are retrieved with the given helm client.
+func Workloads(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig, helmClient helmtypes.Client) ([]Workload, error) {
+	cacheName := deployConfig.Name
+	if deployConfig.Helm != nil && deployConfig.Helm.ReleaseName != "" {
+		cacheName = deployConfig.Helm.ReleaseName
+	}
+
+	deployCache, ok := ctx.Config().RemoteCache().GetDeployment(cacheName)
+	if !ok {
+		return nil, nil
+	}
+
+	objects := []remotecache.KubectlObject{}
+	if deployCache.Kubectl != nil {
+		objects = deployCache.Kubectl.Objects
+	} else if deployCache.Helm != nil && deployCache.Helm.Release != "" && helmClient != nil {
+		var err error
+		objects, err = helmReleaseObjects(ctx, helmClient, deployCache.Helm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get objects of helm release %s", deployCache.Helm.Release)
+		}
+	}
+
+	workloads := []Workload{}
+	for _, obj := range objects {
+		gv, err := schema.ParseGroupVersion(obj.APIVersion)
+		if err != nil {
+			continue
+		}
+
+		group, ok := trackedKinds[obj.Kind]
+		if !ok || gv.Group != group {
+			continue
+		}
+
+		namespace := obj.Namespace
+		if namespace == "" {
+			namespace = ctx.KubeClient().Namespace()
+		}
+		workloads = append(workloads, Workload{
+			Kind:      obj.Kind,
+			Name:      obj.Name,
+			Namespace: namespace,
+		})
+	}
+
+	return workloads, nil
+}
+
+// helmReleaseObjects parses the objects of the manifest of a helm release
+func helmReleaseObjects(ctx devspacecontext.Context, helmClient helmtypes.Client, helmCache *remotecache.HelmCache) ([]remotecache.KubectlObject, error) {
+	manifest, err := helmClient.GetManifest(ctx, helmCache.Release, helmCache.ReleaseNamespace)
+	if err != nil {
+		return nil, err
+	} else if manifest == "" {
+		ctx.Log().Debugf("Couldn't find manifest of helm release %s", helmCache.Release)
+		return nil, nil
+	}
+
+	objects := []remotecache.KubectlObject{}
+	for _, manifest := range splitManifests(manifest) {
+		obj := map[string]interface{}{}
+		err = yamlutil.Unmarshal([]byte(manifest), &obj)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse release manifest")
+		}
+
+		kubeObject := remotecache.KubectlObject{Namespace: helmCache.ReleaseNamespace}
+		kubeObject.APIVersion, _ = obj["apiVersion"].(string)
+		kubeObject.Kind, _ = obj["kind"].(string)
+		if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+			kubeObject.Name, _ = metadata["name"].(string)
+			if namespace, ok := metadata["namespace"].(string); ok && namespace != "" {
+				kubeObject.Namespace = namespace
+			}
+		}
+		if kubeObject.Kind == "" || kubeObject.Name == "" {
+			continue
+		}
+
+		objects = append(objects, kubeObject)
+	}
+
+	return objects, nil
+}
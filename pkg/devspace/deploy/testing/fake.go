@@ -19,7 +19,17 @@ func (f *FakeController) Deploy(ctx devspacecontext.Context, deployments []strin
 	return nil
 }
 
+// Rollback rolls back the deployments
+func (f *FakeController) Rollback(ctx devspacecontext.Context, deployments []string) error {
+	return nil
+}
+
 // Purge purges the deployments
 func (f *FakeController) Purge(ctx devspacecontext.Context, deployments []string, options *deploy.PurgeOptions) error {
 	return nil
 }
+
+// Diff shows the differences of the deployments
+func (f *FakeController) Diff(ctx devspacecontext.Context, deployments []string, options *deploy.DiffOptions) (bool, error) {
+	return false, nil
+}
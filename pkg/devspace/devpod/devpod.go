@@ -218,15 +218,27 @@ func (d *devPod) restart(ctx devspacecontext.Context, devPodConfig *latest.DevPo
 }
 
 func (d *devPod) start(ctx devspacecontext.Context, devPodConfig *latest.DevPod, opts Options, parent *tomb.Tomb) error {
-	// check first if we need to replace the pod
-	if !opts.DisablePodReplace && needPodReplace(devPodConfig) {
+	// check first if we need to intercept or replace the pod
+	devPodCache, ok := ctx.Config().RemoteCache().GetDevPod(devPodConfig.Name)
+	if !opts.DisablePodReplace && devPodConfig.Intercept != nil {
+		err := podreplace.NewPodReplacer().InterceptPod(ctx, devPodConfig)
+		if err != nil {
+			return errors.Wrap(err, "intercept pod")
+		}
+	} else if !opts.DisablePodReplace && needPodReplace(devPodConfig) {
+		if ok && devPodCache.InterceptTemplate != "" {
+			_, err := podreplace.NewPodReplacer().RevertReplacePod(ctx, &devPodCache, &deploy.PurgeOptions{ForcePurge: true})
+			if err != nil {
+				return errors.Wrap(err, "revert intercept")
+			}
+		}
+
 		err := podreplace.NewPodReplacer().ReplacePod(ctx, devPodConfig)
 		if err != nil {
 			return errors.Wrap(err, "replace pod")
 		}
 	} else {
-		devPodCache, ok := ctx.Config().RemoteCache().GetDevPod(devPodConfig.Name)
-		if ok && devPodCache.Deployment != "" {
+		if ok && (devPodCache.Deployment != "" || devPodCache.InterceptTemplate != "") {
 			_, err := podreplace.NewPodReplacer().RevertReplacePod(ctx, &devPodCache, &deploy.PurgeOptions{ForcePurge: true})
 			if err != nil {
 				return errors.Wrap(err, "replace pod")
@@ -12,6 +12,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/lockfactory"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/stringutil"
+	"github.com/pkg/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
@@ -161,6 +162,12 @@ func (d *devPodManager) Start(originalContext devspacecontext.Context, devPodCon
 	lock.Lock()
 	defer lock.Unlock()
 
+	// use the cluster the dev pod targets
+	originalContext, err := originalContext.WithKubeContext(devPodConfig.KubeConfig, devPodConfig.KubeContext)
+	if err != nil {
+		return nil, errors.Wrapf(err, "dev %s", devPodConfig.Name)
+	}
+
 	var dp *devPod
 	d.m.Lock()
 	dp = d.devPods[devPodConfig.Name]
@@ -183,7 +190,7 @@ func (d *devPodManager) Start(originalContext devspacecontext.Context, devPodCon
 	unionLogger := originalContext.Log().WithPrefix(prefix).WithSink(logpkg.GetDevPodFileLogger(prefix))
 
 	// start the dev pod
-	err := dp.Start(originalContext.WithLogger(unionLogger), devPodConfig, options)
+	err = dp.Start(originalContext.WithLogger(unionLogger), devPodConfig, options)
 	if err != nil {
 		return nil, err
 	}
@@ -197,6 +204,17 @@ func (d *devPodManager) Reset(ctx devspacecontext.Context, name string, options
 	defer lock.Unlock()
 
 	d.stop(name)
+
+	// reset the dev pod in the cluster it targets
+	if ctx.Config().Config() != nil && ctx.Config().Config().Dev[name] != nil {
+		devPodConfig := ctx.Config().Config().Dev[name]
+		var err error
+		ctx, err = ctx.WithKubeContext(devPodConfig.KubeConfig, devPodConfig.KubeContext)
+		if err != nil {
+			return errors.Wrapf(err, "dev %s", name)
+		}
+	}
+
 	devPod, ok := ctx.Config().RemoteCache().GetDevPod(name)
 	if ok {
 		_, err := podreplace.NewPodReplacer().RevertReplacePod(ctx, &devPod, options)
@@ -0,0 +1,224 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Type is the type of an event
+type Type string
+
+// List of event types that can be emitted
+const (
+	PipelineStart Type = "pipeline.start"
+	PipelineEnd   Type = "pipeline.end"
+	JobStart      Type = "job.start"
+	JobEnd        Type = "job.end"
+
+	BuildStart   Type = "build.start"
+	BuildFinish  Type = "build.finish"
+	BuildSkipped Type = "build.skipped"
+
+	DeployResult Type = "deploy.result"
+
+	SyncReady    Type = "sync.ready"
+	SyncError    Type = "sync.error"
+	SyncConflict Type = "sync.conflict"
+
+	PortForwardOpened Type = "portforward.opened"
+)
+
+// Event is a single line in the jsonl event stream
+type Event struct {
+	// Time is when the event occurred
+	Time time.Time `json:"time"`
+
+	// Type is the type of the event
+	Type Type `json:"type"`
+
+	// Data holds the typed payload of the event
+	Data interface{} `json:"data,omitempty"`
+}
+
+// Pipeline is the payload of pipeline.start, pipeline.end, job.start and job.end events
+type Pipeline struct {
+	// Name is the name of the pipeline
+	Name string `json:"name"`
+
+	// Dependency is the name of the dependency that runs the pipeline, empty for the root project
+	Dependency string `json:"dependency,omitempty"`
+
+	// Args are the arguments the pipeline or job was started with
+	Args []string `json:"args,omitempty"`
+
+	// Duration is the runtime in milliseconds, only set on end events
+	Duration int64 `json:"durationMs,omitempty"`
+
+	// Error is set if the pipeline or job failed
+	Error string `json:"error,omitempty"`
+}
+
+// Build is the payload of build.start, build.finish and build.skipped events
+type Build struct {
+	// ImageConfigName is the name of the image in the devspace.yaml
+	ImageConfigName string `json:"imageConfigName"`
+
+	// Image is the image name without tag
+	Image string `json:"image"`
+
+	// Tags are the tags the image is built with
+	Tags []string `json:"tags,omitempty"`
+
+	// Error is set if the build failed
+	Error string `json:"error,omitempty"`
+}
+
+// Deploy is the payload of deploy.result events
+type Deploy struct {
+	// Name is the name of the deployment
+	Name string `json:"name"`
+
+	// Type is the deployment method, either helm or kubectl
+	Type string `json:"type,omitempty"`
+
+	// Target is the target of the deployment as reported by the deployer status
+	Target string `json:"target,omitempty"`
+
+	// Status is the status as reported by the deployer status
+	Status string `json:"status,omitempty"`
+
+	// Deployed is true if the deployment was changed, false if it was skipped
+	Deployed bool `json:"deployed"`
+
+	// Error is set if the deployment failed
+	Error string `json:"error,omitempty"`
+}
+
+// Sync is the payload of sync.ready and sync.error events
+type Sync struct {
+	// Name is the name of the dev configuration
+	Name string `json:"name"`
+
+	// Pod is the namespace/name of the pod that is synced to
+	Pod string `json:"pod,omitempty"`
+
+	// Container is the container that is synced to
+	Container string `json:"container,omitempty"`
+
+	// LocalPath is the local path of the sync
+	LocalPath string `json:"localPath,omitempty"`
+
+	// ContainerPath is the container path of the sync
+	ContainerPath string `json:"containerPath,omitempty"`
+
+	// Error is set for sync.error events
+	Error string `json:"error,omitempty"`
+}
+
+// Conflict is the payload of sync.conflict events
+type Conflict struct {
+	// Name is the name of the dev configuration
+	Name string `json:"name"`
+
+	// SyncPath is the path of the sync configuration
+	SyncPath string `json:"syncPath"`
+
+	// Path is the path of the conflicting file relative to the sync path
+	Path string `json:"path"`
+
+	// Strategy is the conflict strategy of the sync
+	Strategy string `json:"strategy"`
+
+	// Resolution is how the conflict was resolved
+	Resolution string `json:"resolution"`
+}
+
+// PortForward is the payload of portforward.opened events
+type PortForward struct {
+	// Name is the name of the dev configuration, empty for reverse port forwards
+	Name string `json:"name,omitempty"`
+
+	// Pod is the namespace/name of the pod the ports are forwarded to
+	Pod string `json:"pod,omitempty"`
+
+	// Reverse is true if the ports are forwarded from the container to the local machine
+	Reverse bool `json:"reverse,omitempty"`
+
+	// Protocol is the protocol of the forwarded ports, either tcp or udp
+	Protocol string `json:"protocol,omitempty"`
+
+	// Ports are the forwarded ports in the format local:remote
+	Ports []string `json:"ports"`
+}
+
+// Emitter receives the emitted events
+type Emitter interface {
+	Emit(event Event)
+}
+
+var (
+	emitterMutex   sync.RWMutex
+	defaultEmitter Emitter
+)
+
+// SetEmitter sets the global emitter, nil disables the event stream
+func SetEmitter(emitter Emitter) {
+	emitterMutex.Lock()
+	defer emitterMutex.Unlock()
+
+	defaultEmitter = emitter
+}
+
+// Enabled returns true if there is an emitter that receives events
+func Enabled() bool {
+	emitterMutex.RLock()
+	defer emitterMutex.RUnlock()
+
+	return defaultEmitter != nil
+}
+
+// Emit sends an event with the given type and payload to the global emitter
+func Emit(eventType Type, data interface{}) {
+	emitterMutex.RLock()
+	emitter := defaultEmitter
+	emitterMutex.RUnlock()
+	if emitter == nil {
+		return
+	}
+
+	emitter.Emit(Event{
+		Time: time.Now(),
+		Type: eventType,
+		Data: data,
+	})
+}
+
+// ErrorString returns the error message or an empty string if err is nil
+func ErrorString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+// NewJSONLEmitter creates an emitter that writes every event as a single json line to out
+func NewJSONLEmitter(out io.Writer) Emitter {
+	return &jsonlEmitter{
+		encoder: json.NewEncoder(out),
+	}
+}
+
+type jsonlEmitter struct {
+	m       sync.Mutex
+	encoder *json.Encoder
+}
+
+func (j *jsonlEmitter) Emit(event Event) {
+	j.m.Lock()
+	defer j.m.Unlock()
+
+	_ = j.encoder.Encode(event)
+}
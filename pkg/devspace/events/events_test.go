@@ -0,0 +1,51 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestJSONLEmitter(t *testing.T) {
+	out := &bytes.Buffer{}
+	SetEmitter(NewJSONLEmitter(out))
+	defer SetEmitter(nil)
+
+	assert.Equal(t, Enabled(), true)
+	Emit(BuildFinish, &Build{ImageConfigName: "app", Image: "myrepo/app", Tags: []string{"abc", "latest"}})
+	Emit(DeployResult, &Deploy{Name: "app", Type: "helm", Status: "Deployed", Deployed: true})
+
+	lines := []map[string]interface{}{}
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		line := map[string]interface{}{}
+		assert.NilError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	assert.Equal(t, len(lines), 2)
+	assert.Equal(t, lines[0]["type"], "build.finish")
+	assert.DeepEqual(t, lines[0]["data"], map[string]interface{}{
+		"imageConfigName": "app",
+		"image":           "myrepo/app",
+		"tags":            []interface{}{"abc", "latest"},
+	})
+	assert.Equal(t, lines[1]["type"], "deploy.result")
+	assert.DeepEqual(t, lines[1]["data"], map[string]interface{}{
+		"name":     "app",
+		"type":     "helm",
+		"status":   "Deployed",
+		"deployed": true,
+	})
+}
+
+func TestEmitDisabled(t *testing.T) {
+	SetEmitter(nil)
+	assert.Equal(t, Enabled(), false)
+
+	// must not panic without an emitter
+	Emit(JobStart, &Pipeline{Name: "dev"})
+}
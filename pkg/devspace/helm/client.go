@@ -1,12 +1,27 @@
 package helm
 
 import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/helm/sdk"
 	"github.com/loft-sh/devspace/pkg/devspace/helm/types"
 	v3 "github.com/loft-sh/devspace/pkg/devspace/helm/v3"
 	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
 )
 
 // NewClient creates a new helm client based on the config
 func NewClient(log log.Logger) (types.Client, error) {
 	return v3.NewClient(log)
 }
+
+// NewClientForEngine creates a new helm client for the given engine
+func NewClientForEngine(engine latest.HelmEngine, log log.Logger) (types.Client, error) {
+	switch engine {
+	case "", latest.HelmEngineCLI:
+		return v3.NewClient(log)
+	case latest.HelmEngineSDK:
+		return sdk.NewClient(log)
+	}
+
+	return nil, errors.Errorf("unknown helm engine %s", engine)
+}
@@ -0,0 +1,20 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestNewClientForEngine(t *testing.T) {
+	for _, engine := range []latest.HelmEngine{"", latest.HelmEngineCLI} {
+		client, err := NewClientForEngine(engine, log.Discard)
+		assert.NilError(t, err)
+		assert.Assert(t, client != nil)
+	}
+
+	_, err := NewClientForEngine("unknown", log.Discard)
+	assert.ErrorContains(t, err, "unknown helm engine unknown")
+}
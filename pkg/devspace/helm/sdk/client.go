@@ -0,0 +1,443 @@
+package sdk
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	dependencyutil "github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	"github.com/loft-sh/devspace/pkg/devspace/helm/generic"
+	"github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/downloader"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+type client struct {
+	log      log.Logger
+	settings *cli.EnvSettings
+
+	// actionConfig creates the helm action configuration for the given namespace,
+	// can be exchanged for testing
+	actionConfig func(ctx devspacecontext.Context, namespace string) (*action.Configuration, error)
+}
+
+// NewClient creates a new helm client that uses the helm go sdk instead of the helm binary
+func NewClient(log log.Logger) (types.Client, error) {
+	c := &client{
+		log:      log,
+		settings: cli.New(),
+	}
+	c.actionConfig = c.newActionConfig
+	return c, nil
+}
+
+func (c *client) newActionConfig(ctx devspacecontext.Context, namespace string) (*action.Configuration, error) {
+	cfg := &action.Configuration{}
+	err := cfg.Init(newRESTClientGetter(ctx.KubeClient(), namespace), namespace, os.Getenv("HELM_DRIVER"), c.log.Debugf)
+	if err != nil {
+		return nil, errors.Wrap(err, "init helm")
+	}
+
+	cfg.RegistryClient, err = registry.NewClient(registry.ClientOptCredentialsFile(c.settings.RegistryConfig))
+	if err != nil {
+		return nil, errors.Wrap(err, "create registry client")
+	}
+
+	return cfg, nil
+}
+
+func (c *client) DownloadChart(ctx devspacecontext.Context, helmConfig *latest.HelmConfig) (string, error) {
+	chartName, err := dependencyutil.DownloadDependency(ctx.Context(), ctx.WorkingDir(), helmConfig.Chart.Source, ctx.Log())
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(chartName), nil
+}
+
+// InstallChart installs or upgrades the given chart via the helm sdk
+func (c *client) InstallChart(ctx devspacecontext.Context, releaseName string, releaseNamespace string, values map[string]interface{}, helmConfig *latest.HelmConfig) (*types.Release, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	cfg, err := c.actionConfig(ctx, releaseNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	install := action.NewInstall(cfg)
+	install.ReleaseName = releaseName
+	install.Namespace = releaseNamespace
+	upgrade := action.NewUpgrade(cfg)
+	upgrade.Namespace = releaseNamespace
+	err = parseUpgradeArgs(helmConfig.UpgradeArgs, install, upgrade)
+	if err != nil {
+		return nil, err
+	}
+
+	chrt, err := c.loadChart(ctx, cfg, &install.ChartPathOptions, helmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if the release exists already
+	history, err := action.NewHistory(cfg).Run(releaseName)
+	if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil, errors.Wrapf(err, "retrieve history of release %s", releaseName)
+	}
+
+	var rel *release.Release
+	if len(history) == 0 || !hasDeployedRevision(history) {
+		// a release without a deployed revision, e.g. because its only revision failed,
+		// is replaced by a fresh install
+		install.Replace = len(history) > 0
+		ctx.Log().Debugf("Install release %s in namespace %s", releaseName, releaseNamespace)
+		rel, err = install.Run(chrt, values)
+		if err != nil {
+			return nil, errors.Wrapf(err, "install release %s", releaseName)
+		}
+	} else {
+		ctx.Log().Debugf("Upgrade release %s in namespace %s", releaseName, releaseNamespace)
+		rel, err = upgrade.Run(releaseName, chrt, values)
+		if err != nil {
+			return nil, errors.Wrapf(err, "upgrade release %s", releaseName)
+		}
+	}
+
+	if helmConfig.DisplayOutput {
+		writer := ctx.Log().Writer(logrus.InfoLevel, false)
+		_, _ = writer.Write([]byte(releaseOutput(rel)))
+		_ = writer.Close()
+	}
+
+	return convertRelease(rel), nil
+}
+
+// hasDeployedRevision checks if one of the revisions of a release was deployed successfully
+func hasDeployedRevision(history []*release.Release) bool {
+	for _, rel := range history {
+		if rel.Info != nil && (rel.Info.Status == release.StatusDeployed || rel.Info.Status == release.StatusSuperseded) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *client) Template(ctx devspacecontext.Context, releaseName, releaseNamespace string, values map[string]interface{}, helmConfig *latest.HelmConfig) (string, error) {
+	if releaseNamespace == "" && ctx.KubeClient() != nil {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	// templating does not need a cluster connection
+	cfg := &action.Configuration{Log: c.log.Debugf}
+	registryClient, err := registry.NewClient(registry.ClientOptCredentialsFile(c.settings.RegistryConfig))
+	if err != nil {
+		return "", errors.Wrap(err, "create registry client")
+	}
+	cfg.RegistryClient = registryClient
+
+	install := action.NewInstall(cfg)
+	install.ReleaseName = releaseName
+	install.Namespace = releaseNamespace
+	install.DryRun = true
+	install.Replace = true
+	install.ClientOnly = true
+	install.IncludeCRDs = true
+	err = parseTemplateArgs(helmConfig.TemplateArgs, install)
+	if err != nil {
+		return "", err
+	}
+
+	chrt, err := c.loadChart(ctx, cfg, &install.ChartPathOptions, helmConfig)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := install.Run(chrt, values)
+	if err != nil {
+		return "", errors.Wrapf(err, "template release %s", releaseName)
+	}
+
+	// add the hooks the same way helm template does
+	manifests := &strings.Builder{}
+	manifests.WriteString(strings.TrimSpace(rel.Manifest))
+	for _, hook := range rel.Hooks {
+		manifests.WriteString("\n---\n# Source: " + hook.Path + "\n" + strings.TrimSpace(hook.Manifest))
+	}
+
+	return manifests.String() + "\n", nil
+}
+
+func (c *client) DeleteRelease(ctx devspacecontext.Context, releaseName string, releaseNamespace string) error {
+	if releaseNamespace == "" {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	cfg, err := c.actionConfig(ctx, releaseNamespace)
+	if err != nil {
+		return err
+	}
+
+	_, err = action.NewUninstall(cfg).Run(releaseName)
+	if err != nil {
+		return errors.Wrapf(err, "uninstall release %s", releaseName)
+	}
+
+	return nil
+}
+
+func (c *client) RollbackRelease(ctx devspacecontext.Context, releaseName string, releaseNamespace string, revision string) error {
+	if releaseNamespace == "" {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	cfg, err := c.actionConfig(ctx, releaseNamespace)
+	if err != nil {
+		return err
+	}
+
+	// an empty revision rolls back to the previous one the same way helm rollback does
+	rollback := action.NewRollback(cfg)
+	if revision != "" {
+		rollback.Version, err = strconv.Atoi(revision)
+		if err != nil {
+			return errors.Wrapf(err, "parse revision %s", revision)
+		}
+	}
+	rollback.Wait = true
+	rollback.Timeout = 5 * time.Minute
+	err = rollback.Run(releaseName)
+	if err != nil {
+		return errors.Wrapf(err, "rollback release %s", releaseName)
+	}
+
+	return nil
+}
+
+func (c *client) ListReleases(ctx devspacecontext.Context, namespace string) ([]*types.Release, error) {
+	if namespace == "" {
+		namespace = ctx.KubeClient().Namespace()
+	}
+
+	cfg, err := c.actionConfig(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	list := action.NewList(cfg)
+	list.SetStateMask()
+	releases, err := list.Run()
+	if err != nil {
+		return nil, errors.Wrap(err, "list releases")
+	}
+
+	retReleases := make([]*types.Release, 0, len(releases))
+	for _, rel := range releases {
+		retReleases = append(retReleases, convertRelease(rel))
+	}
+
+	return retReleases, nil
+}
+
+func (c *client) GetManifest(ctx devspacecontext.Context, releaseName string, releaseNamespace string) (string, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	cfg, err := c.actionConfig(ctx, releaseNamespace)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := action.NewGet(cfg).Run(releaseName)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return "", nil
+		}
+
+		return "", errors.Wrapf(err, "get release %s", releaseName)
+	}
+
+	return rel.Manifest, nil
+}
+
+// loadChart locates, downloads and loads the chart of the given helm config
+func (c *client) loadChart(ctx devspacecontext.Context, cfg *action.Configuration, chartPathOptions *action.ChartPathOptions, helmConfig *latest.HelmConfig) (*chart.Chart, error) {
+	chartPath := ""
+	if helmConfig.Chart.Source != nil {
+		dependencyPath, err := dependencyutil.GetDependencyPath(ctx.Context(), ctx.WorkingDir(), helmConfig.Chart.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		chartPath = filepath.Dir(dependencyPath)
+	} else {
+		chartName, chartRepo := generic.ChartNameAndRepo(helmConfig)
+		chartPathOptions.RepoURL = chartRepo
+		chartPathOptions.Version = helmConfig.Chart.Version
+
+		// log into OCI registry if specified
+		if strings.HasPrefix(chartName, "oci://") {
+			if helmConfig.Chart.Username != "" && helmConfig.Chart.Password != "" {
+				chartNameURL, err := url.Parse(chartName)
+				if err != nil {
+					return nil, errors.Wrap(err, "chartName malformed for oci registry")
+				}
+
+				err = cfg.RegistryClient.Login(chartNameURL.Host, registry.LoginOptBasicAuth(helmConfig.Chart.Username, helmConfig.Chart.Password))
+				if err != nil {
+					return nil, errors.Wrap(err, "login oci registry")
+				}
+			}
+		} else {
+			chartPathOptions.Username = helmConfig.Chart.Username
+			chartPathOptions.Password = helmConfig.Chart.Password
+		}
+
+		// prefer local charts relative to the working dir
+		localPath := chartName
+		if !filepath.IsAbs(localPath) {
+			localPath = filepath.Join(ctx.WorkingDir(), chartName)
+		}
+		if _, err := os.Stat(localPath); err == nil && chartRepo == "" {
+			chartPath = localPath
+		} else {
+			var err error
+			chartPath, err = chartPathOptions.LocateChart(chartName, c.settings)
+			if err != nil {
+				return nil, errors.Wrapf(err, "locate chart %s", chartName)
+			}
+		}
+	}
+
+	// Update dependencies if needed
+	if helmConfig.DisableDependencyUpdate == nil || !*helmConfig.DisableDependencyUpdate {
+		stat, err := os.Stat(chartPath)
+		if err == nil && stat.IsDir() {
+			writer := ctx.Log().Writer(logrus.DebugLevel, false)
+			manager := &downloader.Manager{
+				Out:              writer,
+				ChartPath:        chartPath,
+				Getters:          getter.All(c.settings),
+				RegistryClient:   cfg.RegistryClient,
+				RepositoryConfig: c.settings.RepositoryConfig,
+				RepositoryCache:  c.settings.RepositoryCache,
+			}
+			err = manager.Update()
+			_ = writer.Close()
+			if err != nil {
+				ctx.Log().Warnf("error running helm dependency update: %v", err)
+			}
+		}
+	}
+
+	chrt, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "load chart %s", chartPath)
+	}
+
+	return chrt, nil
+}
+
+// parseUpgradeArgs applies the helm upgrade flags that are supported by the sdk engine
+func parseUpgradeArgs(args []string, install *action.Install, upgrade *action.Upgrade) error {
+	flags := pflag.NewFlagSet("upgrade", pflag.ContinueOnError)
+	wait := flags.Bool("wait", false, "")
+	waitForJobs := flags.Bool("wait-for-jobs", false, "")
+	timeout := flags.Duration("timeout", 5*time.Minute, "")
+	atomic := flags.Bool("atomic", false, "")
+	force := flags.Bool("force", false, "")
+	createNamespace := flags.Bool("create-namespace", false, "")
+	resetValues := flags.Bool("reset-values", false, "")
+	reuseValues := flags.Bool("reuse-values", false, "")
+	description := flags.String("description", "", "")
+	err := flags.Parse(args)
+	if err != nil {
+		return errors.Wrap(err, "upgradeArgs are not supported by the helm sdk engine")
+	}
+
+	install.Wait, upgrade.Wait = *wait || *atomic, *wait || *atomic
+	install.WaitForJobs, upgrade.WaitForJobs = *waitForJobs, *waitForJobs
+	install.Timeout, upgrade.Timeout = *timeout, *timeout
+	install.Atomic, upgrade.Atomic = *atomic, *atomic
+	install.Description, upgrade.Description = *description, *description
+	install.CreateNamespace = *createNamespace
+	upgrade.Force = *force
+	upgrade.ResetValues = *resetValues
+	upgrade.ReuseValues = *reuseValues
+	return nil
+}
+
+// parseTemplateArgs applies the helm template flags that are supported by the sdk engine
+func parseTemplateArgs(args []string, install *action.Install) error {
+	flags := pflag.NewFlagSet("template", pflag.ContinueOnError)
+	skipCRDs := flags.Bool("skip-crds", false, "")
+	isUpgrade := flags.Bool("is-upgrade", false, "")
+	kubeVersion := flags.String("kube-version", "", "")
+	apiVersions := flags.StringSliceP("api-versions", "a", nil, "")
+	err := flags.Parse(args)
+	if err != nil {
+		return errors.Wrap(err, "templateArgs are not supported by the helm sdk engine")
+	}
+
+	install.IncludeCRDs = !*skipCRDs
+	install.IsUpgrade = *isUpgrade
+	install.APIVersions = chartutil.VersionSet(*apiVersions)
+	if *kubeVersion != "" {
+		parsedKubeVersion, err := chartutil.ParseKubeVersion(*kubeVersion)
+		if err != nil {
+			return errors.Wrapf(err, "invalid kube version '%s'", *kubeVersion)
+		}
+
+		install.KubeVersion = parsedKubeVersion
+	}
+
+	return nil
+}
+
+func convertRelease(rel *release.Release) *types.Release {
+	if rel == nil {
+		return nil
+	}
+
+	retRelease := &types.Release{
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Revision:  strconv.Itoa(rel.Version),
+	}
+	if rel.Info != nil {
+		retRelease.Status = rel.Info.Status.String()
+		retRelease.LastDeployed = rel.Info.LastDeployed.Format("2006-01-02 15:04:05.999999999 -0700 MST")
+	}
+
+	return retRelease
+}
+
+func releaseOutput(rel *release.Release) string {
+	output := "NAME: " + rel.Name + "\nNAMESPACE: " + rel.Namespace + "\nREVISION: " + strconv.Itoa(rel.Version) + "\n"
+	if rel.Info != nil {
+		output += "STATUS: " + rel.Info.Status.String() + "\n"
+		if rel.Info.Notes != "" {
+			output += "NOTES:\n" + strings.TrimSpace(rel.Info.Notes) + "\n"
+		}
+	}
+
+	return output
+}
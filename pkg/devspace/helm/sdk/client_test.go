@@ -0,0 +1,212 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testChartYaml = `apiVersion: v2
+name: test
+version: 0.1.0
+`
+
+const testTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+data:
+  value: {{ .Values.value | quote }}
+`
+
+func newFakeClient(t *testing.T) (*client, devspacecontext.Context) {
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "chart", "templates"), 0755)
+	assert.NilError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "chart", "Chart.yaml"), []byte(testChartYaml), 0666)
+	assert.NilError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "chart", "templates", "configmap.yaml"), []byte(testTemplate), 0666)
+	assert.NilError(t, err)
+
+	// all actions share the same in memory release storage
+	cfg := &action.Configuration{
+		Releases:     storage.Init(driver.NewMemory()),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          t.Logf,
+	}
+	c := &client{
+		log:      log.Discard,
+		settings: cli.New(),
+		actionConfig: func(ctx devspacecontext.Context, namespace string) (*action.Configuration, error) {
+			return cfg, nil
+		},
+	}
+
+	kubeClient := &fakekube.Client{
+		Client: fake.NewSimpleClientset(),
+	}
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).
+		WithWorkingDir(dir).
+		WithKubeClient(kubeClient)
+	return c, ctx
+}
+
+func TestInstallChart(t *testing.T) {
+	c, ctx := newFakeClient(t)
+	helmConfig := &latest.HelmConfig{
+		Chart: &latest.ChartConfig{Name: "./chart"},
+	}
+
+	rel, err := c.InstallChart(ctx, "test", "test-namespace", map[string]interface{}{"value": "first"}, helmConfig)
+	assert.NilError(t, err)
+	assert.Equal(t, rel.Name, "test")
+	assert.Equal(t, rel.Revision, "1")
+	assert.Equal(t, rel.Status, "deployed")
+
+	// the second install should upgrade the release
+	rel, err = c.InstallChart(ctx, "test", "test-namespace", map[string]interface{}{"value": "second"}, helmConfig)
+	assert.NilError(t, err)
+	assert.Equal(t, rel.Revision, "2")
+
+	releases, err := c.ListReleases(ctx, "test-namespace")
+	assert.NilError(t, err)
+	assert.Equal(t, len(releases), 1)
+	assert.Equal(t, releases[0].Revision, "2")
+
+	err = c.DeleteRelease(ctx, "test", "test-namespace")
+	assert.NilError(t, err)
+	releases, err = c.ListReleases(ctx, "test-namespace")
+	assert.NilError(t, err)
+	assert.Equal(t, len(releases), 0)
+}
+
+func TestInstallChartFailedRelease(t *testing.T) {
+	c, ctx := newFakeClient(t)
+	helmConfig := &latest.HelmConfig{
+		Chart: &latest.ChartConfig{Name: "./chart"},
+	}
+
+	// the only revision of the release failed
+	cfg, err := c.actionConfig(ctx, "test-namespace")
+	assert.NilError(t, err)
+	err = cfg.Releases.Create(&release.Release{
+		Name:      "test",
+		Namespace: "test-namespace",
+		Version:   1,
+		Info:      &release.Info{Status: release.StatusFailed},
+	})
+	assert.NilError(t, err)
+
+	// the failed release should be replaced
+	rel, err := c.InstallChart(ctx, "test", "test-namespace", map[string]interface{}{"value": "first"}, helmConfig)
+	assert.NilError(t, err)
+	assert.Equal(t, rel.Revision, "2")
+	assert.Equal(t, rel.Status, "deployed")
+
+	// and upgraded afterwards
+	rel, err = c.InstallChart(ctx, "test", "test-namespace", map[string]interface{}{"value": "second"}, helmConfig)
+	assert.NilError(t, err)
+	assert.Equal(t, rel.Revision, "3")
+	assert.Equal(t, rel.Status, "deployed")
+}
+
+func TestRollbackRelease(t *testing.T) {
+	c, ctx := newFakeClient(t)
+	helmConfig := &latest.HelmConfig{
+		Chart: &latest.ChartConfig{Name: "./chart"},
+	}
+
+	for _, value := range []string{"first", "second", "third"} {
+		_, err := c.InstallChart(ctx, "test", "test-namespace", map[string]interface{}{"value": value}, helmConfig)
+		assert.NilError(t, err)
+	}
+
+	// rollback to a specific revision
+	err := c.RollbackRelease(ctx, "test", "test-namespace", "1")
+	assert.NilError(t, err)
+	releases, err := c.ListReleases(ctx, "test-namespace")
+	assert.NilError(t, err)
+	assert.Equal(t, len(releases), 1)
+	assert.Equal(t, releases[0].Revision, "4")
+	manifest, err := c.GetManifest(ctx, "test", "test-namespace")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(manifest, `value: "first"`), manifest)
+
+	// an empty revision rolls back to the previous revision
+	err = c.RollbackRelease(ctx, "test", "test-namespace", "")
+	assert.NilError(t, err)
+	manifest, err = c.GetManifest(ctx, "test", "test-namespace")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(manifest, `value: "third"`), manifest)
+
+	err = c.RollbackRelease(ctx, "test", "test-namespace", "latest")
+	assert.ErrorContains(t, err, "parse revision latest")
+	err = c.RollbackRelease(ctx, "missing", "test-namespace", "1")
+	assert.ErrorContains(t, err, "rollback release missing")
+}
+
+func TestGetManifest(t *testing.T) {
+	c, ctx := newFakeClient(t)
+	helmConfig := &latest.HelmConfig{
+		Chart: &latest.ChartConfig{Name: "./chart"},
+	}
+
+	// a missing release has no manifest
+	manifest, err := c.GetManifest(ctx, "test", "test-namespace")
+	assert.NilError(t, err)
+	assert.Equal(t, manifest, "")
+
+	_, err = c.InstallChart(ctx, "test", "test-namespace", map[string]interface{}{"value": "deployed"}, helmConfig)
+	assert.NilError(t, err)
+	manifest, err = c.GetManifest(ctx, "test", "test-namespace")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(manifest, "kind: ConfigMap"), manifest)
+	assert.Assert(t, strings.Contains(manifest, "name: test"), manifest)
+	assert.Assert(t, strings.Contains(manifest, `value: "deployed"`), manifest)
+}
+
+func TestTemplate(t *testing.T) {
+	c, ctx := newFakeClient(t)
+	helmConfig := &latest.HelmConfig{
+		Chart: &latest.ChartConfig{Name: "./chart"},
+	}
+
+	out, err := c.Template(ctx, "test", "test-namespace", map[string]interface{}{"value": "templated"}, helmConfig)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(out, "kind: ConfigMap"), out)
+	assert.Assert(t, strings.Contains(out, `value: "templated"`), out)
+
+	// template must not create a release
+	releases, err := c.ListReleases(ctx, "test-namespace")
+	assert.NilError(t, err)
+	assert.Equal(t, len(releases), 0)
+}
+
+func TestUnsupportedUpgradeArgs(t *testing.T) {
+	c, ctx := newFakeClient(t)
+	helmConfig := &latest.HelmConfig{
+		Chart:       &latest.ChartConfig{Name: "./chart"},
+		UpgradeArgs: []string{"--post-renderer", "./script.sh"},
+	}
+
+	_, err := c.InstallChart(ctx, "test", "test-namespace", nil, helmConfig)
+	assert.ErrorContains(t, err, "not supported by the helm sdk engine")
+}
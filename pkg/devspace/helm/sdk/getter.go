@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// restClientGetter passes the config of the DevSpace kube client to the helm sdk, so that
+// helm talks to the same cluster and namespace as the rest of DevSpace
+type restClientGetter struct {
+	client    kubectl.Client
+	namespace string
+}
+
+func newRESTClientGetter(client kubectl.Client, namespace string) *restClientGetter {
+	return &restClientGetter{
+		client:    client,
+		namespace: namespace,
+	}
+}
+
+// ToRESTConfig implements interface
+func (r *restClientGetter) ToRESTConfig() (*rest.Config, error) {
+	return rest.CopyConfig(r.client.RestConfig()), nil
+}
+
+// ToDiscoveryClient implements interface
+func (r *restClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(rest.CopyConfig(r.client.RestConfig()))
+	if err != nil {
+		return nil, err
+	}
+
+	return memory.NewMemCacheClient(discoveryClient), nil
+}
+
+// ToRESTMapper implements interface
+func (r *restClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	discoveryClient, err := r.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+	return restmapper.NewShortcutExpander(mapper, discoveryClient), nil
+}
+
+// ToRawKubeConfigLoader implements interface
+func (r *restClientGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	return &namespacedClientConfig{
+		config:    r.client.ClientConfig(),
+		namespace: r.namespace,
+	}
+}
+
+// namespacedClientConfig overrides the namespace of the wrapped client config
+type namespacedClientConfig struct {
+	config    clientcmd.ClientConfig
+	namespace string
+}
+
+// RawConfig implements interface
+func (n *namespacedClientConfig) RawConfig() (clientcmdapi.Config, error) {
+	return n.config.RawConfig()
+}
+
+// ClientConfig implements interface
+func (n *namespacedClientConfig) ClientConfig() (*rest.Config, error) {
+	return n.config.ClientConfig()
+}
+
+// Namespace implements interface
+func (n *namespacedClientConfig) Namespace() (string, bool, error) {
+	return n.namespace, true, nil
+}
+
+// ConfigAccess implements interface
+func (n *namespacedClientConfig) ConfigAccess() clientcmd.ConfigAccess {
+	return n.config.ConfigAccess()
+}
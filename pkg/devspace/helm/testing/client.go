@@ -2,6 +2,7 @@ package v2
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
@@ -12,7 +13,8 @@ import (
 
 // Client implements Interface
 type Client struct {
-	Releases []*types.Release
+	Releases  []*types.Release
+	Manifests map[string]string
 }
 
 func (f *Client) DownloadChart(ctx devspacecontext.Context, helmConfig *latest.HelmConfig) (string, error) {
@@ -35,6 +37,22 @@ func (f *Client) DeleteRelease(ctx devspacecontext.Context, releaseName string,
 	return fmt.Errorf("release %s not found", releaseName)
 }
 
+// RollbackRelease rolls back a helm release to the given revision
+func (f *Client) RollbackRelease(ctx devspacecontext.Context, releaseName string, releaseNamespace string, revision string) error {
+	for _, release := range f.Releases {
+		if release.Name == releaseName {
+			current, err := strconv.Atoi(release.Revision)
+			if err != nil {
+				return err
+			}
+
+			release.Revision = strconv.Itoa(current + 1)
+			return nil
+		}
+	}
+	return fmt.Errorf("release %s not found", releaseName)
+}
+
 // ListReleases lists all helm Releases
 func (f *Client) ListReleases(ctx devspacecontext.Context, releaseNamespace string) ([]*types.Release, error) {
 	return f.Releases, nil
@@ -65,3 +83,8 @@ func (f *Client) InstallChart(ctx devspacecontext.Context, releaseName string, r
 func (f *Client) Template(ctx devspacecontext.Context, releaseName, releaseNamespace string, values map[string]interface{}, helmConfig *latest.HelmConfig) (string, error) {
 	return "", nil
 }
+
+// GetManifest returns the manifest of a helm release
+func (f *Client) GetManifest(ctx devspacecontext.Context, releaseName string, releaseNamespace string) (string, error) {
+	return f.Manifests[releaseName], nil
+}
@@ -64,7 +64,7 @@ func (c *client) InstallChart(ctx devspacecontext.Context, releaseName string, r
 	// Chart settings
 	chartPath := ""
 	if helmConfig.Chart.Source != nil {
-		dependencyPath, err := dependencyutil.GetDependencyPath(ctx.WorkingDir(), helmConfig.Chart.Source)
+		dependencyPath, err := dependencyutil.GetDependencyPath(ctx.Context(), ctx.WorkingDir(), helmConfig.Chart.Source)
 		if err != nil {
 			return nil, err
 		}
@@ -168,7 +168,7 @@ func (c *client) Template(ctx devspacecontext.Context, releaseName, releaseNames
 	// Chart settings
 	chartPath := ""
 	if helmConfig.Chart.Source != nil {
-		dependencyPath, err := dependencyutil.GetDependencyPath(ctx.WorkingDir(), helmConfig.Chart.Source)
+		dependencyPath, err := dependencyutil.GetDependencyPath(ctx.Context(), ctx.WorkingDir(), helmConfig.Chart.Source)
 		if err != nil {
 			return "", err
 		}
@@ -235,6 +235,28 @@ func (c *client) DeleteRelease(ctx devspacecontext.Context, releaseName string,
 	return nil
 }
 
+func (c *client) RollbackRelease(ctx devspacecontext.Context, releaseName string, releaseNamespace string, revision string) error {
+	if releaseNamespace == "" {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	args := []string{
+		"rollback",
+		releaseName,
+		revision,
+		"--wait",
+	}
+	if releaseNamespace != "" {
+		args = append(args, "--namespace", releaseNamespace)
+	}
+	_, err := c.genericHelm.Exec(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c *client) ListReleases(ctx devspacecontext.Context, namespace string) ([]*types.Release, error) {
 	args := []string{
 		"list",
@@ -260,3 +282,28 @@ func (c *client) ListReleases(ctx devspacecontext.Context, namespace string) ([]
 
 	return releases, nil
 }
+
+func (c *client) GetManifest(ctx devspacecontext.Context, releaseName string, releaseNamespace string) (string, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	args := []string{
+		"get",
+		"manifest",
+		releaseName,
+	}
+	if releaseNamespace != "" {
+		args = append(args, "--namespace", releaseNamespace)
+	}
+	out, err := c.genericHelm.Exec(ctx, args)
+	if err != nil {
+		if strings.Contains(err.Error(), "release: not found") {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return string(out), nil
+}
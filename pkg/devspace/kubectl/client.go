@@ -23,6 +23,7 @@ import (
 	"github.com/sirupsen/logrus"
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -40,6 +41,9 @@ type Client interface {
 	// KubeClient returns an interface to a kube client
 	KubeClient() kubernetes.Interface
 
+	// DynamicClient returns an interface to a dynamic kube client
+	DynamicClient() dynamic.Interface
+
 	// Namespace returns the default namespace of the kube context
 	Namespace() string
 
@@ -83,10 +87,11 @@ type Client interface {
 }
 
 type client struct {
-	Client       kubernetes.Interface
-	clientConfig clientcmd.ClientConfig
-	restConfig   *rest.Config
-	kubeLoader   kubeconfig.Loader
+	Client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	clientConfig  clientcmd.ClientConfig
+	restConfig    *rest.Config
+	kubeLoader    kubeconfig.Loader
 
 	currentContext string
 	namespace      string
@@ -130,11 +135,17 @@ func NewClientFromContext(context, namespace string, switchContext bool, kubeLoa
 		return nil, errors.Wrap(err, "new client")
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "new dynamic client")
+	}
+
 	return &client{
-		Client:       kubeClient,
-		clientConfig: clientConfig,
-		restConfig:   restConfig,
-		kubeLoader:   kubeLoader,
+		Client:        kubeClient,
+		dynamicClient: dynamicClient,
+		clientConfig:  clientConfig,
+		restConfig:    restConfig,
+		kubeLoader:    kubeLoader,
 
 		namespace:      activeNamespace,
 		currentContext: activeContext,
@@ -304,6 +315,10 @@ func (client *client) KubeClient() kubernetes.Interface {
 	return client.Client
 }
 
+func (client *client) DynamicClient() dynamic.Interface {
+	return client.dynamicClient
+}
+
 func (client *client) Namespace() string {
 	return client.namespace
 }
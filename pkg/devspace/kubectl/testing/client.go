@@ -19,6 +19,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
@@ -29,6 +30,7 @@ var _ kubectl.Client = &Client{}
 // Client is a fake implementation of the kubectl.Client interface
 type Client struct {
 	Client             kubernetes.Interface
+	Dynamic            dynamic.Interface
 	KubeLoader         kubeconfig.Loader
 	IsKubernetes       bool
 	Context            string
@@ -59,6 +61,11 @@ func (c *Client) KubeClient() kubernetes.Interface {
 	return c.Client
 }
 
+// DynamicClient is a fake implementation of function
+func (c *Client) DynamicClient() dynamic.Interface {
+	return c.Dynamic
+}
+
 // Namespace is a fake implementation of function
 func (c *Client) Namespace() string {
 	return "testNamespace"
@@ -173,7 +173,7 @@ func applySetValues(ctx devspacecontext.Context, name, objName string, set, setS
 		return nil, err
 	}
 
-	return ctx.WithConfig(config.NewConfig(
+	return ctx.WithConfig(config.WithLockfile(config.NewConfig(
 		ctx.Config().Raw(),
 		rawConfig,
 		latestConfig,
@@ -181,7 +181,7 @@ func applySetValues(ctx devspacecontext.Context, name, objName string, set, setS
 		ctx.Config().RemoteCache(),
 		ctx.Config().Variables(),
 		ctx.Config().Path(),
-	)), nil
+	), ctx.Config().Lockfile())), nil
 }
 
 func matchesObjName(s string, objName string) (string, bool) {
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/util/stringutil"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// DiffDeploymentsOptions describe which deployments should get compared with the cluster
+type DiffDeploymentsOptions struct {
+	All    bool     `long:"all" description:"Diff all deployments"`
+	Except []string `long:"except" description:"If used with --all, will exclude the following deployments"`
+}
+
+// DiffDeployments prints the differences between the deployments and the cluster and
+// exits with status 1 if there are any
+func DiffDeployments(ctx devspacecontext.Context, pipeline types.Pipeline, args []string, stdout io.Writer) error {
+	ctx.Log().Debugf("diff_deployments %s", strings.Join(args, " "))
+	err := pipeline.Exclude(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.KubeClient() == nil {
+		return errors.Errorf(ErrMsg)
+	}
+	options := &DiffDeploymentsOptions{}
+	args, err = flags.ParseArgs(options, args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	}
+
+	if !options.All && len(args) == 0 {
+		return errors.Errorf("either specify 'diff_deployments --all' or 'diff_deployments deployment1 deployment2'")
+	} else if options.All {
+		args = []string{}
+		for deployment := range ctx.Config().Config().Deployments {
+			if stringutil.Contains(options.Except, deployment) {
+				continue
+			}
+
+			args = append(args, deployment)
+		}
+		if len(args) == 0 {
+			return nil
+		}
+	}
+
+	writer := pipeline.Options().DeployOptions.RenderWriter
+	if writer == nil {
+		writer = stdout
+	}
+
+	changed, err := deploy.NewController().Diff(ctx, args, &deploy.DiffOptions{Writer: writer})
+	if err != nil {
+		return err
+	} else if changed {
+		return interp.NewExitStatus(1)
+	}
+
+	return nil
+}
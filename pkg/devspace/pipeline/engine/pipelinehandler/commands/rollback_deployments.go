@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/util/stringutil"
+	"github.com/pkg/errors"
+)
+
+// RollbackDeploymentsOptions describe how deployments should get rolled back
+type RollbackDeploymentsOptions struct {
+	All    bool     `long:"all" description:"Roll back all deployments"`
+	Except []string `long:"except" description:"If used with --all, will exclude the following deployments"`
+}
+
+func RollbackDeployments(ctx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+	ctx.Log().Debugf("rollback_deployments %s", strings.Join(args, " "))
+	err := pipeline.Exclude(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.KubeClient() == nil {
+		return errors.Errorf(ErrMsg)
+	}
+	options := &RollbackDeploymentsOptions{}
+	args, err = flags.ParseArgs(options, args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	}
+
+	if !options.All && len(args) == 0 {
+		return fmt.Errorf("either specify 'rollback_deployments --all' or 'rollback_deployments deployment1 deployment2'")
+	} else if options.All {
+		args = []string{}
+		for _, d := range ctx.Config().RemoteCache().ListDeployments() {
+			if stringutil.Contains(options.Except, d.Name) {
+				continue
+			}
+
+			args = append(args, d.Name)
+		}
+		if len(args) == 0 {
+			return nil
+		}
+	} else {
+		for _, deployment := range args {
+			if _, ok := ctx.Config().RemoteCache().GetDeployment(deployment); !ok {
+				return fmt.Errorf("couldn't find deployed deployment %v", deployment)
+			}
+		}
+	}
+
+	return deploy.NewController().Rollback(ctx, args)
+}
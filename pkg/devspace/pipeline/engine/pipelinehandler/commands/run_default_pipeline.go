@@ -88,7 +88,7 @@ func applyPipelineSetValue(ctx devspacecontext.Context, set, setString []string)
 		return nil, err
 	}
 
-	return ctx.WithConfig(config.NewConfig(
+	return ctx.WithConfig(config.WithLockfile(config.NewConfig(
 		ctx.Config().Raw(),
 		rawConfig,
 		latestConfig,
@@ -96,5 +96,5 @@ func applyPipelineSetValue(ctx devspacecontext.Context, set, setString []string)
 		ctx.Config().RemoteCache(),
 		ctx.Config().Variables(),
 		ctx.Config().Path(),
-	)), nil
+	), ctx.Config().Lockfile())), nil
 }
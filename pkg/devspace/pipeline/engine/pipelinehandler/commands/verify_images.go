@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/loft-sh/devspace/pkg/devspace/build/sign"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/util/stringutil"
+	"github.com/pkg/errors"
+)
+
+// VerifyImagesOptions describe which images should get verified
+type VerifyImagesOptions struct {
+	All     bool     `long:"all" description:"Verify all images that have a sign configuration"`
+	Except  []string `long:"except" description:"If used with --all, will exclude the following images"`
+	Enabled bool     `long:"enabled" description:"If used with --all, will only verify images that have sign.verify enabled"`
+}
+
+// VerifyImages verifies the signatures of the last built images
+func VerifyImages(ctx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+	ctx.Log().Debugf("verify_images %s", strings.Join(args, " "))
+	options := &VerifyImagesOptions{}
+	args, err := flags.ParseArgs(options, args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	}
+
+	images := ctx.Config().Config().Images
+	if options.All {
+		args = []string{}
+		for image, imageConf := range images {
+			if imageConf.Sign == nil || (options.Enabled && !imageConf.Sign.Verify) || stringutil.Contains(options.Except, image) {
+				continue
+			}
+
+			args = append(args, image)
+		}
+		sort.Strings(args)
+	} else if len(args) > 0 {
+		for _, image := range args {
+			if images[image] == nil {
+				return fmt.Errorf("couldn't find image %v", image)
+			} else if images[image].Sign == nil {
+				return fmt.Errorf("image %v has no sign configuration", image)
+			}
+		}
+	} else {
+		return fmt.Errorf("either specify 'verify_images --all' or 'verify_images image1 image2'")
+	}
+
+	for _, image := range args {
+		imageConf := images[image]
+		imageCache, _ := ctx.Config().LocalCache().GetImageCache(image)
+		imageName := imageCache.ResolveImage()
+		if imageName == "" {
+			imageName = imageConf.Image
+		}
+
+		// the pushed digest is verified, as the tag could point to another image by now
+		if imageCache.ImageDigest == "" {
+			return fmt.Errorf("the pushed digest of image %v is unknown, please run 'build_images %v' first", image, image)
+		}
+
+		ref, err := sign.VerifyImage(ctx.Context(), ctx.WorkingDir(), imageConf.Sign, imageName+"@"+imageCache.ImageDigest)
+		if err != nil {
+			return errors.Wrapf(err, "verify image %s", image)
+		}
+
+		ctx.Log().Donef("Verified signature of image %s", ref.String())
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/pipelinehandler/commands"
 	enginetypes "github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/types"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"mvdan.cc/sh/v3/interp"
 )
@@ -45,13 +46,23 @@ var PipelineCommands = map[string]func(devCtx devspacecontext.Context, pipeline
 	"build_images": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
 		return commands.BuildImages(devCtx, pipeline, args)
 	},
+	"verify_images": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		return commands.VerifyImages(devCtx, pipeline, args)
+	},
 	"create_deployments": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
 		hc := interp.HandlerCtx(devCtx.Context())
 		return commands.CreateDeployments(devCtx, pipeline, args, hc.Stdout)
 	},
+	"diff_deployments": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		hc := interp.HandlerCtx(devCtx.Context())
+		return commands.DiffDeployments(devCtx, pipeline, args, hc.Stdout)
+	},
 	"purge_deployments": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
 		return commands.PurgeDeployments(devCtx, pipeline, args)
 	},
+	"rollback_deployments": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		return commands.RollbackDeployments(devCtx, pipeline, args)
+	},
 	"start_dev": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
 		return commands.StartDev(devCtx, pipeline, args)
 	},
@@ -157,7 +168,7 @@ func (e *execHandler) handlePipelineCommands(ctx context.Context, command string
 	pipelineCommand, ok := PipelineCommands[command]
 	if ok {
 		return e.executePipelineCommand(ctx, command, func() error {
-			return pipelineCommand(devCtx, e.pipeline, args)
+			return tracePipelineCommand(devCtx, e.pipeline, command, args, pipelineCommand)
 		})
 	}
 
@@ -165,13 +176,24 @@ func (e *execHandler) handlePipelineCommands(ctx context.Context, command string
 	pipelineCommand, ok = PipelineCommands[strings.TrimPrefix(command, "__")]
 	if ok {
 		return e.executePipelineCommand(ctx, command, func() error {
-			return pipelineCommand(devCtx, e.pipeline, args)
+			return tracePipelineCommand(devCtx, e.pipeline, command, args, pipelineCommand)
 		})
 	}
 
 	return false, nil
 }
 
+// tracePipelineCommand runs the pipeline command within its own tracing span
+func tracePipelineCommand(devCtx devspacecontext.Context, pipeline types.Pipeline, command string, args []string, pipelineCommand func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error) error {
+	spanCtx, span := tracing.Start(devCtx.Context(), tracing.CategoryPipeline, strings.TrimPrefix(command, "__"))
+	defer span.End()
+	span.SetAttribute("args", strings.Join(args, " "))
+
+	err := pipelineCommand(devCtx.WithContext(spanCtx), pipeline, args)
+	span.SetError(err)
+	return err
+}
+
 func (e *execHandler) executePipelineCommand(ctx context.Context, command string, commandFn func() error) (bool, error) {
 	if e.pipeline == nil {
 		hc := interp.HandlerCtx(ctx)
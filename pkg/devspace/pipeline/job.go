@@ -3,15 +3,22 @@ package pipeline
 import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/events"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/pipelinehandler"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
+	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/scanner"
 	"github.com/loft-sh/devspace/pkg/util/tomb"
 	"io"
 	"mvdan.cc/sh/v3/expand"
 	"os"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 type Job struct {
@@ -93,9 +100,11 @@ func (j *Job) Run(ctx devspacecontext.Context, args []string, environ expand.Env
 	return t.Wait()
 }
 
-func (j *Job) execute(ctx devspacecontext.Context, args []string, parent *tomb.Tomb, environ expand.Environ) error {
+func (j *Job) execute(ctx devspacecontext.Context, args []string, parent *tomb.Tomb, environ expand.Environ) (err error) {
 	ctx = ctx.WithLogger(ctx.Log())
-	stdoutReader, stdoutWriter := io.Pipe()
+	// secret values are redacted before the output reaches the logger
+	stdoutReader, stdoutPipe := io.Pipe()
+	stdoutWriter := log.NewMaskingWriter(stdoutPipe)
 	defer stdoutWriter.Close()
 
 	parent.Go(func() error {
@@ -106,7 +115,8 @@ func (j *Job) execute(ctx devspacecontext.Context, args []string, parent *tomb.T
 		return nil
 	})
 
-	stderrReader, stderrWriter := io.Pipe()
+	stderrReader, stderrPipe := io.Pipe()
+	stderrWriter := log.NewMaskingWriter(stderrPipe)
 	defer stderrWriter.Close()
 
 	parent.Go(func() error {
@@ -117,7 +127,60 @@ func (j *Job) execute(ctx devspacecontext.Context, args []string, parent *tomb.T
 		return nil
 	})
 
+	spanName := "pipeline " + j.Config.Name
+	if dependency := j.eventPayload(args).Dependency; dependency != "" {
+		spanName = "pipeline " + dependency + "/" + j.Config.Name
+	}
+	spanCtx, span := tracing.Start(ctx.Context(), tracing.CategoryPipeline, spanName)
+	ctx = ctx.WithContext(spanCtx)
+	started := time.Now()
+	events.Emit(events.JobStart, j.eventPayload(args))
+	defer func() {
+		span.SetError(err)
+		span.End()
+		payload := j.eventPayload(args)
+		payload.Duration = time.Since(started).Milliseconds()
+		payload.Error = events.ErrorString(err)
+		events.Emit(events.JobEnd, payload)
+	}()
+
+	var revisions map[string]string
+	if j.Config.RollbackOnFailure && ctx.Config() != nil {
+		revisions = deploy.Revisions(ctx)
+	}
+
 	handler := pipelinehandler.NewPipelineExecHandler(ctx, stdoutWriter, stderrWriter, j.Pipeline)
-	_, err := engine.ExecutePipelineShellCommand(ctx.Context(), j.Config.Run, args, ctx.WorkingDir(), j.Config.ContinueOnError, stdoutWriter, stderrWriter, os.Stdin, environ, handler)
+	_, err = engine.ExecutePipelineShellCommand(ctx.Context(), j.Config.Run, args, ctx.WorkingDir(), j.Config.ContinueOnError, stdoutWriter, stderrWriter, os.Stdin, environ, handler)
+	if err != nil && revisions != nil {
+		rollbackErr := j.rollback(ctx, revisions)
+		if rollbackErr != nil {
+			ctx.Log().Errorf("Error rolling back deployments: %v", rollbackErr)
+		}
+	}
 	return err
 }
+
+func (j *Job) eventPayload(args []string) *events.Pipeline {
+	if p, ok := j.Pipeline.(*pipeline); ok {
+		return p.eventPayload(j.Config, args)
+	}
+
+	return &events.Pipeline{Name: j.Config.Name, Args: args}
+}
+
+// rollback rolls back all deployments whose revision has changed since the job was started
+func (j *Job) rollback(ctx devspacecontext.Context, revisions map[string]string) error {
+	changed := []string{}
+	for name, revision := range deploy.Revisions(ctx) {
+		if previous, ok := revisions[name]; ok && previous != revision {
+			changed = append(changed, name)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	sort.Strings(changed)
+	ctx.Log().Warnf("Pipeline %s failed, rolling back deployments %s", j.Config.Name, strings.Join(changed, ", "))
+	return deploy.NewController().Rollback(ctx, changed)
+}
@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+// recordingLogger records the info and warn messages without masking them
+type recordingLogger struct {
+	*log.DiscardLogger
+
+	m        sync.Mutex
+	messages []string
+}
+
+func (r *recordingLogger) Info(args ...interface{}) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.messages = append(r.messages, fmt.Sprint(args...))
+}
+
+func (r *recordingLogger) Warn(args ...interface{}) {
+	r.Info(args...)
+}
+
+func TestJobMasksSecretVariables(t *testing.T) {
+	// resolved secret variables are registered as masked values
+	log.AddMaskedValue("job-secret-value")
+
+	config := &latest.Pipeline{
+		Name: "test",
+		Run:  "echo token=job-secret-value\necho error job-secret-value >&2",
+	}
+	pipe := NewPipeline("test", nil, nil, config, types.Options{})
+	logger := &recordingLogger{DiscardLogger: log.Discard}
+	ctx := devspacecontext.NewContext(context.Background(), nil, logger).WithWorkingDir(t.TempDir())
+
+	err := pipe.Run(ctx, nil)
+	assert.NilError(t, err)
+
+	// the output of the pipeline is captured line by line, so the secrets are masked before
+	// the lines reach the logger
+	logger.m.Lock()
+	defer logger.m.Unlock()
+	output := strings.Join(logger.messages, "\n")
+	assert.Assert(t, strings.Contains(output, "token="+log.MaskedValue), output)
+	assert.Assert(t, strings.Contains(output, "error "+log.MaskedValue), output)
+	assert.Assert(t, !strings.Contains(output, "job-secret-value"), output)
+}
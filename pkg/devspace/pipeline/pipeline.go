@@ -20,6 +20,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/registry"
 	types2 "github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"github.com/loft-sh/devspace/pkg/devspace/devpod"
+	"github.com/loft-sh/devspace/pkg/devspace/events"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/randutil"
@@ -186,7 +187,25 @@ func (p *pipeline) Dependencies() map[string]types.Pipeline {
 }
 
 func (p *pipeline) Run(ctx devspacecontext.Context, args []string) error {
-	return p.executeJob(ctx, p.main, args, ctx.Environ())
+	started := time.Now()
+	events.Emit(events.PipelineStart, p.eventPayload(p.main.Config, args))
+	err := p.executeJob(ctx, p.main, args, ctx.Environ())
+	payload := p.eventPayload(p.main.Config, args)
+	payload.Duration = time.Since(started).Milliseconds()
+	payload.Error = events.ErrorString(err)
+	events.Emit(events.PipelineEnd, payload)
+	return err
+}
+
+func (p *pipeline) eventPayload(configPipeline *latest.Pipeline, args []string) *events.Pipeline {
+	payload := &events.Pipeline{
+		Name: configPipeline.Name,
+		Args: args,
+	}
+	if p.parent != nil {
+		payload.Dependency = p.name
+	}
+	return payload
 }
 
 func (p *pipeline) StartNewDependencies(ctx devspacecontext.Context, dependencies []types2.Dependency, options types.DependencyOptions) error {
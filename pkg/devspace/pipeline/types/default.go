@@ -15,6 +15,10 @@ func GetDefaultPipeline(pipeline string) (*latest.Pipeline, error) {
 		return DefaultPurgePipeline, nil
 	case "build":
 		return DefaultBuildPipeline, nil
+	case "rollback":
+		return DefaultRollbackPipeline, nil
+	case "diff":
+		return DefaultDiffPipeline, nil
 	}
 
 	return nil, fmt.Errorf("couldn't find pipeline %v", pipeline)
@@ -25,6 +29,7 @@ var DefaultDeployPipeline = &latest.Pipeline{
 	Run: `run_dependencies --all
 ensure_pull_secrets --all
 build_images --all
+verify_images --all --enabled
 create_deployments --all`,
 }
 
@@ -33,6 +38,7 @@ var DefaultDevPipeline = &latest.Pipeline{
 	Run: `run_dependencies --all
 ensure_pull_secrets --all
 build_images --all
+verify_images --all --enabled
 create_deployments --all
 start_dev --all`,
 }
@@ -44,8 +50,27 @@ purge_deployments --all
 run_dependencies --all --pipeline purge`,
 }
 
+var DefaultRollbackPipeline = &latest.Pipeline{
+	Name: "rollback",
+	Run: `if [ $# -gt 0 ]; then
+  rollback_deployments $@
+else
+  rollback_deployments --all
+fi`,
+}
+
 var DefaultBuildPipeline = &latest.Pipeline{
 	Name: "build",
 	Run: `run_dependencies --all --pipeline build
 build_images --all`,
 }
+
+var DefaultDiffPipeline = &latest.Pipeline{
+	Name: "diff",
+	Run: `build_images --all
+if [ $# -gt 0 ]; then
+  diff_deployments $@
+else
+  diff_deployments --all
+fi`,
+}
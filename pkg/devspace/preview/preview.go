@@ -0,0 +1,279 @@
+package preview
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/util/encoding"
+	"github.com/loft-sh/devspace/pkg/util/git"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// Label marks a namespace as preview environment
+	Label = "devspace.sh/preview"
+
+	// OwnerAnnotation holds the owner of a preview environment
+	OwnerAnnotation = "devspace.sh/preview-owner"
+
+	// TTLAnnotation holds the duration after which a preview environment expires
+	TTLAnnotation = "devspace.sh/preview-ttl"
+
+	// CreatedAnnotation holds the time a preview environment was created or last refreshed
+	CreatedAnnotation = "devspace.sh/preview-created"
+)
+
+const (
+	// DefaultNameTemplate is the name template used for branches
+	DefaultNameTemplate = "preview-${DEVSPACE_GIT_BRANCH}"
+
+	// PullRequestNameTemplate is the name template used if a pull request is built
+	PullRequestNameTemplate = "preview-pr-${DEVSPACE_GIT_PULL_REQUEST}"
+)
+
+// Preview is a preview environment, which is a namespace with a limited lifetime
+type Preview struct {
+	Name    string
+	Owner   string
+	TTL     time.Duration
+	Created time.Time
+}
+
+// ExpiresAt returns the time the preview expires or the zero time if it never expires
+func (p *Preview) ExpiresAt() time.Time {
+	if p.TTL <= 0 || p.Created.IsZero() {
+		return time.Time{}
+	}
+
+	return p.Created.Add(p.TTL)
+}
+
+// Expired returns true if the preview has expired at the given time
+func (p *Preview) Expired(now time.Time) bool {
+	expiresAt := p.ExpiresAt()
+	return !expiresAt.IsZero() && now.After(expiresAt)
+}
+
+// CreateOptions are the options for creating a preview environment
+type CreateOptions struct {
+	Name  string
+	Owner string
+	TTL   time.Duration
+}
+
+// Name expands the variables in the template and converts the result into a valid namespace name
+func Name(template string, vars map[string]string) (string, error) {
+	missing := []string{}
+	expanded := os.Expand(template, func(name string) string {
+		value, ok := vars[name]
+		if !ok || value == "" {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("cannot resolve variable(s) %s in preview name template %s", strings.Join(missing, ", "), template)
+	}
+
+	name := strings.Trim(encoding.Convert(expanded), "-")
+	if name == "" {
+		return "", fmt.Errorf("preview name template %s results in an empty name", template)
+	}
+
+	return name, nil
+}
+
+// Create creates the namespace of a preview environment. If the preview already exists,
+// its owner and ttl are updated and its lifetime starts again.
+func Create(ctx context.Context, client kubernetes.Interface, options CreateOptions) (*Preview, error) {
+	if options.Name == "" {
+		return nil, fmt.Errorf("preview name is empty")
+	}
+
+	now := time.Now()
+	namespace, err := client.CoreV1().Namespaces().Get(ctx, options.Name, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "get namespace")
+		}
+
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: options.Name,
+				Labels: map[string]string{
+					Label: "true",
+				},
+			},
+		}
+		setAnnotations(namespace, options, now)
+		namespace, err = client.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+		if err != nil {
+			return nil, errors.Wrap(err, "create namespace")
+		}
+
+		return fromNamespace(namespace), nil
+	} else if !isPreview(namespace) {
+		return nil, fmt.Errorf("namespace %s already exists and is not a preview environment", options.Name)
+	}
+
+	setAnnotations(namespace, options, now)
+	namespace, err = client.CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "update namespace")
+	}
+
+	return fromNamespace(namespace), nil
+}
+
+// List returns all preview environments sorted by name
+func List(ctx context.Context, client kubernetes.Interface) ([]*Preview, error) {
+	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+		LabelSelector: Label + "=true",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list namespaces")
+	}
+
+	previews := []*Preview{}
+	for i := range namespaces.Items {
+		if !isPreview(&namespaces.Items[i]) {
+			continue
+		}
+
+		previews = append(previews, fromNamespace(&namespaces.Items[i]))
+	}
+
+	sort.Slice(previews, func(i, j int) bool {
+		return previews[i].Name < previews[j].Name
+	})
+	return previews, nil
+}
+
+// Delete deletes the remote cache secrets and the namespace of a preview environment
+func Delete(ctx context.Context, client kubernetes.Interface, name string) error {
+	namespace, err := client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get namespace")
+	} else if !isPreview(namespace) {
+		return fmt.Errorf("namespace %s is not a preview environment", name)
+	}
+
+	// delete the cache secrets explicitly, so that they are gone even if the
+	// namespace is stuck in terminating
+	secrets, err := client.CoreV1().Secrets(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "list secrets")
+	}
+	for _, secret := range secrets.Items {
+		if secret.Type != remotecache.SecretType {
+			continue
+		}
+
+		err = client.CoreV1().Secrets(name).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "delete cache secret %s", secret.Name)
+		}
+	}
+
+	err = client.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, "delete namespace")
+	}
+
+	return nil
+}
+
+// GC deletes all preview environments that have expired at the given time and
+// returns their names
+func GC(ctx context.Context, client kubernetes.Interface, now time.Time, dryRun bool) ([]string, error) {
+	previews, err := List(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := []string{}
+	errs := []error{}
+	for _, preview := range previews {
+		if !preview.Expired(now) {
+			continue
+		}
+
+		if !dryRun {
+			err = Delete(ctx, client, preview.Name)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "delete preview %s", preview.Name))
+				continue
+			}
+		}
+
+		deleted = append(deleted, preview.Name)
+	}
+
+	return deleted, utilerrors.NewAggregate(errs)
+}
+
+func isPreview(namespace *corev1.Namespace) bool {
+	return namespace.Labels != nil && namespace.Labels[Label] == "true"
+}
+
+func setAnnotations(namespace *corev1.Namespace, options CreateOptions, now time.Time) {
+	if namespace.Annotations == nil {
+		namespace.Annotations = map[string]string{}
+	}
+
+	namespace.Annotations[CreatedAnnotation] = now.UTC().Format(time.RFC3339)
+	if options.Owner != "" {
+		namespace.Annotations[OwnerAnnotation] = options.Owner
+	}
+	if options.TTL > 0 {
+		namespace.Annotations[TTLAnnotation] = options.TTL.String()
+	} else {
+		delete(namespace.Annotations, TTLAnnotation)
+	}
+}
+
+func fromNamespace(namespace *corev1.Namespace) *Preview {
+	preview := &Preview{
+		Name:    namespace.Name,
+		Created: namespace.CreationTimestamp.Time,
+	}
+	if namespace.Annotations == nil {
+		return preview
+	}
+
+	preview.Owner = namespace.Annotations[OwnerAnnotation]
+	if created, err := time.Parse(time.RFC3339, namespace.Annotations[CreatedAnnotation]); err == nil {
+		preview.Created = created
+	}
+	if ttl, err := time.ParseDuration(namespace.Annotations[TTLAnnotation]); err == nil {
+		preview.TTL = ttl
+	}
+
+	return preview
+}
+
+// Variables returns the variables that can be used in a name template
+func Variables(ctx context.Context, dir string) map[string]string {
+	vars := map[string]string{}
+	if branch, err := git.GetBranch(dir); err == nil {
+		vars["DEVSPACE_GIT_BRANCH"] = branch
+	}
+	if hash, err := git.GetHash(ctx, dir); err == nil && len(hash) >= 8 {
+		vars["DEVSPACE_GIT_COMMIT"] = hash[:8]
+	}
+	if pullRequest := git.GetPullRequest(); pullRequest != "" {
+		vars["DEVSPACE_GIT_PULL_REQUEST"] = pullRequest
+	}
+
+	return vars
+}
@@ -0,0 +1,105 @@
+package preview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestName(t *testing.T) {
+	name, err := Name(DefaultNameTemplate, map[string]string{"DEVSPACE_GIT_BRANCH": "Feature/Login_Page"})
+	assert.NilError(t, err)
+	assert.Equal(t, name, "preview-feature-loginpage")
+
+	name, err = Name(PullRequestNameTemplate, map[string]string{"DEVSPACE_GIT_PULL_REQUEST": "42"})
+	assert.NilError(t, err)
+	assert.Equal(t, name, "preview-pr-42")
+
+	_, err = Name(PullRequestNameTemplate, map[string]string{})
+	assert.ErrorContains(t, err, "DEVSPACE_GIT_PULL_REQUEST")
+}
+
+func TestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+	})
+
+	// create two previews, one of them without ttl
+	created, err := Create(ctx, client, CreateOptions{Name: "preview-pr-1", Owner: "alice", TTL: time.Hour})
+	assert.NilError(t, err)
+	assert.Equal(t, created.Owner, "alice")
+	assert.Equal(t, created.TTL, time.Hour)
+	_, err = Create(ctx, client, CreateOptions{Name: "preview-main"})
+	assert.NilError(t, err)
+
+	// creating again refreshes the preview
+	created, err = Create(ctx, client, CreateOptions{Name: "preview-pr-1", Owner: "bob", TTL: 2 * time.Hour})
+	assert.NilError(t, err)
+	assert.Equal(t, created.Owner, "bob")
+	assert.Equal(t, created.TTL, 2*time.Hour)
+
+	// existing namespaces are never turned into previews
+	_, err = Create(ctx, client, CreateOptions{Name: "default"})
+	assert.ErrorContains(t, err, "not a preview environment")
+	err = Delete(ctx, client, "default")
+	assert.ErrorContains(t, err, "not a preview environment")
+
+	previews, err := List(ctx, client)
+	assert.NilError(t, err)
+	assert.Equal(t, len(previews), 2)
+	assert.Equal(t, previews[0].Name, "preview-main")
+	assert.Equal(t, previews[1].Name, "preview-pr-1")
+
+	// add a cache secret and another secret to the expiring preview
+	_, err = client.CoreV1().Secrets("preview-pr-1").Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "devspace-cache-test", Namespace: "preview-pr-1"},
+		Type:       remotecache.SecretType,
+	}, metav1.CreateOptions{})
+	assert.NilError(t, err)
+	_, err = client.CoreV1().Secrets("preview-pr-1").Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "preview-pr-1"},
+	}, metav1.CreateOptions{})
+	assert.NilError(t, err)
+
+	// nothing has expired yet
+	deleted, err := GC(ctx, client, time.Now(), false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(deleted), 0)
+
+	// dry run does not delete anything
+	deleted, err = GC(ctx, client, time.Now().Add(3*time.Hour), true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, deleted, []string{"preview-pr-1"})
+	_, err = client.CoreV1().Namespaces().Get(ctx, "preview-pr-1", metav1.GetOptions{})
+	assert.NilError(t, err)
+
+	deleted, err = GC(ctx, client, time.Now().Add(3*time.Hour), false)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, deleted, []string{"preview-pr-1"})
+
+	_, err = client.CoreV1().Namespaces().Get(ctx, "preview-pr-1", metav1.GetOptions{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+	_, err = client.CoreV1().Secrets("preview-pr-1").Get(ctx, "devspace-cache-test", metav1.GetOptions{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+
+	// previews without ttl never expire
+	previews, err = List(ctx, client)
+	assert.NilError(t, err)
+	assert.Equal(t, len(previews), 1)
+	assert.Equal(t, previews[0].Name, "preview-main")
+	assert.Assert(t, !previews[0].Expired(time.Now().Add(1000*time.Hour)))
+
+	err = Delete(ctx, client, "preview-main")
+	assert.NilError(t, err)
+	previews, err = List(ctx, client)
+	assert.NilError(t, err)
+	assert.Equal(t, len(previews), 0)
+}
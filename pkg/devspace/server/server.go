@@ -160,6 +160,8 @@ func newHandler(ctx devspacecontext.Context, path string, pipeline types.Pipelin
 	handler.mux.HandleFunc("/api/enter", handler.enter)
 	handler.mux.HandleFunc("/api/resize", handler.resize)
 	handler.mux.HandleFunc("/api/logs", handler.logs)
+	handler.mux.HandleFunc("/api/sync/conflicts", handler.syncConflicts)
+	handler.mux.HandleFunc("/api/sync/status", handler.syncStatus)
 	return handler, nil
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -97,6 +98,43 @@ func buildDeployment(ctx devspacecontext.Context, name string, target runtime.Ob
 				},
 			})
 		}
+	case *appsv1.DaemonSet:
+		deployment.Annotations[TargetNameAnnotation] = t.Name
+		deployment.Annotations[TargetKindAnnotation] = "DaemonSet"
+		deployment.Spec.Selector = t.Spec.Selector
+		podTemplate.Labels = t.Spec.Template.Labels
+		podTemplate.Annotations = t.Spec.Template.Annotations
+		podTemplate.Spec = *t.Spec.Template.Spec.DeepCopy()
+
+		// the daemon set might already be scaled down, so we use the original node selector
+		nodeSelector, err := originalNodeSelector(t)
+		if err != nil {
+			return nil, err
+		}
+		podTemplate.Spec.NodeSelector = nodeSelector
+	case *batchv1.Job:
+		deployment.Annotations[TargetNameAnnotation] = t.Name
+		deployment.Annotations[TargetKindAnnotation] = "Job"
+		podTemplate.Labels = withoutJobLabels(t.Spec.Template.Labels)
+		podTemplate.Annotations = t.Spec.Template.Annotations
+		podTemplate.Spec = *t.Spec.Template.Spec.DeepCopy()
+		toDeploymentPodSpec(&podTemplate.Spec)
+	case *batchv1.CronJob:
+		deployment.Annotations[TargetNameAnnotation] = t.Name
+		deployment.Annotations[TargetKindAnnotation] = "CronJob"
+		podTemplate.Labels = withoutJobLabels(t.Spec.JobTemplate.Spec.Template.Labels)
+		podTemplate.Annotations = t.Spec.JobTemplate.Spec.Template.Annotations
+		podTemplate.Spec = *t.Spec.JobTemplate.Spec.Template.Spec.DeepCopy()
+		toDeploymentPodSpec(&podTemplate.Spec)
+	case *corev1.Pod:
+		deployment.Annotations[TargetNameAnnotation] = t.Name
+		deployment.Annotations[TargetKindAnnotation] = "Pod"
+		podTemplate.Labels = copyMap(t.Labels)
+		podTemplate.Annotations = copyMap(t.Annotations)
+		podTemplate.Spec = *t.Spec.DeepCopy()
+		podTemplate.Spec.NodeName = ""
+		podTemplate.Spec.EphemeralContainers = nil
+		toDeploymentPodSpec(&podTemplate.Spec)
 	default:
 		return nil, fmt.Errorf("unrecognized object")
 	}
@@ -172,6 +210,36 @@ func buildDeployment(ctx devspacecontext.Context, name string, target runtime.Ob
 	return deployment, nil
 }
 
+// jobLabels are the labels the job controller adds to the pod template of a job
+var jobLabels = []string{"controller-uid", "job-name", "batch.kubernetes.io/controller-uid", "batch.kubernetes.io/job-name"}
+
+// withoutJobLabels removes the labels that would make the job controller adopt the replaced pod
+func withoutJobLabels(podLabels map[string]string) map[string]string {
+	newLabels := copyMap(podLabels)
+	for _, label := range jobLabels {
+		delete(newLabels, label)
+	}
+	return newLabels
+}
+
+// toDeploymentPodSpec removes the settings from a job or pod spec that are not allowed within a deployment
+func toDeploymentPodSpec(spec *corev1.PodSpec) {
+	spec.RestartPolicy = corev1.RestartPolicyAlways
+	spec.ActiveDeadlineSeconds = nil
+}
+
+func copyMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
 func modifyDevContainer(ctx devspacecontext.Context, devPod *latest.DevPod, devContainer *latest.DevContainer, podTemplate *corev1.PodTemplateSpec) error {
 	err := replaceImage(ctx, devPod, devContainer, podTemplate)
 	if err != nil {
@@ -1,14 +1,18 @@
 package podreplace
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
 	runtimevar "github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/imageselector"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -17,8 +21,9 @@ import (
 
 func findTargetByKindName(ctx devspacecontext.Context, kind, namespace, name string) (runtime.Object, error) {
 	var (
-		err    error
-		parent runtime.Object
+		err        error
+		parent     runtime.Object
+		apiVersion = "apps/v1"
 	)
 	switch kind {
 	case "ReplicaSet":
@@ -27,6 +32,17 @@ func findTargetByKindName(ctx devspacecontext.Context, kind, namespace, name str
 		parent, err = ctx.KubeClient().KubeClient().AppsV1().Deployments(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
 	case "StatefulSet":
 		parent, err = ctx.KubeClient().KubeClient().AppsV1().StatefulSets(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
+	case "DaemonSet":
+		parent, err = ctx.KubeClient().KubeClient().AppsV1().DaemonSets(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
+	case "Job":
+		parent, err = ctx.KubeClient().KubeClient().BatchV1().Jobs(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
+		apiVersion = "batch/v1"
+	case "CronJob":
+		parent, err = ctx.KubeClient().KubeClient().BatchV1().CronJobs(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
+		apiVersion = "batch/v1"
+	case "Pod":
+		parent, err = ctx.KubeClient().KubeClient().CoreV1().Pods(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
+		apiVersion = "v1"
 	default:
 		return nil, fmt.Errorf("unrecognized parent kind")
 	}
@@ -35,11 +51,65 @@ func findTargetByKindName(ctx devspacecontext.Context, kind, namespace, name str
 	}
 
 	typeAccessor, _ := meta.TypeAccessor(parent)
-	typeAccessor.SetAPIVersion("apps/v1")
+	typeAccessor.SetAPIVersion(apiVersion)
 	typeAccessor.SetKind(kind)
 	return parent, nil
 }
 
+// findReplacedTarget finds the target of an already replaced pod. Bare pods are deleted
+// during the replacement, so they are restored from the manifest in the dev pod cache.
+func findReplacedTarget(ctx devspacecontext.Context, devPodCache *remotecache.DevPodCache, kind, namespace, name string) (runtime.Object, error) {
+	target, err := findTargetByKindName(ctx, kind, namespace, name)
+	if err != nil && kerrors.IsNotFound(err) && kind == "Pod" && devPodCache != nil {
+		cached, cacheErr := cachedTargetPod(devPodCache)
+		if cacheErr != nil {
+			return nil, cacheErr
+		} else if cached != nil {
+			return cached, nil
+		}
+	}
+
+	return target, err
+}
+
+// cachedTargetPod returns the bare pod that was saved in the dev pod cache before it was replaced
+func cachedTargetPod(devPodCache *remotecache.DevPodCache) (*corev1.Pod, error) {
+	if devPodCache.TargetKind != "Pod" || devPodCache.TargetManifest == "" {
+		return nil, nil
+	}
+
+	pod := &corev1.Pod{}
+	err := json.Unmarshal([]byte(devPodCache.TargetManifest), pod)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse replaced pod manifest")
+	}
+
+	pod.APIVersion = "v1"
+	pod.Kind = "Pod"
+	return pod, nil
+}
+
+// podManifest returns the manifest of a bare pod that is used to recreate the pod when
+// the replacement is reverted
+func podManifest(pod *corev1.Pod) (string, error) {
+	spec := pod.Spec.DeepCopy()
+	spec.NodeName = ""
+	out, err := json.Marshal(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pod.Name,
+			Namespace:   pod.Namespace,
+			Labels:      pod.Labels,
+			Annotations: pod.Annotations,
+		},
+		Spec: *spec,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
 func findTargetBySelector(ctx devspacecontext.Context, devPod *latest.DevPod, filter func(obj metav1.Object) bool) (runtime.Object, error) {
 	namespace := ctx.KubeClient().Namespace()
 	if devPod.Namespace != "" {
@@ -103,6 +173,83 @@ func findTargetBySelector(ctx devspacecontext.Context, devPod *latest.DevPod, fi
 		}
 	}
 
+	// daemonSets
+	daemonSets, err := ctx.KubeClient().KubeClient().AppsV1().DaemonSets(namespace).List(ctx.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list DaemonSets")
+	}
+	for _, d := range daemonSets.Items {
+		if filter != nil && !filter(&d) {
+			continue
+		}
+
+		matched, err := matchesSelector(ctx, &d.Spec.Template, devPod)
+		if err != nil {
+			return nil, err
+		} else if matched {
+			d.Kind = "DaemonSet"
+			return &d, nil
+		}
+	}
+
+	// cronJobs
+	cronJobs, err := ctx.KubeClient().KubeClient().BatchV1().CronJobs(namespace).List(ctx.Context(), metav1.ListOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, "list CronJobs")
+	} else if err == nil {
+		for _, d := range cronJobs.Items {
+			if filter != nil && !filter(&d) {
+				continue
+			}
+
+			matched, err := matchesSelector(ctx, &d.Spec.JobTemplate.Spec.Template, devPod)
+			if err != nil {
+				return nil, err
+			} else if matched {
+				d.Kind = "CronJob"
+				return &d, nil
+			}
+		}
+	}
+
+	// jobs
+	jobs, err := ctx.KubeClient().KubeClient().BatchV1().Jobs(namespace).List(ctx.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list Jobs")
+	}
+	for _, d := range jobs.Items {
+		if len(d.OwnerReferences) > 0 || (filter != nil && !filter(&d)) {
+			continue
+		}
+
+		matched, err := matchesSelector(ctx, &d.Spec.Template, devPod)
+		if err != nil {
+			return nil, err
+		} else if matched {
+			d.Kind = "Job"
+			return &d, nil
+		}
+	}
+
+	// bare pods
+	pods, err := ctx.KubeClient().KubeClient().CoreV1().Pods(namespace).List(ctx.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list Pods")
+	}
+	for _, d := range pods.Items {
+		if len(d.OwnerReferences) > 0 || d.DeletionTimestamp != nil || d.Labels[selector.ReplacedLabel] != "" || (filter != nil && !filter(&d)) {
+			continue
+		}
+
+		matched, err := matchesSelector(ctx, &corev1.PodTemplateSpec{ObjectMeta: d.ObjectMeta, Spec: d.Spec}, devPod)
+		if err != nil {
+			return nil, err
+		} else if matched {
+			d.Kind = "Pod"
+			return &d, nil
+		}
+	}
+
 	return nil, nil
 }
 
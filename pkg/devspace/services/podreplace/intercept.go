@@ -0,0 +1,288 @@
+package podreplace
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/loft-sh/devspace/helper/intercept"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
+	patch2 "github.com/loft-sh/devspace/pkg/util/patch"
+	"github.com/loft-sh/devspace/pkg/util/stringutil"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// InterceptHashAnnotation holds the hash of the intercept configuration that was applied
+	InterceptHashAnnotation = "devspace.sh/intercept-hash"
+
+	// InterceptContainer is the name of the sidecar container that runs the intercept proxy
+	InterceptContainer = "devspace-intercept"
+
+	// DefaultInterceptImage is the default image used for the intercept container, which
+	// needs sh and iptables. It is built from hack/intercept/Dockerfile.
+	DefaultInterceptImage = "devspacesh/intercept:v1"
+)
+
+// InterceptPod injects the intercept proxy into the pod template of the target, which
+// keeps running while matching requests are routed to the developer
+func (p *replacer) InterceptPod(ctx devspacecontext.Context, devPod *latest.DevPod) error {
+	if devPod.Intercept == nil {
+		return fmt.Errorf("dev.%s.intercept is not defined", devPod.Name)
+	}
+
+	namespace := devPod.Namespace
+	if namespace == "" {
+		namespace = ctx.KubeClient().Namespace()
+	}
+
+	devPodCache, ok := ctx.Config().RemoteCache().GetDevPod(devPod.Name)
+	if ok && devPodCache.Deployment != "" {
+		// the pod was replaced before, so we revert that first
+		ctx.Log().Debugf("Revert replaced pod of dev %s before intercepting", devPod.Name)
+		_, err := p.RevertReplacePod(ctx, &devPodCache, &deploy.PurgeOptions{ForcePurge: true})
+		if err != nil {
+			return errors.Wrap(err, "revert replaced pod")
+		}
+
+		devPodCache, ok = ctx.Config().RemoteCache().GetDevPod(devPod.Name)
+	}
+	if !ok {
+		devPodCache.Name = devPod.Name
+		devPodCache.Namespace = namespace
+	}
+
+	// check if root name exists
+	rootName, ok := values.RootNameFrom(ctx.Context())
+	if ok && !stringutil.Contains(devPodCache.Projects, rootName) {
+		devPodCache.Projects = append(devPodCache.Projects, rootName)
+	}
+
+	// find the target
+	var (
+		target runtime.Object
+		err    error
+	)
+	if devPodCache.InterceptTemplate != "" && devPodCache.TargetKind != "" {
+		target, err = findTargetByKindName(ctx, devPodCache.TargetKind, devPodCache.Namespace, devPodCache.TargetName)
+		if err != nil {
+			return errors.Wrapf(err, "find intercepted %s %s", devPodCache.TargetKind, devPodCache.TargetName)
+		}
+	} else {
+		target, err = findTargetBySelector(ctx, devPod, nil)
+		if err != nil {
+			return err
+		} else if target == nil {
+			return fmt.Errorf("couldn't find a matching deployment, statefulset, daemonset, cronjob or replica set")
+		}
+	}
+
+	podTemplate, err := podTemplateOf(target)
+	if err != nil {
+		return err
+	}
+
+	configHash, err := hashConfig(&latest.DevPod{Name: devPod.Name, Intercept: devPod.Intercept})
+	if err != nil {
+		return errors.Wrap(err, "hash config")
+	}
+
+	metaTarget := target.(metav1.Object)
+	if devPodCache.InterceptTemplate != "" && metaTarget.GetAnnotations()[InterceptHashAnnotation] == configHash {
+		ctx.Log().Debugf("%s %s is already intercepted", target.GetObjectKind().GroupVersionKind().Kind, metaTarget.GetName())
+		ctx.Config().RemoteCache().SetDevPod(devPodCache.Name, devPodCache)
+		return ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+	}
+
+	// save the original pod template only once, so that an updated configuration
+	// does not overwrite it with an already intercepted one
+	if devPodCache.InterceptTemplate == "" {
+		out, err := json.Marshal(podTemplate)
+		if err != nil {
+			return errors.Wrap(err, "save original pod template")
+		}
+
+		devPodCache.TargetKind = target.GetObjectKind().GroupVersionKind().Kind
+		devPodCache.TargetName = metaTarget.GetName()
+		devPodCache.InterceptTemplate = string(out)
+	}
+	ctx.Config().RemoteCache().SetDevPod(devPodCache.Name, devPodCache)
+	err = ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+	if err != nil {
+		return err
+	}
+
+	// build the intercepted pod template from the original one
+	original := &corev1.PodTemplateSpec{}
+	err = json.Unmarshal([]byte(devPodCache.InterceptTemplate), original)
+	if err != nil {
+		return errors.Wrap(err, "parse original pod template")
+	}
+
+	intercepted, err := applyPodPatches(original, &latest.DevPod{Patches: interceptPatches(devPod.Intercept)})
+	if err != nil {
+		return errors.Wrap(err, "inject intercept proxy")
+	}
+
+	ctx.Log().Debugf("Intercepting %s %s...", devPodCache.TargetKind, devPodCache.TargetName)
+	err = patchTarget(ctx, target, intercepted, configHash)
+	if err != nil {
+		return err
+	}
+
+	ctx.Log().Debugf("Successfully intercepted %s %s", devPodCache.TargetKind, devPodCache.TargetName)
+	return nil
+}
+
+// revertIntercept restores the original pod template of an intercepted target
+func revertIntercept(ctx devspacecontext.Context, devPodCache *remotecache.DevPodCache, namespace string) (bool, error) {
+	target, err := findTargetByKindName(ctx, devPodCache.TargetKind, namespace, devPodCache.TargetName)
+	if err != nil {
+		ctx.Log().Debugf("Error getting intercepted target by name: %v", err)
+		return false, nil
+	}
+
+	original := &corev1.PodTemplateSpec{}
+	err = json.Unmarshal([]byte(devPodCache.InterceptTemplate), original)
+	if err != nil {
+		return false, errors.Wrap(err, "parse original pod template")
+	}
+
+	ctx.Log().Infof("Removing intercept proxy from %s %s...", devPodCache.TargetKind, devPodCache.TargetName)
+	err = patchTarget(ctx, target, original, "")
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// interceptPatches returns the patches that inject the intercept proxy into a pod template.
+// The sidecar waits until the DevSpace helper is injected into it, which only happens for the
+// single pod that is selected by DevSpace. Only then the proxy starts and redirects the traffic
+// of the intercepted port to itself, so all other replicas keep serving the traffic unchanged.
+// As the pod template changes, all replicas of the target are rolled out nevertheless.
+func interceptPatches(config *latest.InterceptConfig) []*latest.PatchConfig {
+	image := config.Image
+	if image == "" {
+		image = DefaultInterceptImage
+	}
+
+	target := config.Target
+	if target == "" {
+		target = fmt.Sprintf("localhost:%d", intercept.TunnelPort)
+	}
+
+	// the proxy arguments are passed as positional parameters to the script, so that
+	// they are never interpreted by the shell
+	script := fmt.Sprintf(`until [ -x %[1]s ]; do sleep 1; done; exec %[1]s intercept "$@"`, inject.DevSpaceHelperContainerPath)
+	command := []interface{}{
+		"sh", "-c", script, InterceptContainer,
+		"--listen", fmt.Sprintf(":%d", intercept.ProxyPort),
+		"--original", fmt.Sprintf("localhost:%d", config.Port),
+		"--target", target,
+		"--redirect-port", strconv.Itoa(config.Port),
+	}
+	headers := make([]string, 0, len(config.Headers))
+	for name := range config.Headers {
+		headers = append(headers, name)
+	}
+	sort.Strings(headers)
+	for _, name := range headers {
+		command = append(command, "--header", name+"="+config.Headers[name])
+	}
+
+	return []*latest.PatchConfig{
+		{
+			Operation: "add",
+			Path:      "spec.containers",
+			Value: map[string]interface{}{
+				"name":    InterceptContainer,
+				"image":   image,
+				"command": command,
+				"securityContext": map[string]interface{}{
+					"capabilities": map[string]interface{}{
+						"add": []interface{}{"NET_ADMIN"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// podTemplateOf returns the pod template of a target that can be intercepted
+func podTemplateOf(obj runtime.Object) (*corev1.PodTemplateSpec, error) {
+	switch t := obj.(type) {
+	case *appsv1.Deployment:
+		return &t.Spec.Template, nil
+	case *appsv1.StatefulSet:
+		return &t.Spec.Template, nil
+	case *appsv1.DaemonSet:
+		return &t.Spec.Template, nil
+	case *appsv1.ReplicaSet:
+		return &t.Spec.Template, nil
+	case *batchv1.CronJob:
+		return &t.Spec.JobTemplate.Spec.Template, nil
+	}
+
+	return nil, fmt.Errorf("%s cannot be intercepted, because its pod template cannot be changed", obj.GetObjectKind().GroupVersionKind().Kind)
+}
+
+// patchTarget replaces the pod template of the target and sets or removes the intercept hash
+func patchTarget(ctx devspacecontext.Context, target runtime.Object, podTemplate *corev1.PodTemplateSpec, configHash string) error {
+	cloned := target.DeepCopyObject()
+	template, err := podTemplateOf(target)
+	if err != nil {
+		return err
+	}
+	*template = *podTemplate
+
+	metaTarget := target.(metav1.Object)
+	annotations := metaTarget.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if configHash == "" {
+		delete(annotations, InterceptHashAnnotation)
+	} else {
+		annotations[InterceptHashAnnotation] = configHash
+	}
+	metaTarget.SetAnnotations(annotations)
+
+	patch := patch2.MergeFrom(cloned)
+	bytes, err := patch.Data(target)
+	if err != nil {
+		return errors.Wrap(err, "create target patch")
+	} else if string(bytes) == "{}" {
+		return nil
+	}
+
+	switch t := target.(type) {
+	case *appsv1.Deployment:
+		_, err = ctx.KubeClient().KubeClient().AppsV1().Deployments(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *appsv1.StatefulSet:
+		_, err = ctx.KubeClient().KubeClient().AppsV1().StatefulSets(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *appsv1.DaemonSet:
+		_, err = ctx.KubeClient().KubeClient().AppsV1().DaemonSets(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *appsv1.ReplicaSet:
+		_, err = ctx.KubeClient().KubeClient().AppsV1().ReplicaSets(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *batchv1.CronJob:
+		_, err = ctx.KubeClient().KubeClient().BatchV1().CronJobs(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	}
+	if err != nil {
+		return errors.Wrap(err, "patch target")
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package podreplace
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInterceptDeployment(t *testing.T) {
+	ctx := newTestContext(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(2),
+			Selector: &metav1.LabelSelector{MatchLabels: testLabels},
+			Template: testPodTemplate(),
+		},
+	})
+
+	replacer := NewPodReplacer()
+	devPod := &latest.DevPod{
+		Name:          "test",
+		LabelSelector: testLabels,
+		Intercept: &latest.InterceptConfig{
+			Port:    8080,
+			Headers: map[string]string{"x-dev-user": "alice"},
+		},
+	}
+
+	err := replacer.InterceptPod(ctx, devPod)
+	assert.NilError(t, err)
+
+	devPodCache, ok := ctx.Config().RemoteCache().GetDevPod("test")
+	assert.Assert(t, ok)
+	assert.Equal(t, devPodCache.TargetKind, "Deployment")
+	assert.Equal(t, devPodCache.TargetName, "test")
+	assert.Equal(t, devPodCache.Deployment, "")
+	assert.Assert(t, devPodCache.InterceptTemplate != "")
+
+	// the original pod keeps running with the proxy injected
+	deployment, err := ctx.KubeClient().KubeClient().AppsV1().Deployments(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *deployment.Spec.Replicas, int32(2))
+	assert.Assert(t, deployment.Annotations[InterceptHashAnnotation] != "")
+	assert.Equal(t, len(deployment.Spec.Template.Spec.Containers), 2)
+	assert.Equal(t, deployment.Spec.Template.Spec.Containers[0].Image, "test:latest")
+	assert.Equal(t, deployment.Spec.Template.Spec.Containers[1].Name, InterceptContainer)
+	assert.DeepEqual(t, deployment.Spec.Template.Spec.Containers[1].Command[3:], []string{
+		InterceptContainer,
+		"--listen", ":15080",
+		"--original", "localhost:8080",
+		"--target", "localhost:15081",
+		"--redirect-port", "8080",
+		"--header", "x-dev-user=alice",
+	})
+	assert.DeepEqual(t, deployment.Spec.Template.Spec.Containers[1].SecurityContext.Capabilities.Add, []corev1.Capability{"NET_ADMIN"})
+
+	// the traffic is only redirected by the proxy of the selected pod, so that all other
+	// replicas keep serving it
+	assert.Equal(t, len(deployment.Spec.Template.Spec.InitContainers), 0)
+
+	// intercepting again with a changed config should keep the original template
+	devPod.Intercept.Headers = map[string]string{"x-dev-user": "bob'; rm -rf / #", "x-team": "a b"}
+	err = replacer.InterceptPod(ctx, devPod)
+	assert.NilError(t, err)
+
+	updatedCache, _ := ctx.Config().RemoteCache().GetDevPod("test")
+	assert.Equal(t, updatedCache.InterceptTemplate, devPodCache.InterceptTemplate)
+	deployment, err = ctx.KubeClient().KubeClient().AppsV1().Deployments(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(deployment.Spec.Template.Spec.Containers), 2)
+	command := deployment.Spec.Template.Spec.Containers[1].Command
+	assert.DeepEqual(t, command[len(command)-4:], []string{"--header", "x-dev-user=bob'; rm -rf / #", "--header", "x-team=a b"})
+	assert.Assert(t, !strings.Contains(command[2], "bob"), command[2])
+
+	// revert restores the original pod template
+	reverted, err := replacer.RevertReplacePod(ctx, &updatedCache, &deploy.PurgeOptions{ForcePurge: true})
+	assert.NilError(t, err)
+	assert.Assert(t, reverted)
+
+	deployment, err = ctx.KubeClient().KubeClient().AppsV1().Deployments(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, deployment.Annotations[InterceptHashAnnotation], "")
+	assert.Equal(t, len(deployment.Spec.Template.Spec.Containers), 1)
+	assert.Equal(t, len(deployment.Spec.Template.Spec.InitContainers), 0)
+	_, ok = ctx.Config().RemoteCache().GetDevPod("test")
+	assert.Assert(t, !ok)
+}
@@ -1,6 +1,7 @@
 package podreplace
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/ptr"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,14 +33,23 @@ const (
 	TargetNameAnnotation       = "devspace.sh/parent-name"
 	DevPodConfigHashAnnotation = "devspace.sh/config-hash"
 
-	ReplicasAnnotation = "devspace.sh/replicas"
+	ReplicasAnnotation     = "devspace.sh/replicas"
+	NodeSelectorAnnotation = "devspace.sh/node-selector"
+	SuspendAnnotation      = "devspace.sh/suspend"
+
+	// ScaledDownNodeSelector is added to the node selector of a replaced daemon set,
+	// which no node matches so all daemon set pods are removed
+	ScaledDownNodeSelector = "devspace.sh/scaled-down"
 )
 
 type PodReplacer interface {
 	// ReplacePod will try to replace a pod with the given config
 	ReplacePod(ctx devspacecontext.Context, devPod *latest.DevPod) error
 
-	// RevertReplacePod will try to revert a pod replacement with the given config
+	// InterceptPod will inject an intercept proxy into the pod with the given config
+	InterceptPod(ctx devspacecontext.Context, devPod *latest.DevPod) error
+
+	// RevertReplacePod will try to revert a pod replacement or intercept with the given config
 	RevertReplacePod(ctx devspacecontext.Context, devPodCache *remotecache.DevPodCache, options *deploy.PurgeOptions) (bool, error)
 }
 
@@ -80,7 +91,7 @@ func (p *replacer) ReplacePod(ctx devspacecontext.Context, devPod *latest.DevPod
 
 			// fallthrough to recreate replicaSet
 		} else {
-			recreateNeeded, err := updateNeeded(ctx, deployment, devPod)
+			recreateNeeded, err := updateNeeded(ctx, deployment, &devPodCache, devPod)
 			if err != nil {
 				return err
 			} else if !recreateNeeded {
@@ -101,13 +112,28 @@ func (p *replacer) ReplacePod(ctx devspacecontext.Context, devPod *latest.DevPod
 	if err != nil {
 		return err
 	} else if target == nil {
-		return fmt.Errorf("couldn't find a matching deployment, statefulset or replica set")
+		// a bare pod is already deleted if it was replaced before
+		cachedPod, err := cachedTargetPod(&devPodCache)
+		if err != nil {
+			return err
+		} else if cachedPod == nil {
+			return fmt.Errorf("couldn't find a matching deployment, statefulset, daemonset, cronjob, job, replica set or pod")
+		}
+
+		target = cachedPod
 	}
 
 	// make sure we already save the cache here
 	devPodCache.TargetKind = target.GetObjectKind().GroupVersionKind().Kind
 	devPodCache.TargetName = target.(metav1.Object).GetName()
 	devPodCache.Deployment = target.(metav1.Object).GetName() + "-devspace"
+	devPodCache.TargetManifest = ""
+	if pod, ok := target.(*corev1.Pod); ok {
+		devPodCache.TargetManifest, err = podManifest(pod)
+		if err != nil {
+			return errors.Wrap(err, "save pod manifest")
+		}
+	}
 	ctx.Config().RemoteCache().SetDevPod(devPodCache.Name, devPodCache)
 	err = ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
 	if err != nil {
@@ -125,12 +151,12 @@ func (p *replacer) ReplacePod(ctx devspacecontext.Context, devPod *latest.DevPod
 	return nil
 }
 
-func updateNeeded(ctx devspacecontext.Context, deployment *appsv1.Deployment, devPod *latest.DevPod) (recreateNeeded bool, err error) {
+func updateNeeded(ctx devspacecontext.Context, deployment *appsv1.Deployment, devPodCache *remotecache.DevPodCache, devPod *latest.DevPod) (recreateNeeded bool, err error) {
 	if deployment.Annotations == nil || deployment.Annotations[TargetKindAnnotation] == "" || deployment.Annotations[TargetNameAnnotation] == "" {
 		return true, deleteDeployment(ctx, deployment)
 	}
 
-	target, err := findTargetByKindName(ctx, deployment.Annotations[TargetKindAnnotation], deployment.Namespace, deployment.Annotations[TargetNameAnnotation])
+	target, err := findReplacedTarget(ctx, devPodCache, deployment.Annotations[TargetKindAnnotation], deployment.Namespace, deployment.Annotations[TargetNameAnnotation])
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			return true, deleteDeployment(ctx, deployment)
@@ -445,6 +471,101 @@ func scaleDownTarget(ctx devspacecontext.Context, obj runtime.Object) error {
 			return err
 		}
 
+		return nil
+	case *appsv1.DaemonSet:
+		if t.Annotations == nil {
+			t.Annotations = map[string]string{}
+		}
+
+		if t.Annotations[NodeSelectorAnnotation] != "" {
+			return nil
+		}
+
+		nodeSelector := t.Spec.Template.Spec.NodeSelector
+		if nodeSelector == nil {
+			nodeSelector = map[string]string{}
+		}
+		out, err := json.Marshal(nodeSelector)
+		if err != nil {
+			return err
+		}
+
+		t.Annotations[NodeSelectorAnnotation] = string(out)
+		t.Spec.Template.Spec.NodeSelector = map[string]string{}
+		for k, v := range nodeSelector {
+			t.Spec.Template.Spec.NodeSelector[k] = v
+		}
+		t.Spec.Template.Spec.NodeSelector[ScaledDownNodeSelector] = "true"
+		patch := patch2.MergeFrom(cloned)
+		bytes, err := patch.Data(t)
+		if err != nil {
+			return err
+		}
+
+		_, err = ctx.KubeClient().KubeClient().AppsV1().DaemonSets(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	case *batchv1.Job:
+		if t.Annotations == nil {
+			t.Annotations = map[string]string{}
+		}
+
+		parallelism := 1
+		if t.Spec.Parallelism != nil {
+			parallelism = int(*t.Spec.Parallelism)
+		}
+
+		if parallelism == 0 {
+			return nil
+		}
+
+		t.Annotations[ReplicasAnnotation] = strconv.Itoa(parallelism)
+		t.Spec.Parallelism = ptr.Int32(0)
+		patch := patch2.MergeFrom(cloned)
+		bytes, err := patch.Data(t)
+		if err != nil {
+			return err
+		}
+
+		_, err = ctx.KubeClient().KubeClient().BatchV1().Jobs(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	case *batchv1.CronJob:
+		if t.Annotations == nil {
+			t.Annotations = map[string]string{}
+		}
+
+		if t.Spec.Suspend != nil && *t.Spec.Suspend {
+			return nil
+		}
+
+		t.Annotations[SuspendAnnotation] = "false"
+		t.Spec.Suspend = ptr.Bool(true)
+		patch := patch2.MergeFrom(cloned)
+		bytes, err := patch.Data(t)
+		if err != nil {
+			return err
+		}
+
+		_, err = ctx.KubeClient().KubeClient().BatchV1().CronJobs(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	case *corev1.Pod:
+		// bare pods cannot be scaled down, so we delete them and recreate them on revert
+		err := ctx.KubeClient().KubeClient().CoreV1().Pods(t.Namespace).Delete(ctx.Context(), t.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+
 		return nil
 	}
 
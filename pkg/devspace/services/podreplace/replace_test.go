@@ -0,0 +1,190 @@
+package podreplace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "testNamespace"
+
+var testLabels = map[string]string{"app": "test"}
+
+func newTestContext(objects ...runtime.Object) devspacecontext.Context {
+	conf := config.NewConfig(nil, nil, latest.NewRaw(), nil, remotecache.NewCache("", "test"), nil, "")
+	return devspacecontext.NewContext(context.Background(), nil, log.Discard).
+		WithConfig(conf).
+		WithKubeClient(&fakekube.Client{Client: fake.NewSimpleClientset(objects...)})
+}
+
+func testPodTemplate() corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: testLabels,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "test",
+					Image: "test:latest",
+				},
+			},
+		},
+	}
+}
+
+func replaceAndRevert(t *testing.T, ctx devspacecontext.Context, expectedKind string, afterReplace func()) {
+	replacer := NewPodReplacer()
+	devPod := &latest.DevPod{
+		Name:          "test",
+		LabelSelector: testLabels,
+	}
+
+	err := replacer.ReplacePod(ctx, devPod)
+	assert.NilError(t, err)
+
+	devPodCache, ok := ctx.Config().RemoteCache().GetDevPod("test")
+	assert.Assert(t, ok)
+	assert.Equal(t, devPodCache.TargetKind, expectedKind)
+	assert.Equal(t, devPodCache.TargetName, "test")
+	assert.Equal(t, devPodCache.Deployment, "test-devspace")
+
+	deployment, err := ctx.KubeClient().KubeClient().AppsV1().Deployments(testNamespace).Get(ctx.Context(), "test-devspace", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, deployment.Annotations[TargetKindAnnotation], expectedKind)
+	afterReplace()
+
+	// replacing again should not fail
+	err = replacer.ReplacePod(ctx, devPod)
+	assert.NilError(t, err)
+
+	deleted, err := replacer.RevertReplacePod(ctx, &devPodCache, &deploy.PurgeOptions{ForcePurge: true})
+	assert.NilError(t, err)
+	assert.Assert(t, deleted)
+
+	_, err = ctx.KubeClient().KubeClient().AppsV1().Deployments(testNamespace).Get(ctx.Context(), "test-devspace", metav1.GetOptions{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+	_, ok = ctx.Config().RemoteCache().GetDevPod("test")
+	assert.Assert(t, !ok)
+}
+
+func TestReplaceDaemonSet(t *testing.T) {
+	podTemplate := testPodTemplate()
+	podTemplate.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+	ctx := newTestContext(&appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: testLabels},
+			Template: podTemplate,
+		},
+	})
+
+	replaceAndRevert(t, ctx, "DaemonSet", func() {
+		daemonSet, err := ctx.KubeClient().KubeClient().AppsV1().DaemonSets(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Equal(t, daemonSet.Spec.Template.Spec.NodeSelector[ScaledDownNodeSelector], "true")
+		assert.Equal(t, daemonSet.Annotations[NodeSelectorAnnotation], `{"kubernetes.io/os":"linux"}`)
+
+		deployment, err := ctx.KubeClient().KubeClient().AppsV1().Deployments(testNamespace).Get(ctx.Context(), "test-devspace", metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, deployment.Spec.Template.Spec.NodeSelector, map[string]string{"kubernetes.io/os": "linux"})
+	})
+
+	daemonSet, err := ctx.KubeClient().KubeClient().AppsV1().DaemonSets(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, daemonSet.Spec.Template.Spec.NodeSelector, map[string]string{"kubernetes.io/os": "linux"})
+	assert.Equal(t, daemonSet.Annotations[NodeSelectorAnnotation], "")
+}
+
+func TestReplaceJob(t *testing.T) {
+	podTemplate := testPodTemplate()
+	podTemplate.Labels = map[string]string{"app": "test", "job-name": "test", "controller-uid": "123"}
+	podTemplate.Spec.RestartPolicy = corev1.RestartPolicyNever
+	ctx := newTestContext(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace},
+		Spec: batchv1.JobSpec{
+			Parallelism: ptr.Int32(2),
+			Template:    podTemplate,
+		},
+	})
+
+	replaceAndRevert(t, ctx, "Job", func() {
+		job, err := ctx.KubeClient().KubeClient().BatchV1().Jobs(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Equal(t, *job.Spec.Parallelism, int32(0))
+		assert.Equal(t, job.Annotations[ReplicasAnnotation], "2")
+
+		deployment, err := ctx.KubeClient().KubeClient().AppsV1().Deployments(testNamespace).Get(ctx.Context(), "test-devspace", metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Equal(t, deployment.Spec.Template.Spec.RestartPolicy, corev1.RestartPolicyAlways)
+		assert.Equal(t, deployment.Spec.Template.Labels["job-name"], "")
+		assert.Equal(t, deployment.Spec.Template.Labels["controller-uid"], "")
+	})
+
+	job, err := ctx.KubeClient().KubeClient().BatchV1().Jobs(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *job.Spec.Parallelism, int32(2))
+}
+
+func TestReplaceCronJob(t *testing.T) {
+	podTemplate := testPodTemplate()
+	podTemplate.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	ctx := newTestContext(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "*/5 * * * *",
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: podTemplate,
+				},
+			},
+		},
+	})
+
+	replaceAndRevert(t, ctx, "CronJob", func() {
+		cronJob, err := ctx.KubeClient().KubeClient().BatchV1().CronJobs(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Assert(t, cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend)
+	})
+
+	cronJob, err := ctx.KubeClient().KubeClient().BatchV1().CronJobs(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, cronJob.Spec.Suspend != nil && !*cronJob.Spec.Suspend)
+}
+
+func TestReplaceBarePod(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ctx := newTestContext(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace, Labels: testLabels},
+		Spec:       podTemplate.Spec,
+	})
+
+	replaceAndRevert(t, ctx, "Pod", func() {
+		_, err := ctx.KubeClient().KubeClient().CoreV1().Pods(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+		assert.Assert(t, kerrors.IsNotFound(err))
+
+		devPodCache, _ := ctx.Config().RemoteCache().GetDevPod("test")
+		assert.Assert(t, devPodCache.TargetManifest != "")
+	})
+
+	pod, err := ctx.KubeClient().KubeClient().CoreV1().Pods(testNamespace).Get(ctx.Context(), "test", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pod.Labels, testLabels)
+	assert.Equal(t, pod.Spec.Containers[0].Image, "test:latest")
+}
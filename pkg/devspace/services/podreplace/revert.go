@@ -1,6 +1,7 @@
 package podreplace
 
 import (
+	"encoding/json"
 	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/context/values"
@@ -8,6 +9,7 @@ import (
 	patch2 "github.com/loft-sh/devspace/pkg/util/patch"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,6 +50,17 @@ func (p *replacer) RevertReplacePod(ctx devspacecontext.Context, devPodCache *re
 		namespace = ctx.KubeClient().Namespace()
 	}
 
+	// remove the intercept proxy
+	if devPodCache.InterceptTemplate != "" {
+		reverted, err := revertIntercept(ctx, devPodCache, namespace)
+		if err != nil {
+			return false, err
+		}
+
+		ctx.Config().RemoteCache().DeleteDevPod(devPodCache.Name)
+		return reverted, ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+	}
+
 	// delete replica set & scale up parent
 	deleted := false
 	if devPodCache.Deployment != "" {
@@ -61,6 +74,18 @@ func (p *replacer) RevertReplacePod(ctx devspacecontext.Context, devPodCache *re
 		}
 	}
 
+	// recreate a bare pod
+	if devPodCache.TargetKind == "Pod" {
+		ctx.Log().Infof("Recreating Pod %s...", devPodCache.TargetName)
+		err := recreateTargetPod(ctx, devPodCache)
+		if err != nil {
+			return false, err
+		}
+
+		ctx.Config().RemoteCache().DeleteDevPod(devPodCache.Name)
+		return deleted, ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+	}
+
 	// scale up parent
 	parent, err := findTargetByKindName(ctx, devPodCache.TargetKind, namespace, devPodCache.TargetName)
 	if err != nil {
@@ -80,6 +105,44 @@ func (p *replacer) RevertReplacePod(ctx devspacecontext.Context, devPodCache *re
 	return deleted, ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
 }
 
+// recreateTargetPod creates the bare pod again that was deleted during the replacement
+func recreateTargetPod(ctx devspacecontext.Context, devPodCache *remotecache.DevPodCache) error {
+	pod, err := cachedTargetPod(devPodCache)
+	if err != nil {
+		return err
+	} else if pod == nil {
+		ctx.Log().Debugf("No manifest for pod %s found, skip recreating it", devPodCache.TargetName)
+		return nil
+	}
+
+	if pod.Namespace == "" {
+		pod.Namespace = devPodCache.Namespace
+	}
+	_, err = ctx.KubeClient().KubeClient().CoreV1().Pods(pod.Namespace).Create(ctx.Context(), pod, metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, "recreate pod")
+	}
+
+	return nil
+}
+
+// originalNodeSelector returns the node selector of the daemon set before it was scaled down
+func originalNodeSelector(daemonSet *appsv1.DaemonSet) (map[string]string, error) {
+	if daemonSet.Annotations == nil || daemonSet.Annotations[NodeSelectorAnnotation] == "" {
+		return daemonSet.Spec.Template.Spec.NodeSelector, nil
+	}
+
+	nodeSelector := map[string]string{}
+	err := json.Unmarshal([]byte(daemonSet.Annotations[NodeSelectorAnnotation]), &nodeSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse original node selector")
+	} else if len(nodeSelector) == 0 {
+		return nil, nil
+	}
+
+	return nodeSelector, nil
+}
+
 func scaleUpTarget(ctx devspacecontext.Context, parent runtime.Object) error {
 	clonedParent := parent.DeepCopyObject()
 	metaParent, err := meta.Accessor(parent)
@@ -89,30 +152,65 @@ func scaleUpTarget(ctx devspacecontext.Context, parent runtime.Object) error {
 
 	// check if required annotation is there
 	annotations := metaParent.GetAnnotations()
-	if annotations == nil || annotations[ReplicasAnnotation] == "" {
+	if annotations == nil {
 		return nil
 	}
 
-	// scale up parent
-	oldReplica, err := strconv.Atoi(annotations[ReplicasAnnotation])
-	if err != nil {
-		return errors.Wrap(err, "parse old replicas")
-	} else if oldReplica == 0 {
-		return nil
-	}
-
-	oldReplica32 := int32(oldReplica)
 	switch t := parent.(type) {
-	case *appsv1.ReplicaSet:
-		t.Spec.Replicas = &oldReplica32
-	case *appsv1.Deployment:
-		t.Spec.Replicas = &oldReplica32
-	case *appsv1.StatefulSet:
-		t.Spec.Replicas = &oldReplica32
-	}
+	case *appsv1.DaemonSet:
+		if annotations[NodeSelectorAnnotation] == "" {
+			return nil
+		}
+
+		// restore the node selector
+		nodeSelector, err := originalNodeSelector(t)
+		if err != nil {
+			return err
+		}
 
-	// delete replicas annotation
-	delete(annotations, ReplicasAnnotation)
+		t.Spec.Template.Spec.NodeSelector = nodeSelector
+		delete(annotations, NodeSelectorAnnotation)
+	case *batchv1.CronJob:
+		if annotations[SuspendAnnotation] == "" {
+			return nil
+		}
+
+		// resume the cron job
+		suspend, err := strconv.ParseBool(annotations[SuspendAnnotation])
+		if err != nil {
+			return errors.Wrap(err, "parse old suspend")
+		}
+
+		t.Spec.Suspend = &suspend
+		delete(annotations, SuspendAnnotation)
+	default:
+		if annotations[ReplicasAnnotation] == "" {
+			return nil
+		}
+
+		// scale up parent
+		oldReplica, err := strconv.Atoi(annotations[ReplicasAnnotation])
+		if err != nil {
+			return errors.Wrap(err, "parse old replicas")
+		} else if oldReplica == 0 {
+			return nil
+		}
+
+		oldReplica32 := int32(oldReplica)
+		switch t := parent.(type) {
+		case *appsv1.ReplicaSet:
+			t.Spec.Replicas = &oldReplica32
+		case *appsv1.Deployment:
+			t.Spec.Replicas = &oldReplica32
+		case *appsv1.StatefulSet:
+			t.Spec.Replicas = &oldReplica32
+		case *batchv1.Job:
+			t.Spec.Parallelism = &oldReplica32
+		}
+
+		// delete replicas annotation
+		delete(annotations, ReplicasAnnotation)
+	}
 	metaParent.SetAnnotations(annotations)
 
 	// create patch
@@ -130,6 +228,12 @@ func scaleUpTarget(ctx devspacecontext.Context, parent runtime.Object) error {
 		_, err = ctx.KubeClient().KubeClient().AppsV1().Deployments(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
 	case *appsv1.StatefulSet:
 		_, err = ctx.KubeClient().KubeClient().AppsV1().StatefulSets(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *appsv1.DaemonSet:
+		_, err = ctx.KubeClient().KubeClient().AppsV1().DaemonSets(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *batchv1.Job:
+		_, err = ctx.KubeClient().KubeClient().BatchV1().Jobs(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
+	case *batchv1.CronJob:
+		_, err = ctx.KubeClient().KubeClient().BatchV1().CronJobs(t.Namespace).Patch(ctx.Context(), t.Name, patch.Type(), bytes, metav1.PatchOptions{})
 	}
 	if err != nil {
 		return errors.Wrap(err, "patch parent")
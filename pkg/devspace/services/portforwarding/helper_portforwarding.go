@@ -0,0 +1,127 @@
+package portforwarding
+
+import (
+	"io"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/hook"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
+	"github.com/loft-sh/devspace/pkg/devspace/services/sync"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/loft-sh/devspace/pkg/devspace/tunnel"
+	"github.com/loft-sh/devspace/pkg/util/tomb"
+	"github.com/pkg/errors"
+)
+
+// StartHelperForwarding forwards udp ports, unix sockets and ports with a target through the
+// DevSpace helper, because kubernetes port forwarding only supports tcp ports
+func StartHelperForwarding(ctx devspacecontext.Context, name, arch string, portMappings []*latest.PortMapping, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+	if ctx.IsDone() {
+		return nil
+	}
+
+	container, err := selector.SelectSingleContainer(ctx.Context(), ctx.KubeClient(), ctx.Log())
+	if err != nil {
+		return errors.Wrap(err, "error selecting container")
+	} else if container == nil {
+		return nil
+	}
+
+	// make sure the DevSpace helper binary is injected
+	err = inject.InjectDevSpaceHelper(ctx.Context(), ctx.KubeClient(), container.Pod, container.Container.Name, arch, ctx.Log())
+	if err != nil {
+		return err
+	}
+
+	errorChan := make(chan error, 2)
+	closeChan := make(chan struct{})
+
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+	go func() {
+		err := sync.StartStream(ctx.Context(), ctx.KubeClient(), container.Pod, container.Container.Name, []string{inject.DevSpaceHelperContainerPath, "tunnel"}, stdinReader, stdoutWriter, false, ctx.Log())
+		if err != nil {
+			errorChan <- errors.Errorf("connection lost to pod %s/%s: %v", container.Pod.Namespace, container.Pod.Name, err)
+		}
+	}()
+
+	go func() {
+		err := tunnel.StartForward(ctx.Context(), stdoutReader, stdinWriter, portMappings, closeChan, container.Pod.Namespace, container.Pod.Name, name, ctx.Log())
+		if err != nil {
+			errorChan <- err
+		}
+	}()
+
+	parent.Go(func() error {
+		select {
+		case <-ctx.Context().Done():
+			close(closeChan)
+			_ = stdinWriter.Close()
+			_ = stdoutWriter.Close()
+			stopPortForwarding(ctx, name, portMappings, parent)
+		case err := <-errorChan:
+			close(closeChan)
+			_ = stdinWriter.Close()
+			_ = stdoutWriter.Close()
+			if ctx.IsDone() {
+				stopPortForwarding(ctx, name, portMappings, parent)
+				return nil
+			}
+			if err != nil {
+				ctx.Log().Errorf("Restarting because: %v", err)
+				shouldExit := sync.PrintPodError(ctx.Context(), ctx.KubeClient(), container.Pod, ctx.Log())
+				hook.LogExecuteHooks(ctx, map[string]interface{}{
+					"port_forwarding_config": portMappings,
+					"error":                  err,
+				}, hook.EventsForSingle("restart:portForwarding", name).With("portForwarding.restart")...)
+				if shouldExit {
+					stopPortForwarding(ctx, name, portMappings, parent)
+					return nil
+				}
+
+				for {
+					err = StartHelperForwarding(ctx, name, arch, portMappings, selector, parent)
+					if err != nil {
+						hook.LogExecuteHooks(ctx, map[string]interface{}{
+							"port_forwarding_config": portMappings,
+							"error":                  err,
+						}, hook.EventsForSingle("restart:portForwarding", name).With("portForwarding.restart")...)
+						ctx.Log().Errorf("Error restarting port-forwarding: %v", err)
+						ctx.Log().Errorf("Will try again in 15 seconds")
+
+						select {
+						case <-time.After(time.Second * 15):
+							continue
+						case <-ctx.Context().Done():
+							stopPortForwarding(ctx, name, portMappings, parent)
+							return nil
+						}
+					}
+
+					break
+				}
+			}
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// splitByForwarder splits the port mappings into tcp ports that can be forwarded by kubernetes
+// and port mappings that need to be forwarded through the DevSpace helper
+func splitByForwarder(portMappings []*latest.PortMapping) ([]*latest.PortMapping, []*latest.PortMapping) {
+	kube := []*latest.PortMapping{}
+	helper := []*latest.PortMapping{}
+	for _, portMapping := range portMappings {
+		if portMapping.Protocol == latest.PortProtocolUDP || portMapping.LocalSocket != "" || portMapping.Target != "" {
+			helper = append(helper, portMapping)
+		} else {
+			kube = append(kube, portMapping)
+		}
+	}
+
+	return kube, helper
+}
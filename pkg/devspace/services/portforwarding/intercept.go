@@ -0,0 +1,40 @@
+package portforwarding
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/devspace/helper/intercept"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
+	"github.com/loft-sh/devspace/pkg/devspace/services/podreplace"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/loft-sh/devspace/pkg/util/tomb"
+	"github.com/pkg/errors"
+)
+
+// startIntercept injects the DevSpace helper into the intercept proxy container, which waits for it
+// before starting the proxy. If matching requests should reach a local process, the tunnel port
+// of the proxy is reverse forwarded to the local port.
+func startIntercept(ctx devspacecontext.Context, devPod *latest.DevPod, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+	selector = selector.WithContainer(podreplace.InterceptContainer)
+	if devPod.Intercept.Target != "" {
+		container, err := selector.SelectSingleContainer(ctx.Context(), ctx.KubeClient(), ctx.Log())
+		if err != nil {
+			return errors.Wrap(err, "error selecting intercept container")
+		}
+
+		return inject.InjectDevSpaceHelper(ctx.Context(), ctx.KubeClient(), container.Pod, container.Container.Name, string(devPod.Arch), ctx.Log())
+	}
+
+	localPort := devPod.Intercept.LocalPort
+	if localPort == 0 {
+		localPort = devPod.Intercept.Port
+	}
+
+	return startReversePortForwardingWithHooks(ctx, devPod.Name, string(devPod.Arch), []*latest.PortMapping{
+		{
+			Port: fmt.Sprintf("%d:%d", localPort, intercept.TunnelPort),
+		},
+	}, selector, parent)
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/events"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/services/sync"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
@@ -30,7 +31,7 @@ func StartPortForwarding(ctx devspacecontext.Context, devPod *latest.DevPod, sel
 	initDoneArray := []chan struct{}{}
 	if len(devPod.Ports) > 0 {
 		initDoneArray = append(initDoneArray, parent.NotifyGo(func() error {
-			return startPortForwardingWithHooks(ctx, devPod.Name, devPod.Ports, selector, parent)
+			return startPortForwardingWithHooks(ctx, devPod, selector, parent)
 		}))
 	}
 
@@ -44,6 +45,13 @@ func StartPortForwarding(ctx devspacecontext.Context, devPod *latest.DevPod, sel
 		return true
 	})
 
+	// intercept
+	if devPod.Intercept != nil {
+		initDoneArray = append(initDoneArray, parent.NotifyGo(func() error {
+			return startIntercept(ctx, devPod, selector, parent)
+		}))
+	}
+
 	// wait until everything is initialized
 	for _, initDone := range initDoneArray {
 		<-initDone
@@ -76,7 +84,8 @@ func startReversePortForwardingWithHooks(ctx devspacecontext.Context, name, arch
 	return nil
 }
 
-func startPortForwardingWithHooks(ctx devspacecontext.Context, name string, portMappings []*latest.PortMapping, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+func startPortForwardingWithHooks(ctx devspacecontext.Context, devPod *latest.DevPod, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+	name, portMappings := devPod.Name, devPod.Ports
 	pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 		"port_forwarding_config": portMappings,
 	}, hook.EventsForSingle("start:portForwarding", name).With("portForwarding.start")...)
@@ -85,7 +94,19 @@ func startPortForwardingWithHooks(ctx devspacecontext.Context, name string, port
 	}
 
 	// start port forwarding
-	err := StartForwarding(ctx, name, portMappings, selector, parent)
+	var err error
+	kubePorts, helperPorts := splitByForwarder(portMappings)
+	if len(kubePorts) > 0 {
+		err = StartForwarding(ctx, name, kubePorts, selector, parent)
+	}
+	if err == nil && len(helperPorts) > 0 {
+		helperSelector := selector
+		if devPod.Container != "" {
+			helperSelector = selector.WithContainer(devPod.Container)
+		}
+
+		err = StartHelperForwarding(ctx, name, string(devPod.Arch), helperPorts, helperSelector, parent)
+	}
 	if err != nil {
 		pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 			"port_forwarding_config": portMappings,
@@ -165,6 +186,11 @@ func StartForwarding(ctx devspacecontext.Context, name string, portMappings []*l
 		return nil
 	case <-readyChan:
 		ctx.Log().Donef("Port forwarding started on: %s", strings.Join(portsFormatted, ", "))
+		events.Emit(events.PortForwardOpened, &events.PortForward{
+			Name:  name,
+			Pod:   pod.Namespace + "/" + pod.Name,
+			Ports: ports,
+		})
 	case err := <-errorChan:
 		if ctx.IsDone() {
 			return nil
@@ -0,0 +1,78 @@
+package sync
+
+import (
+	"sort"
+	syncpkg "sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/events"
+	"github.com/loft-sh/devspace/pkg/devspace/sync"
+)
+
+// maxConflicts is the maximum amount of conflicts that are remembered per sync
+const maxConflicts = 100
+
+// SyncConflicts are the latest conflicts that were detected by a single sync
+type SyncConflicts struct {
+	// Name is the name of the dev configuration
+	Name string `json:"name"`
+
+	// SyncPath is the path of the sync configuration
+	SyncPath string `json:"syncPath"`
+
+	// Conflicts are the detected conflicts, the oldest first
+	Conflicts []*sync.Conflict `json:"conflicts"`
+}
+
+var (
+	conflicts      = map[string]*SyncConflicts{}
+	conflictsMutex syncpkg.Mutex
+)
+
+// recordConflict remembers the conflict for the sync and emits a sync.conflict event
+func recordConflict(name, syncPath string, conflict *sync.Conflict) {
+	events.Emit(events.SyncConflict, &events.Conflict{
+		Name:       name,
+		SyncPath:   syncPath,
+		Path:       conflict.Path,
+		Strategy:   string(conflict.Strategy),
+		Resolution: string(conflict.Resolution),
+	})
+
+	conflictsMutex.Lock()
+	defer conflictsMutex.Unlock()
+
+	key := runningSyncKey(name, syncPath)
+	syncConflicts, ok := conflicts[key]
+	if !ok {
+		syncConflicts = &SyncConflicts{
+			Name:     name,
+			SyncPath: syncPath,
+		}
+		conflicts[key] = syncConflicts
+	}
+
+	syncConflicts.Conflicts = append(syncConflicts.Conflicts, conflict)
+	if len(syncConflicts.Conflicts) > maxConflicts {
+		syncConflicts.Conflicts = syncConflicts.Conflicts[len(syncConflicts.Conflicts)-maxConflicts:]
+	}
+}
+
+// Conflicts returns the latest conflicts of every sync of this process
+func Conflicts() []*SyncConflicts {
+	conflictsMutex.Lock()
+	defer conflictsMutex.Unlock()
+
+	retConflicts := make([]*SyncConflicts, 0, len(conflicts))
+	for _, syncConflicts := range conflicts {
+		retConflicts = append(retConflicts, &SyncConflicts{
+			Name:      syncConflicts.Name,
+			SyncPath:  syncConflicts.SyncPath,
+			Conflicts: append([]*sync.Conflict{}, syncConflicts.Conflicts...),
+		})
+	}
+
+	sort.Slice(retConflicts, func(i, j int) bool {
+		return runningSyncKey(retConflicts[i].Name, retConflicts[i].SyncPath) < runningSyncKey(retConflicts[j].Name, retConflicts[j].SyncPath)
+	})
+	return retConflicts
+}
@@ -0,0 +1,41 @@
+package sync
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/events"
+	"github.com/loft-sh/devspace/pkg/devspace/sync"
+	"gotest.tools/assert"
+)
+
+func TestRecordConflict(t *testing.T) {
+	out := &bytes.Buffer{}
+	events.SetEmitter(events.NewJSONLEmitter(out))
+	defer events.SetEmitter(nil)
+	defer func() { conflicts = map[string]*SyncConflicts{} }()
+
+	recordConflict("web", "./src:/app", &sync.Conflict{Path: "/a.txt", Strategy: latest.ConflictStrategyPreferRemote, Resolution: sync.ConflictResolutionKeptRemote})
+	recordConflict("api", "./:/app", &sync.Conflict{Path: "/b.txt", Strategy: latest.ConflictStrategyFail, Resolution: sync.ConflictResolutionFailed})
+	for i := 0; i < maxConflicts+1; i++ {
+		recordConflict("web", "./src:/app", &sync.Conflict{Path: "/c.txt", Strategy: latest.ConflictStrategyPreferRemote, Resolution: sync.ConflictResolutionKeptRemote})
+	}
+
+	// the conflicts are kept per sync
+	syncConflicts := Conflicts()
+	assert.Equal(t, len(syncConflicts), 2)
+	assert.Equal(t, syncConflicts[0].Name, "api")
+	assert.Equal(t, len(syncConflicts[0].Conflicts), 1)
+	assert.Equal(t, syncConflicts[0].Conflicts[0].Path, "/b.txt")
+	assert.Equal(t, syncConflicts[1].Name, "web")
+	assert.Equal(t, syncConflicts[1].SyncPath, "./src:/app")
+	assert.Equal(t, len(syncConflicts[1].Conflicts), maxConflicts)
+	assert.Equal(t, syncConflicts[1].Conflicts[0].Path, "/c.txt")
+
+	// and emitted as typed events
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, len(lines), maxConflicts+3)
+	assert.Assert(t, strings.Contains(lines[1], `"type":"sync.conflict","data":{"name":"api","syncPath":"./:/app","path":"/b.txt","strategy":"fail","resolution":"failed"}`), lines[1])
+}
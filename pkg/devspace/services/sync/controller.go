@@ -19,13 +19,16 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/events"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/loft-sh/devspace/pkg/devspace/sync"
+	"github.com/loft-sh/devspace/pkg/util/hash"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/scanner"
 	"github.com/pkg/errors"
@@ -66,6 +69,7 @@ func (c *controller) Start(ctx devspacecontext.Context, options *Options, parent
 
 	err := c.startWithWait(ctx, options, parent)
 	if err != nil {
+		emitSyncEvent(events.SyncError, options, nil, err)
 		pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 			"sync_config": options.SyncConfig,
 			"ERROR":       err,
@@ -147,6 +151,7 @@ func (c *controller) startWithWait(ctx devspacecontext.Context, options *Options
 				downloadDone = true
 			case <-ctx.Context().Done():
 				client.Stop(nil)
+				unregisterSync(options.Name, options.SyncConfig.Path)
 				pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 					"sync_config": options.SyncConfig,
 				}, hook.EventsForSingle("stop:sync", options.Name).With("sync.stop")...)
@@ -156,6 +161,7 @@ func (c *controller) startWithWait(ctx devspacecontext.Context, options *Options
 				return nil
 			case <-onDone:
 				parent.Kill(nil)
+				unregisterSync(options.Name, options.SyncConfig.Path)
 				pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 					"sync_config": options.SyncConfig,
 				}, hook.EventsForSingle("stop:sync", options.Name).With("sync.stop")...)
@@ -177,6 +183,8 @@ func (c *controller) startWithWait(ctx devspacecontext.Context, options *Options
 		}
 	}
 
+	emitSyncEvent(events.SyncReady, options, pod, nil)
+
 	// should we restart the client on error?
 	if options.RestartOnError {
 		parent.Go(func() error {
@@ -193,6 +201,7 @@ func (c *controller) startWithWait(ctx devspacecontext.Context, options *Options
 					"ERROR":       err,
 				}, hook.EventsForSingle("restart:sync", options.Name).With("sync.restart")...)
 
+				emitSyncEvent(events.SyncError, options, pod, err)
 				ctx.Log().Errorf("Restarting because: %v", err)
 				shouldExit := PrintPodError(ctx.Context(), ctx.KubeClient(), pod.Pod, ctx.Log())
 				if shouldExit {
@@ -230,6 +239,22 @@ func (c *controller) startWithWait(ctx devspacecontext.Context, options *Options
 	return nil
 }
 
+func emitSyncEvent(eventType events.Type, options *Options, container *selector.SelectedPodContainer, err error) {
+	payload := &events.Sync{
+		Name:  options.Name,
+		Error: events.ErrorString(err),
+	}
+	if options.SyncConfig != nil {
+		payload.LocalPath, payload.ContainerPath, _ = ParseSyncPath(options.SyncConfig.Path)
+	}
+	if container != nil && container.Pod != nil && container.Container != nil {
+		payload.Pod = container.Pod.Namespace + "/" + container.Pod.Name
+		payload.Container = container.Container.Name
+	}
+
+	events.Emit(eventType, payload)
+}
+
 func syncStop(ctx devspacecontext.Context, syncClient *sync.Sync, options *Options, parent *tomb.Tomb) {
 	syncClient.Stop(nil)
 	syncDone(ctx, options, parent)
@@ -237,6 +262,7 @@ func syncStop(ctx devspacecontext.Context, syncClient *sync.Sync, options *Optio
 
 func syncDone(ctx devspacecontext.Context, options *Options, parent *tomb.Tomb) {
 	parent.Kill(nil)
+	unregisterSync(options.Name, options.SyncConfig.Path)
 	hook.LogExecuteHooks(ctx.WithLogger(options.SyncLog), map[string]interface{}{
 		"sync_config": options.SyncConfig,
 	}, hook.EventsForSingle("stop:sync", options.Name).With("sync.stop")...)
@@ -277,7 +303,7 @@ func (c *controller) startSync(ctx devspacecontext.Context, options *Options, on
 	}
 
 	ctx.Log().Debug("Starting sync...")
-	syncClient, err := c.initClient(ctx, container.Pod, options.Arch, container.Container.Name, syncConfig, options.Starter, options.Verbose, options.SyncLog)
+	syncClient, err := c.initClient(ctx, options.Name, container.Pod, options.Arch, container.Container.Name, syncConfig, options.Starter, options.Verbose, options.SyncLog)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "start sync")
 	}
@@ -286,6 +312,7 @@ func (c *controller) startSync(ctx devspacecontext.Context, options *Options, on
 	if err != nil {
 		return nil, nil, errors.Errorf("Sync error: %v", err)
 	}
+	registerSync(options.Name, syncConfig.Path, container.Pod.Namespace+"/"+container.Pod.Name, container.Container.Name, syncClient)
 
 	localPath, remotePath, err := ParseSyncPath(syncConfig.Path)
 	if err == nil {
@@ -321,7 +348,7 @@ func ParseSyncPath(path string) (localPath string, remotePath string, err error)
 	return splitted[0], splitted[1], nil
 }
 
-func (c *controller) initClient(ctx devspacecontext.Context, pod *v1.Pod, arch, container string, syncConfig *latest.SyncConfig, starter sync.DelayedContainerStarter, verbose bool, customLog logpkg.Logger) (*sync.Sync, error) {
+func (c *controller) initClient(ctx devspacecontext.Context, name string, pod *v1.Pod, arch, container string, syncConfig *latest.SyncConfig, starter sync.DelayedContainerStarter, verbose bool, customLog logpkg.Logger) (*sync.Sync, error) {
 	localPath, containerPath, err := ParseSyncPath(syncConfig.Path)
 	if err != nil {
 		return nil, err
@@ -341,10 +368,12 @@ func (c *controller) initClient(ctx devspacecontext.Context, pod *v1.Pod, arch,
 		Verbose:              verbose,
 		InitialSyncCompareBy: compareBy,
 		InitialSync:          syncConfig.InitialSync,
+		ConflictStrategy:     syncConfig.ConflictStrategy,
 		UpstreamDisabled:     upstreamDisabled,
 		DownstreamDisabled:   downstreamDisabled,
 		Log:                  customLog,
 		Polling:              syncConfig.Polling,
+		StateFile:            stateFile(ctx, name, pod, container, syncConfig),
 		Starter:              starter,
 		ResolveCommand: func(command string, args []string) (string, []string, error) {
 			return hook.ResolveCommand(ctx.Context(), command, args, ctx.WorkingDir(), ctx.Config(), ctx.Dependencies())
@@ -395,6 +424,11 @@ func (c *controller) initClient(ctx devspacecontext.Context, pod *v1.Pod, arch,
 		options.Log = logpkg.GetFileLogger("sync")
 	}
 
+	// record conflicts for the ui and the event stream
+	options.OnConflict = func(conflict *sync.Conflict) {
+		recordConflict(name, syncConfig.Path, conflict)
+	}
+
 	// add exec hooks
 	if syncConfig.OnUpload != nil {
 		options.Exec = syncConfig.OnUpload.Exec
@@ -541,6 +575,13 @@ func (c *controller) initClient(ctx devspacecontext.Context, pod *v1.Pod, arch,
 	return syncClient, nil
 }
 
+// stateFile returns the path where the state of the sync is saved. The state is only valid
+// for the same dev configuration, pod and container, so these are part of the file name.
+func stateFile(ctx devspacecontext.Context, name string, pod *v1.Pod, container string, syncConfig *latest.SyncConfig) string {
+	key := strings.Join([]string{name, string(pod.UID), container, syncConfig.Path}, ":")
+	return filepath.Join(ctx.WorkingDir(), constants.DefaultCacheFolder, "sync", hash.String(key)+".json")
+}
+
 func getSyncCommands(cmd *latest.SyncExecCommand) (string, []string, string, []string) {
 	if cmd.Command != "" {
 		return cmd.Command, cmd.Args, cmd.Command, cmd.Args
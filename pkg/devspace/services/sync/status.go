@@ -0,0 +1,101 @@
+package sync
+
+import (
+	"sort"
+	syncpkg "sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/sync"
+)
+
+// Status is the status of a sync that was started by this process
+type Status struct {
+	// Name is the name of the dev configuration
+	Name string `json:"name"`
+
+	// SyncPath is the path of the sync configuration
+	SyncPath string `json:"syncPath"`
+
+	// Pod is the namespace and name of the pod the sync is connected to
+	Pod string `json:"pod"`
+
+	// Container is the container the sync is connected to
+	Container string `json:"container"`
+
+	// Restarts is how often the sync was restarted because of an error
+	Restarts int `json:"restarts"`
+
+	// Stats are the statistics of the sync since it was (re-)started
+	Stats sync.Stats `json:"stats"`
+}
+
+type runningSync struct {
+	status Status
+	client *sync.Sync
+}
+
+var (
+	runningSyncs      = map[string]*runningSync{}
+	runningSyncsMutex syncpkg.Mutex
+)
+
+func runningSyncKey(name, syncPath string) string {
+	return name + ":" + syncPath
+}
+
+// registerSync remembers the started sync client, so that its statistics can be retrieved.
+// If the sync was already registered, it was restarted and the last error is carried over.
+func registerSync(name, syncPath, pod, container string, client *sync.Sync) {
+	runningSyncsMutex.Lock()
+	defer runningSyncsMutex.Unlock()
+
+	key := runningSyncKey(name, syncPath)
+	status := Status{
+		Name:      name,
+		SyncPath:  syncPath,
+		Pod:       pod,
+		Container: container,
+	}
+	if previous, ok := runningSyncs[key]; ok {
+		status.Restarts = previous.status.Restarts + 1
+
+		previousStats := previous.client.Stats()
+		status.Stats.LastError = previousStats.LastError
+		status.Stats.LastErrorTime = previousStats.LastErrorTime
+	}
+
+	runningSyncs[key] = &runningSync{
+		status: status,
+		client: client,
+	}
+}
+
+func unregisterSync(name, syncPath string) {
+	runningSyncsMutex.Lock()
+	defer runningSyncsMutex.Unlock()
+
+	delete(runningSyncs, runningSyncKey(name, syncPath))
+}
+
+// Statuses returns the status of all syncs that are currently running in this process
+func Statuses() []*Status {
+	runningSyncsMutex.Lock()
+	defer runningSyncsMutex.Unlock()
+
+	statuses := make([]*Status, 0, len(runningSyncs))
+	for _, running := range runningSyncs {
+		status := running.status
+		stats := running.client.Stats()
+		if stats.LastError == "" {
+			stats.LastError = status.Stats.LastError
+			stats.LastErrorTime = status.Stats.LastErrorTime
+		}
+
+		status.Stats = stats
+		statuses = append(statuses, &status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return runningSyncKey(statuses[i].Name, statuses[i].SyncPath) < runningSyncKey(statuses[j].Name, statuses[j].SyncPath)
+	})
+	return statuses
+}
@@ -0,0 +1,295 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/fsutil"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConflictSuffix is appended to the local copy of a file that is kept because of a conflict
+const ConflictSuffix = ".conflict"
+
+// ConflictResolution is how a conflict was resolved
+type ConflictResolution string
+
+// List of values that a conflict resolution can take
+const (
+	// ConflictResolutionKeptLocal means the local file was uploaded and replaced the container file
+	ConflictResolutionKeptLocal ConflictResolution = "keptLocal"
+
+	// ConflictResolutionKeptRemote means the container file was downloaded and replaced the local file
+	ConflictResolutionKeptRemote ConflictResolution = "keptRemote"
+
+	// ConflictResolutionKeptBoth means the local file was copied to <file>.conflict and the container file was downloaded
+	ConflictResolutionKeptBoth ConflictResolution = "keptBoth"
+
+	// ConflictResolutionFailed means the sync was stopped with an error
+	ConflictResolutionFailed ConflictResolution = "failed"
+)
+
+// Conflict is a path that was changed locally and in the container since it was synced the last time
+type Conflict struct {
+	// Path is the path of the file relative to the sync path
+	Path string `json:"path"`
+
+	// Strategy is the strategy that was used to resolve the conflict
+	Strategy latest.ConflictStrategy `json:"strategy"`
+
+	// Resolution is how the conflict was resolved
+	Resolution ConflictResolution `json:"resolution"`
+
+	// Local is the version of the local file
+	Local *FileVersion `json:"local,omitempty"`
+
+	// Remote is the version of the file in the container
+	Remote *FileVersion `json:"remote,omitempty"`
+
+	// Ancestor is the version both sides had in common when the file was synced the last
+	// time. This is nil if the file was created on both sides
+	Ancestor *FileVersion `json:"ancestor,omitempty"`
+
+	// Time is the time the conflict was detected
+	Time time.Time `json:"time"`
+}
+
+// ConflictError is returned if a conflict was detected and the conflict strategy is fail
+type ConflictError struct {
+	Path string
+}
+
+// Error implements interface
+func (c *ConflictError) Error() string {
+	return fmt.Sprintf("conflict: '%s' was changed locally and in the container", c.Path)
+}
+
+// FileVersion identifies a version of a synced file
+type FileVersion struct {
+	Size  int64 `json:"size"`
+	Mtime int64 `json:"mtime"`
+}
+
+func (f *FileVersion) equals(other *FileVersion) bool {
+	return other != nil && f.Size == other.Size && f.Mtime == other.Mtime
+}
+
+func newFileVersion(fileInformation *FileInformation) *FileVersion {
+	if fileInformation == nil || fileInformation.IsDirectory || fileInformation.IsSymbolicLink {
+		return nil
+	}
+
+	return &FileVersion{
+		Size:  fileInformation.Size,
+		Mtime: fileInformation.Mtime,
+	}
+}
+
+// conflictVersions are the versions of a conflict that was already resolved by one side
+// of the sync, which means the other side should not report it again
+type conflictVersions struct {
+	local  FileVersion
+	remote FileVersion
+}
+
+func (s *Sync) conflictStrategy() latest.ConflictStrategy {
+	if s.Options.ConflictStrategy == "" {
+		return latest.ConflictStrategyPreferLocal
+	}
+
+	return s.Options.ConflictStrategy
+}
+
+// checkConflict checks if the given local and remote versions of a path conflict with each other, which is the
+// case if both sides changed since the version in the file index. The first return value signals if there is a
+// conflict and the second one if the conflict was not already seen by the other side of the sync.
+// s.fileIndex needs to be locked before this function is called
+func (s *Sync) checkConflict(name string, local *FileVersion, remoteVersion *FileVersion) (bool, bool) {
+	if s.Options.UpstreamDisabled || s.Options.DownstreamDisabled || local == nil || remoteVersion == nil || local.equals(remoteVersion) {
+		return false, false
+	}
+
+	ancestor := newFileVersion(s.fileIndex.fileMap[name])
+	if ancestor != nil && (local.equals(ancestor) || remoteVersion.equals(ancestor)) {
+		return false, false
+	}
+
+	versions := conflictVersions{local: *local, remote: *remoteVersion}
+	if seen, ok := s.fileIndex.conflicts[name]; ok {
+		delete(s.fileIndex.conflicts, name)
+		if seen == versions {
+			return true, false
+		}
+	}
+
+	return true, true
+}
+
+// resolveConflict reports the conflict and prepares its resolution. If the other side of the sync is expected
+// to see the conflict as well, it is remembered so that it is not reported twice.
+// s.fileIndex needs to be locked before this function is called
+func (s *Sync) resolveConflict(name string, local *FileVersion, remoteVersion *FileVersion, expectOtherSide bool) error {
+	strategy := s.conflictStrategy()
+	conflict := &Conflict{
+		Path:     name,
+		Strategy: strategy,
+		Local:    local,
+		Remote:   remoteVersion,
+		Ancestor: newFileVersion(s.fileIndex.fileMap[name]),
+		Time:     time.Now(),
+	}
+
+	var err error
+	switch strategy {
+	case latest.ConflictStrategyFail:
+		conflict.Resolution = ConflictResolutionFailed
+		err = &ConflictError{Path: name}
+	case latest.ConflictStrategyKeepBoth:
+		conflict.Resolution = ConflictResolutionKeptBoth
+		localPath := filepath.Join(s.LocalPath, name)
+		copyErr := fsutil.Copy(localPath, localPath+ConflictSuffix, true)
+		if copyErr != nil {
+			conflict.Resolution = ConflictResolutionFailed
+			err = errors.Wrapf(copyErr, "keep local copy of %s", name)
+		}
+	case latest.ConflictStrategyPreferRemote:
+		conflict.Resolution = ConflictResolutionKeptRemote
+	default:
+		conflict.Resolution = ConflictResolutionKeptLocal
+	}
+
+	s.log.Warnf("Conflict - '%s' was changed locally and in the container, resolved with strategy %s: %s", name, strategy, conflict.Resolution)
+	if s.Options.OnConflict != nil {
+		s.Options.OnConflict(conflict)
+	}
+	if err != nil {
+		return err
+	}
+
+	if expectOtherSide {
+		s.fileIndex.conflicts[name] = conflictVersions{local: *local, remote: *remoteVersion}
+	}
+
+	return nil
+}
+
+// filterConflicts retrieves the container versions of the given files and resolves the files that were
+// changed in the container as well. Returns the files that should still be uploaded.
+// u.sync.fileIndex needs to be locked before this function is called
+func (u *upstream) filterConflicts(files []*FileInformation) ([]*FileInformation, error) {
+	if u.sync.Options.DownstreamDisabled || u.statUnsupported {
+		return files, nil
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		if newFileVersion(f) != nil {
+			paths = append(paths, f.Name)
+		}
+	}
+	if len(paths) == 0 {
+		return files, nil
+	}
+
+	ctx, cancel := context.WithTimeout(u.sync.ctx, time.Minute*5)
+	defer cancel()
+
+	stats, err := u.client.Stat(ctx, &remote.Paths{Paths: paths})
+	if err != nil {
+		// the helper is too old to detect conflicts
+		if status.Code(err) == codes.Unimplemented {
+			u.statUnsupported = true
+			return files, nil
+		}
+
+		return nil, errors.Wrap(err, "stat remote files")
+	}
+
+	remoteVersions := make(map[string]*FileVersion, len(stats.Changes))
+	for _, change := range stats.Changes {
+		if change.ChangeType == remote.ChangeType_DELETE || change.IsDir {
+			continue
+		}
+
+		remoteVersions[change.Path] = &FileVersion{
+			Size:  change.Size,
+			Mtime: change.MtimeUnix,
+		}
+	}
+
+	strategy := u.sync.conflictStrategy()
+	upload := make([]*FileInformation, 0, len(files))
+	for _, f := range files {
+		local := newFileVersion(f)
+		conflict, unseen := u.sync.checkConflict(f.Name, local, remoteVersions[f.Name])
+		if conflict && unseen {
+			// if we don't upload the file, the downstream will see the conflict as well
+			err := u.sync.resolveConflict(f.Name, local, remoteVersions[f.Name], strategy != latest.ConflictStrategyPreferLocal)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !conflict || strategy == latest.ConflictStrategyPreferLocal {
+			upload = append(upload, f)
+		}
+	}
+
+	return upload, nil
+}
+
+// filterConflicts checks if the given remote changes were changed locally as well and resolves those
+// conflicts. Returns the changes that should still be downloaded.
+func (d *downstream) filterConflicts(changes []*remote.Change) ([]*remote.Change, error) {
+	d.sync.fileIndex.fileMapMutex.Lock()
+	defer d.sync.fileIndex.fileMapMutex.Unlock()
+
+	strategy := d.sync.conflictStrategy()
+	download := make([]*remote.Change, 0, len(changes))
+	for _, change := range changes {
+		if change.IsDir {
+			download = append(download, change)
+			continue
+		}
+
+		stat, err := os.Lstat(filepath.Join(d.sync.LocalPath, change.Path))
+		if err != nil || !stat.Mode().IsRegular() {
+			download = append(download, change)
+			continue
+		}
+
+		local := &FileVersion{
+			Size:  stat.Size(),
+			Mtime: stat.ModTime().Unix(),
+		}
+		remoteVersion := &FileVersion{
+			Size:  change.Size,
+			Mtime: change.MtimeUnix,
+		}
+		conflict, unseen := d.sync.checkConflict(change.Path, local, remoteVersion)
+		if !conflict {
+			download = append(download, change)
+			continue
+		} else if unseen {
+			// if we don't download the file, the upstream will see the conflict as well
+			err := d.sync.resolveConflict(change.Path, local, remoteVersion, strategy == latest.ConflictStrategyPreferLocal)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if strategy != latest.ConflictStrategyPreferLocal {
+			d.unarchiver.overridePaths[change.Path] = true
+			download = append(download, change)
+		}
+	}
+
+	return download, nil
+}
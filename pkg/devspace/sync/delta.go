@@ -0,0 +1,169 @@
+package sync
+
+import (
+	"context"
+	"hash/crc32"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/loft-sh/devspace/helper/util/rsync"
+	"github.com/pkg/errors"
+)
+
+const (
+	// deltaFileSize is the minimum file size for a file to be uploaded as block delta
+	deltaFileSize = 64 * 1024
+
+	// deltaMessageSize is the amount of literal data that is sent in a single delta message
+	deltaMessageSize = 512 * 1024
+)
+
+// patchFiles uploads the given files as block deltas against the files that already exist in
+// the container. Files that cannot be patched are returned and should be uploaded as archive.
+// u.sync.fileIndex needs to be locked before this function is called
+func (u *upstream) patchFiles(files []*FileInformation) ([]*FileInformation, []*FileInformation, error) {
+	upload := make([]*FileInformation, 0, len(files))
+	candidates := make([]*FileInformation, 0, len(files))
+	for _, f := range files {
+		remoteFile := u.sync.fileIndex.fileMap[f.Name]
+		if f.IsDirectory || f.IsSymbolicLink || f.ResolvedLink || f.Size < deltaFileSize || remoteFile == nil || remoteFile.IsDirectory || remoteFile.IsSymbolicLink {
+			upload = append(upload, f)
+			continue
+		}
+
+		candidates = append(candidates, f)
+	}
+	if len(candidates) == 0 {
+		return upload, nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(u.sync.ctx, time.Hour)
+	defer cancel()
+
+	// retrieve the signatures of all files with a single request
+	paths := make([]*remote.TouchPath, 0, len(candidates))
+	for _, f := range candidates {
+		paths = append(paths, &remote.TouchPath{Path: f.Name})
+	}
+	signatures, err := u.client.Checksums(ctx, &remote.TouchPaths{
+		Paths:      paths,
+		Signatures: true,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "retrieve signatures")
+	} else if len(signatures.Signatures) != len(candidates) {
+		// the helper is too old to calculate signatures
+		return append(upload, candidates...), nil, nil
+	}
+
+	deltaFiles := make([]*FileInformation, 0, len(candidates))
+	deltaSignatures := make([]*remote.FileSignature, 0, len(candidates))
+	for i, f := range candidates {
+		if signatures.Signatures[i].BlockSize <= 0 {
+			// the file does not exist anymore
+			upload = append(upload, f)
+			continue
+		}
+
+		deltaFiles = append(deltaFiles, f)
+		deltaSignatures = append(deltaSignatures, signatures.Signatures[i])
+	}
+	if len(deltaFiles) == 0 {
+		return upload, nil, nil
+	}
+
+	patchClient, err := u.client.Patch(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "patch")
+	}
+
+	patched := make([]*FileInformation, 0, len(deltaFiles))
+	for i, f := range deltaFiles {
+		ok, err := u.sendDelta(patchClient, f, deltaSignatures[i])
+		if err != nil {
+			_, recvErr := patchClient.CloseAndRecv()
+			if recvErr != nil {
+				return nil, nil, errors.Wrapf(recvErr, "patch %s", f.Name)
+			}
+
+			return nil, nil, errors.Wrapf(err, "patch %s", f.Name)
+		} else if !ok {
+			upload = append(upload, f)
+			continue
+		}
+
+		patched = append(patched, f)
+	}
+
+	_, err = patchClient.CloseAndRecv()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "after patch")
+	}
+
+	return upload, patched, nil
+}
+
+// sendDelta streams the delta between the local file and the remote signature to the helper.
+// Returns false if the local file could not be read and should be uploaded as archive instead.
+func (u *upstream) sendDelta(patchClient remote.Upstream_PatchClient, f *FileInformation, signature *remote.FileSignature) (bool, error) {
+	file, err := os.Open(path.Join(u.sync.LocalPath, f.Name))
+	if err != nil {
+		return false, nil
+	}
+	defer file.Close()
+
+	var (
+		checksum = crc32.NewIEEE()
+		reader   = io.TeeReader(file, checksum)
+
+		operations  []*remote.DeltaOperation
+		literalSize = 0
+		sentSize    = 0
+		reusedSize  = int64(0)
+	)
+	send := func(done bool) error {
+		delta := &remote.Delta{
+			Path:       f.Name,
+			MtimeUnix:  f.Mtime,
+			Mode:       uint32(f.Mode),
+			BlockSize:  signature.BlockSize,
+			Operations: operations,
+			Done:       done,
+		}
+		if done {
+			delta.Checksum = checksum.Sum32()
+		}
+
+		err := patchClient.Send(delta)
+		u.sync.recordTransfer(int64(literalSize), 0)
+		operations = nil
+		literalSize = 0
+		return err
+	}
+
+	err = rsync.Delta(reader, signature, func(operation *remote.DeltaOperation) error {
+		operations = append(operations, operation)
+		literalSize += len(operation.Data)
+		sentSize += len(operation.Data)
+		reusedSize += operation.BlockCount * signature.BlockSize
+		if literalSize < deltaMessageSize && len(operations) < 1024 {
+			return nil
+		}
+
+		return send(false)
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "calculate delta")
+	}
+
+	err = send(true)
+	if err != nil {
+		return false, err
+	}
+
+	u.sync.log.Debugf("Upstream - Patched '%s' (sent %0.2f KB, reused %0.2f KB)", u.getRelativeUpstreamPath(f.Name), float64(sentSize)/1024.0, float64(reusedSize)/1024.0)
+	return true, nil
+}
@@ -0,0 +1,113 @@
+//go:build !windows
+// +build !windows
+
+package sync
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/loft-sh/devspace/helper/server"
+	"google.golang.org/grpc"
+	"gotest.tools/assert"
+)
+
+// countingUpstreamClient counts the checksum requests sent to the helper
+type countingUpstreamClient struct {
+	remote.UpstreamClient
+
+	checksumRequests int
+}
+
+func (c *countingUpstreamClient) Checksums(ctx context.Context, in *remote.TouchPaths, opts ...grpc.CallOption) (*remote.PathsChecksum, error) {
+	c.checksumRequests++
+	return c.UpstreamClient.Checksums(ctx, in, opts...)
+}
+
+func TestPatchFiles(t *testing.T) {
+	remotePath, localPath, _ := initTestDirs(t)
+
+	// the remote files are changed locally, except gone.bin which was removed in the container
+	random := rand.New(rand.NewSource(1))
+	files := []*FileInformation{}
+	localContents := map[string][]byte{}
+	for _, name := range []string{"/a.bin", "/b.bin", "/gone.bin", "/small.txt"} {
+		content := make([]byte, 256*1024)
+		if name == "/small.txt" {
+			content = make([]byte, 1024)
+		}
+		_, _ = random.Read(content)
+		if name != "/gone.bin" {
+			assert.NilError(t, os.WriteFile(filepath.Join(remotePath, name), content, 0666))
+		}
+
+		localContent := append([]byte{}, content...)
+		copy(localContent[len(content)/2:], bytes.Repeat([]byte("local"), 100))
+		localContent = append(localContent, []byte("appended data")...)
+		assert.NilError(t, os.WriteFile(filepath.Join(localPath, name), localContent, 0666))
+		localContents[name] = localContent
+
+		files = append(files, &FileInformation{
+			Name:  name,
+			Size:  int64(len(localContent)),
+			Mtime: 1,
+			Mode:  0666,
+		})
+	}
+
+	syncClient, err := NewSync(context.Background(), localPath, getSyncOptions(nil))
+	assert.NilError(t, err)
+	defer syncClient.Stop(nil)
+
+	// start the upstream server
+	upClientReader, upClientWriter, _ := os.Pipe()
+	upServerReader, upServerWriter, _ := os.Pipe()
+	defer upClientReader.Close()
+	defer upClientWriter.Close()
+	defer upServerReader.Close()
+	defer upServerWriter.Close()
+
+	go func() {
+		_ = server.StartUpstreamServer(upServerReader, upClientWriter, &server.UpstreamOptions{
+			UploadPath:  remotePath,
+			ExludePaths: []string{},
+			ExitOnClose: false,
+		})
+	}()
+
+	err = syncClient.InitUpstream(upClientReader, upServerWriter)
+	assert.NilError(t, err)
+	client := &countingUpstreamClient{UpstreamClient: syncClient.upstream.client}
+	syncClient.upstream.client = client
+
+	syncClient.fileIndex.fileMapMutex.Lock()
+	for _, f := range files {
+		syncClient.fileIndex.fileMap[f.Name] = &FileInformation{Name: f.Name, Mode: 0666}
+	}
+	upload, patched, err := syncClient.upstream.patchFiles(files)
+	syncClient.fileIndex.fileMapMutex.Unlock()
+	assert.NilError(t, err)
+
+	// changed files are patched with the signatures of a single request
+	assert.Equal(t, client.checksumRequests, 1)
+	assert.DeepEqual(t, fileNames(patched), []string{"/a.bin", "/b.bin"})
+	assert.DeepEqual(t, fileNames(upload), []string{"/small.txt", "/gone.bin"})
+	for _, name := range []string{"/a.bin", "/b.bin"} {
+		content, err := os.ReadFile(filepath.Join(remotePath, name))
+		assert.NilError(t, err)
+		assert.Assert(t, bytes.Equal(content, localContents[name]), "unexpected content of %s", name)
+	}
+}
+
+func fileNames(files []*FileInformation) []string {
+	names := []string{}
+	for _, f := range files {
+		names = append(names, f.Name)
+	}
+	return names
+}
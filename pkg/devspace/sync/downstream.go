@@ -177,6 +177,7 @@ func (d *downstream) mainLoop() error {
 		if err != nil {
 			return errors.Wrap(err, "count changes")
 		}
+		d.sync.recordPendingDownloads(changeAmount.Amount)
 
 		// start waiting timer
 		if changeAmount.Amount > 0 && lastAmountChanges == 0 {
@@ -247,6 +248,15 @@ func (d *downstream) applyChanges(changes []*remote.Change, force bool) error {
 	// Remove all files and folders that should be deleted first and we ignore errors
 	d.remove(remove, force)
 
+	// Check if the files were changed locally as well
+	if len(download) > 0 {
+		var err error
+		download, err = d.filterConflicts(download)
+		if err != nil {
+			return errors.Wrap(err, "resolve conflicts")
+		}
+	}
+
 	// Extract downloaded archive
 	if len(download) > 0 {
 		for i := 0; i < syncRetries; i++ {
@@ -258,6 +268,7 @@ func (d *downstream) applyChanges(changes []*remote.Change, force bool) error {
 			}
 
 			d.sync.log.Infof("Downstream - Retry download because of error: %v", err)
+			d.sync.recordRetry()
 
 			download = d.updateDownloadChanges(download)
 			if len(download) == 0 {
@@ -266,6 +277,10 @@ func (d *downstream) applyChanges(changes []*remote.Change, force bool) error {
 		}
 	}
 
+	if len(download) > 0 {
+		d.sync.recordDownload(len(download))
+	}
+
 	d.sync.log.Infof("Downstream - Successfully processed %d change(s)", len(changes))
 	return nil
 }
@@ -369,6 +384,7 @@ func (d *downstream) downloadFiles(writer io.WriteCloser, changes []*remote.Chan
 	for {
 		chunk, err := downloadClient.Recv()
 		if chunk != nil {
+			d.sync.recordTransfer(0, int64(len(chunk.Content)))
 			_, err := writer.Write(chunk.Content)
 			if err != nil {
 				// this means the tar is done already, so we just exit here
@@ -403,9 +419,11 @@ func (d *downstream) remove(remove []*remote.Change, force bool) {
 		d.sync.log.Infof("Downstream - Remove %d files", numRemoveFiles)
 	}
 
+	removed := 0
 	for _, change := range remove {
 		absFilepath := filepath.Join(d.sync.LocalPath, change.Path)
 		if shouldRemoveLocal(absFilepath, parseFileInformation(change), d.sync, force) {
+			removed++
 			if numRemoveFiles <= 3 || d.sync.Options.Verbose {
 				d.sync.log.Infof("Downstream - Remove '.%s'", change.Path)
 			}
@@ -424,6 +442,10 @@ func (d *downstream) remove(remove []*remote.Change, force bool) {
 
 		delete(fileMap, change.Path)
 	}
+
+	if removed > 0 {
+		d.sync.recordDeletes(removed, false)
+	}
 }
 
 func (d *downstream) deleteSafeRecursive(relativePath string, deleteChanges []*remote.Change, force bool) {
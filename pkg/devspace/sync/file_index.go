@@ -5,14 +5,21 @@ import (
 	"sync"
 )
 
+// fileIndex holds the last synced version of every path, which is the common
+// ancestor of the local and remote version of a path
 type fileIndex struct {
 	fileMap      map[string]*FileInformation
 	fileMapMutex sync.Mutex
+
+	// conflicts are conflicts that were resolved by one side of the sync
+	// and are expected to be seen by the other side as well
+	conflicts map[string]conflictVersions
 }
 
 func newFileIndex() *fileIndex {
 	return &fileIndex{
-		fileMap: make(map[string]*FileInformation),
+		fileMap:   make(map[string]*FileInformation),
+		conflicts: make(map[string]conflictVersions),
 	}
 }
 
@@ -31,9 +31,10 @@ type initialSyncOptions struct {
 	DownstreamDisabled bool
 	FileIndex          *fileIndex
 
-	ApplyRemote func(changes []*FileInformation, remove bool)
-	ApplyLocal  func(changes []*remote.Change, force bool) error
-	AddSymlink  func(relativePath, absPath string) (os.FileInfo, error)
+	ApplyRemote      func(changes []*FileInformation, remove bool)
+	ApplyLocal       func(changes []*remote.Change, force bool) error
+	AddSymlink       func(relativePath, absPath string) (os.FileInfo, error)
+	CompareChecksums func(files []*FileInformation) ([]bool, error)
 
 	UpstreamDone   func()
 	DownstreamDone func()
@@ -192,6 +193,7 @@ func (i *initialSyncer) CalculateDelta(remoteState map[string]*FileInformation,
 
 func (i *initialSyncer) deltaState(remoteState map[string]*FileInformation, localState map[string]*FileInformation, strategy latest.InitialSyncStrategy) ([]*FileInformation, error) {
 	changes := make([]*FileInformation, 0, 1024)
+	compare := make([]*FileInformation, 0, 64)
 	for relativePath, stat := range localState {
 		absPath := path.Join(i.o.LocalPath, relativePath)
 		ignore := false
@@ -262,6 +264,33 @@ func (i *initialSyncer) deltaState(remoteState map[string]*FileInformation, loca
 				changes = append(changes, stat)
 			} else if action == noAction {
 				delete(remoteState, relativePath)
+			} else if action == compareAction {
+				compare = append(compare, stat)
+			}
+		}
+	}
+
+	// compare the contents of files that have the same size
+	if len(compare) > 0 {
+		i.o.Log.Debugf("Initial Sync - Compare checksums of %d files", len(compare))
+		equal, err := i.o.CompareChecksums(compare)
+		if err != nil {
+			return nil, errors.Wrap(err, "compare checksums")
+		}
+
+		i.o.FileIndex.Lock()
+		defer i.o.FileIndex.Unlock()
+		for idx, stat := range compare {
+			action := noAction
+			if !equal[idx] {
+				action = i.decideConflict(stat, strategy)
+			}
+
+			if action == uploadAction {
+				delete(remoteState, stat.Name)
+				changes = append(changes, stat)
+			} else if action == noAction {
+				delete(remoteState, stat.Name)
 			}
 		}
 	}
@@ -384,6 +413,7 @@ const (
 	uploadAction   action = iota
 	downloadAction action = iota
 	noAction       action = iota
+	compareAction  action = iota
 )
 
 func (i *initialSyncer) decide(fileInformation *FileInformation, strategy latest.InitialSyncStrategy) action {
@@ -422,30 +452,44 @@ func (i *initialSyncer) decide(fileInformation *FileInformation, strategy latest
 				return uploadAction
 			}
 
-			if fileInformation.Mtime == i.o.FileIndex.fileMap[fileInformation.Name].Mtime {
+			if i.o.CompareBy == latest.InitialSyncCompareByChecksum && i.o.CompareChecksums != nil {
+				if strategy == latest.InitialSyncStrategyKeepAll {
+					return noAction
+				}
+
+				return compareAction
+			} else if fileInformation.Mtime == i.o.FileIndex.fileMap[fileInformation.Name].Mtime {
 				return noAction
 			} else if i.o.CompareBy == latest.InitialSyncCompareBySize {
 				return noAction
 			}
 		}
 
-		// Okay we have a conflict so now we decide based on the given strategy
-		switch strategy {
-		case latest.InitialSyncStrategyPreferLocal:
+		return i.decideConflict(fileInformation, strategy)
+	}
+
+	return uploadAction
+}
+
+// decideConflict decides based on the given strategy what to do with a file that differs between local and remote.
+// i.o.FileIndex needs to be locked before this function is called
+func (i *initialSyncer) decideConflict(fileInformation *FileInformation, strategy latest.InitialSyncStrategy) action {
+	// Okay we have a conflict so now we decide based on the given strategy
+	switch strategy {
+	case latest.InitialSyncStrategyPreferLocal:
+		return uploadAction
+	case latest.InitialSyncStrategyPreferRemote:
+		return downloadAction
+	case latest.InitialSyncStrategyPreferNewest:
+		if fileInformation.Mtime == i.o.FileIndex.fileMap[fileInformation.Name].Mtime {
+			return noAction
+		} else if fileInformation.Mtime > i.o.FileIndex.fileMap[fileInformation.Name].Mtime {
 			return uploadAction
-		case latest.InitialSyncStrategyPreferRemote:
+		} else {
 			return downloadAction
-		case latest.InitialSyncStrategyPreferNewest:
-			if fileInformation.Mtime == i.o.FileIndex.fileMap[fileInformation.Name].Mtime {
-				return noAction
-			} else if fileInformation.Mtime > i.o.FileIndex.fileMap[fileInformation.Name].Mtime {
-				return uploadAction
-			} else {
-				return downloadAction
-			}
-		case latest.InitialSyncStrategyKeepAll:
-			return noAction
 		}
+	case latest.InitialSyncStrategyKeepAll:
+		return noAction
 	}
 
 	return uploadAction
@@ -0,0 +1,158 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/pkg/errors"
+)
+
+// syncState is the last known state of a sync that is saved when the sync stops
+type syncState struct {
+	// Identity is the identity of the container filesystem the state was saved for
+	Identity string `json:"identity"`
+
+	// Files is the file index of the sync
+	Files map[string]*FileInformation `json:"files"`
+}
+
+// loadState retrieves the identity of the container filesystem and loads the state of the
+// last session. If there is no state or it was saved for another filesystem, nil is returned.
+func (s *Sync) loadState() (*syncState, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, time.Minute)
+	defer cancel()
+
+	identity, err := s.downstream.client.Identity(ctx, &remote.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieve filesystem identity")
+	} else if identity.Id == "" {
+		return nil, nil
+	}
+
+	s.stateMutex.Lock()
+	s.identity = identity.Id
+	s.stateMutex.Unlock()
+
+	out, err := os.ReadFile(s.Options.StateFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "read state")
+	}
+
+	// a state can only be used once, because the file index is out of date
+	// as soon as the resumed sync changes something
+	err = os.Remove(s.Options.StateFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "remove state")
+	}
+
+	state := &syncState{}
+	err = json.Unmarshal(out, state)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse state")
+	} else if state.Identity != identity.Id || state.Files == nil {
+		s.log.Debugf("Initial Sync - Container filesystem has changed since the last session")
+		return nil, nil
+	}
+
+	return state, nil
+}
+
+// saveState saves the current file index, if the initial sync has completed
+func (s *Sync) saveState() error {
+	if s.Options.StateFile == "" || s.upstream == nil || s.upstream.IsInitialSyncing() {
+		return nil
+	}
+
+	s.stateMutex.Lock()
+	identity := s.identity
+	initialSyncCompleted := s.initialSyncCompleted
+	s.stateMutex.Unlock()
+	if identity == "" || !initialSyncCompleted {
+		return nil
+	}
+
+	s.fileIndex.fileMapMutex.Lock()
+	out, err := json.Marshal(&syncState{
+		Identity: identity,
+		Files:    s.fileIndex.fileMap,
+	})
+	s.fileIndex.fileMapMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(s.Options.StateFile), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so that we never leave a partial state behind
+	tempFile := s.Options.StateFile + ".tmp"
+	err = os.WriteFile(tempFile, out, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile, s.Options.StateFile)
+}
+
+// resumeSync restores the file index from the given state and only reconciles the paths that
+// were created, changed or removed locally since the state was saved. Changes in the container
+// are picked up by the downstream afterwards, as it compares the complete remote tree against
+// the restored file index.
+func (s *Sync) resumeSync(initialSync *initialSyncer, state *syncState) error {
+	localState := make(map[string]*FileInformation)
+	err := initialSync.CalculateLocalState(s.LocalPath, localState, false)
+	if err != nil {
+		return err
+	}
+
+	s.fileIndex.fileMapMutex.Lock()
+	s.fileIndex.fileMap = state.Files
+
+	changed := 0
+	remoteState := make(map[string]*FileInformation)
+	changedState := make(map[string]*FileInformation)
+	for key, element := range localState {
+		saved := s.fileIndex.fileMap[key]
+		if saved != nil && !element.ResolvedLink && !changedSinceState(element, saved) {
+			continue
+		}
+
+		changed++
+		changedState[key] = element
+		if saved != nil && s.isDownloadable(saved) {
+			remoteState[key] = saved
+		}
+	}
+	for key, element := range s.fileIndex.fileMap {
+		if localState[key] != nil || !s.isDownloadable(element) {
+			continue
+		} else if s.uploadIgnoreMatcher != nil && s.uploadIgnoreMatcher.Matches(key, element.IsDirectory) {
+			continue
+		}
+
+		changed++
+		remoteState[key] = element
+	}
+	s.fileIndex.fileMapMutex.Unlock()
+
+	s.log.Infof("Resume sync from last session (%d path(s) changed locally)", changed)
+	return s.runInitialSync(initialSync, remoteState, changedState)
+}
+
+func changedSinceState(local *FileInformation, saved *FileInformation) bool {
+	if local.IsDirectory || saved.IsDirectory {
+		return local.IsDirectory != saved.IsDirectory
+	}
+
+	return local.Mtime != saved.Mtime || local.Size != saved.Size || !equalFilePermissions(local.Mode, saved.Mode)
+}
@@ -0,0 +1,138 @@
+package sync
+
+import (
+	"time"
+)
+
+// Stats holds the statistics of a sync
+type Stats struct {
+	// InitialSyncCompleted signals if the initial sync has finished
+	InitialSyncCompleted bool `json:"initialSyncCompleted"`
+
+	// PendingUploads is the amount of local changes that were not uploaded yet
+	PendingUploads int `json:"pendingUploads"`
+
+	// PendingDownloads is the amount of container changes that were not downloaded yet
+	PendingDownloads int64 `json:"pendingDownloads"`
+
+	// Uploads is the amount of files and folders that were uploaded
+	Uploads int64 `json:"uploads"`
+
+	// Downloads is the amount of files and folders that were downloaded
+	Downloads int64 `json:"downloads"`
+
+	// Deletes is the amount of paths that were removed locally or in the container
+	Deletes int64 `json:"deletes"`
+
+	// Retries is the amount of uploads and downloads that were retried
+	Retries int64 `json:"retries"`
+
+	// BytesUploaded is the amount of bytes that were sent to the container
+	BytesUploaded int64 `json:"bytesUploaded"`
+
+	// BytesDownloaded is the amount of bytes that were received from the container
+	BytesDownloaded int64 `json:"bytesDownloaded"`
+
+	// LastUpload is the time of the last upload or remote delete
+	LastUpload *time.Time `json:"lastUpload,omitempty"`
+
+	// LastDownload is the time of the last download or local delete
+	LastDownload *time.Time `json:"lastDownload,omitempty"`
+
+	// LastError is the last error that stopped the sync
+	LastError string `json:"lastError,omitempty"`
+
+	// LastErrorTime is the time of the last error
+	LastErrorTime *time.Time `json:"lastErrorTime,omitempty"`
+}
+
+// LastActivity returns the time of the last upload or download
+func (s *Stats) LastActivity() *time.Time {
+	if s.LastUpload == nil || (s.LastDownload != nil && s.LastDownload.After(*s.LastUpload)) {
+		return s.LastDownload
+	}
+
+	return s.LastUpload
+}
+
+// Stats returns a snapshot of the current statistics of the sync
+func (s *Sync) Stats() Stats {
+	s.statsMutex.Lock()
+	stats := s.stats
+	s.statsMutex.Unlock()
+
+	s.stateMutex.Lock()
+	stats.InitialSyncCompleted = s.initialSyncCompleted
+	s.stateMutex.Unlock()
+
+	if s.upstream != nil {
+		s.upstream.eventBufferMutex.Lock()
+		stats.PendingUploads = len(s.upstream.events) + len(s.upstream.eventBuffer)
+		s.upstream.eventBufferMutex.Unlock()
+	}
+
+	return stats
+}
+
+// updateStats updates the statistics of the sync with the given function
+func (s *Sync) updateStats(update func(stats *Stats)) {
+	s.statsMutex.Lock()
+	defer s.statsMutex.Unlock()
+
+	update(&s.stats)
+}
+
+func (s *Sync) recordUpload(files int) {
+	now := time.Now()
+	s.updateStats(func(stats *Stats) {
+		stats.Uploads += int64(files)
+		stats.LastUpload = &now
+	})
+}
+
+func (s *Sync) recordDownload(files int) {
+	now := time.Now()
+	s.updateStats(func(stats *Stats) {
+		stats.Downloads += int64(files)
+		stats.LastDownload = &now
+	})
+}
+
+func (s *Sync) recordTransfer(uploaded int64, downloaded int64) {
+	s.updateStats(func(stats *Stats) {
+		stats.BytesUploaded += uploaded
+		stats.BytesDownloaded += downloaded
+	})
+}
+
+func (s *Sync) recordDeletes(deletes int, upstream bool) {
+	now := time.Now()
+	s.updateStats(func(stats *Stats) {
+		stats.Deletes += int64(deletes)
+		if upstream {
+			stats.LastUpload = &now
+		} else {
+			stats.LastDownload = &now
+		}
+	})
+}
+
+func (s *Sync) recordRetry() {
+	s.updateStats(func(stats *Stats) {
+		stats.Retries++
+	})
+}
+
+func (s *Sync) recordError(err error) {
+	now := time.Now()
+	s.updateStats(func(stats *Stats) {
+		stats.LastError = err.Error()
+		stats.LastErrorTime = &now
+	})
+}
+
+func (s *Sync) recordPendingDownloads(amount int64) {
+	s.updateStats(func(stats *Stats) {
+		stats.PendingDownloads = amount
+	})
+}
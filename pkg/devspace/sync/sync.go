@@ -52,6 +52,14 @@ type Options struct {
 	InitialSyncCompareBy latest.InitialSyncCompareBy
 	InitialSync          latest.InitialSyncStrategy
 
+	ConflictStrategy latest.ConflictStrategy
+	OnConflict       func(conflict *Conflict)
+
+	// StateFile is the file where the file index is saved when the sync stops. If the
+	// container filesystem is still the same on the next start, the sync is resumed from
+	// this state instead of doing a complete initial sync.
+	StateFile string
+
 	Starter DelayedContainerStarter
 
 	Log log.Logger
@@ -77,6 +85,15 @@ type Sync struct {
 	upstream   *upstream
 	downstream *downstream
 
+	// stateMutex protects identity and initialSyncCompleted, which
+	// are needed to save the state of the sync
+	stateMutex           sync.Mutex
+	identity             string
+	initialSyncCompleted bool
+
+	statsMutex sync.Mutex
+	stats      Stats
+
 	stopOnce sync.Once
 
 	onError chan error
@@ -288,10 +305,11 @@ func (s *Sync) initialSync(onInitUploadDone chan struct{}, onInitDownloadDone ch
 		DownstreamDisabled: s.Options.DownstreamDisabled,
 		FileIndex:          s.fileIndex,
 
-		ApplyRemote: s.sendChangesToUpstream,
-		ApplyLocal:  s.downstream.applyChanges,
-		AddSymlink:  s.upstream.AddSymlink,
-		Log:         s.log,
+		ApplyRemote:      s.sendChangesToUpstream,
+		ApplyLocal:       s.downstream.applyChanges,
+		AddSymlink:       s.upstream.AddSymlink,
+		CompareChecksums: s.upstream.compareChecksums,
+		Log:              s.log,
 
 		UpstreamDone: func() {
 			if !s.Options.UpstreamDisabled {
@@ -325,6 +343,16 @@ func (s *Sync) initialSync(onInitUploadDone chan struct{}, onInitDownloadDone ch
 		},
 	})
 
+	// try to resume the sync from the last session
+	if s.Options.StateFile != "" {
+		state, err := s.loadState()
+		if err != nil {
+			s.log.Debugf("Initial Sync - Cannot resume sync: %v", err)
+		} else if state != nil {
+			return s.resumeSync(initialSync, state)
+		}
+	}
+
 	s.log.Debugf("Initial Sync - Retrieve Initial State")
 	errChan := make(chan error)
 	go func() {
@@ -347,10 +375,7 @@ func (s *Sync) initialSync(onInitUploadDone chan struct{}, onInitDownloadDone ch
 	downloadChanges := make(map[string]*FileInformation)
 	s.fileIndex.fileMapMutex.Lock()
 	for key, element := range s.fileIndex.fileMap {
-		if s.downloadIgnoreMatcher != nil && s.downloadIgnoreMatcher.Matches(element.Name, element.IsDirectory) {
-			continue
-		}
-		if element.IsSymbolicLink {
+		if !s.isDownloadable(element) {
 			continue
 		}
 
@@ -358,7 +383,27 @@ func (s *Sync) initialSync(onInitUploadDone chan struct{}, onInitDownloadDone ch
 	}
 	s.fileIndex.fileMapMutex.Unlock()
 
-	return initialSync.Run(downloadChanges, localState)
+	return s.runInitialSync(initialSync, downloadChanges, localState)
+}
+
+func (s *Sync) runInitialSync(initialSync *initialSyncer, remoteState map[string]*FileInformation, localState map[string]*FileInformation) error {
+	err := initialSync.Run(remoteState, localState)
+	if err != nil {
+		return err
+	}
+
+	s.stateMutex.Lock()
+	s.initialSyncCompleted = true
+	s.stateMutex.Unlock()
+	return nil
+}
+
+func (s *Sync) isDownloadable(element *FileInformation) bool {
+	if s.downloadIgnoreMatcher != nil && s.downloadIgnoreMatcher.Matches(element.Name, element.IsDirectory) {
+		return false
+	}
+
+	return !element.IsSymbolicLink
 }
 
 func (s *Sync) sendChangesToUpstream(changes []*FileInformation, remove bool) {
@@ -373,7 +418,7 @@ func (s *Sync) sendChangesToUpstream(changes []*FileInformation, remove bool) {
 		s.fileIndex.fileMapMutex.Lock()
 
 		for i := j; i < (j+initialUpstreamBatchSize) && i < len(changes); i++ {
-			if remove {
+			if remove || s.Options.InitialSyncCompareBy == latest.InitialSyncCompareByChecksum {
 				sendBatch = append(sendBatch, changes[i])
 			} else if s.fileIndex.fileMap[changes[i].Name] == nil || !equalFilePermissions(changes[i].Mode, s.fileIndex.fileMap[changes[i].Name].Mode) || changes[i].Mtime != s.fileIndex.fileMap[changes[i].Name].Mtime || changes[i].Size != s.fileIndex.fileMap[changes[i].Name].Size {
 				sendBatch = append(sendBatch, changes[i])
@@ -426,7 +471,13 @@ func (s *Sync) Stop(fatalError error) {
 			}
 		}
 
+		err := s.saveState()
+		if err != nil {
+			s.log.Debugf("Error saving sync state: %v", err)
+		}
+
 		if fatalError != nil {
+			s.recordError(fatalError)
 			s.Error(fatalError)
 
 			// This needs to be rethought because we do not always kill the application here, would be better to have an error channel
@@ -4,13 +4,16 @@
 package sync
 
 import (
+	"bytes"
 	"context"
+	"math/rand"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	gosync "sync"
 	"testing"
 	"time"
 
@@ -19,6 +22,7 @@ import (
 	"github.com/loft-sh/devspace/helper/server"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 func initTestDirs(t *testing.T) (string, string, string) {
@@ -250,6 +254,15 @@ func TestNormalSync(t *testing.T) {
 
 	t.Log("Delete test is done")
 
+	stats := syncClient.Stats()
+	if stats.Uploads == 0 || stats.BytesUploaded == 0 || stats.LastUpload == nil {
+		t.Fatalf("Expected uploads in stats, got %#v", stats)
+	} else if stats.Downloads == 0 || stats.BytesDownloaded == 0 || stats.LastDownload == nil {
+		t.Fatalf("Expected downloads in stats, got %#v", stats)
+	} else if stats.Deletes == 0 {
+		t.Fatalf("Expected deletes in stats, got %#v", stats)
+	}
+
 	filesToCheck, foldersToCheck, err = renameSomeTestFilesAndFolders(local, remote, outside, filesToCheck, foldersToCheck)
 	if err != nil {
 		t.Error(err)
@@ -754,3 +767,488 @@ func TestRemoveDirInFileMap(t *testing.T) {
 		t.Fatal("Remove dir in file map failed!")
 	}
 }
+
+type lockedBuffer struct {
+	m   gosync.Mutex
+	buf bytes.Buffer
+}
+
+func (l *lockedBuffer) Write(p []byte) (int, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	return l.buf.Write(p)
+}
+
+func (l *lockedBuffer) String() string {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	return l.buf.String()
+}
+
+func TestChecksumSync(t *testing.T) {
+	remote, local, _ := initTestDirs(t)
+
+	// Create the files that already exist in the container
+	bigContent := make([]byte, 512*1024)
+	_, _ = rand.New(rand.NewSource(1)).Read(bigContent)
+	sameContent := []byte("this file has the same contents locally and remote")
+	err := os.WriteFile(filepath.Join(remote, "big.bin"), bigContent, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(remote, "same.txt"), sameContent, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remoteMtime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	err = os.Chtimes(filepath.Join(remote, "same.txt"), remoteMtime, remoteMtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Locally a part of the big file was rewritten and the other file only got touched
+	localContent := make([]byte, len(bigContent))
+	copy(localContent, bigContent)
+	copy(localContent[200*1024:], bytes.Repeat([]byte("local"), 1024))
+	err = os.WriteFile(filepath.Join(local, "big.bin"), localContent, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(local, "same.txt"), sameContent, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := &lockedBuffer{}
+	options := getSyncOptions(nil)
+	options.InitialSync = latest.InitialSyncStrategyPreferLocal
+	options.InitialSyncCompareBy = latest.InitialSyncCompareByChecksum
+	options.Log = log.NewStreamLogger(out, out, logrus.InfoLevel)
+	syncClient, err := NewSync(context.Background(), local, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	syncClient.onError = make(chan error)
+	defer syncClient.Stop(nil)
+
+	// Start the downstream server
+	downClientReader, downClientWriter, _ := os.Pipe()
+	downServerReader, downServerWriter, _ := os.Pipe()
+	defer downClientReader.Close()
+	defer downClientWriter.Close()
+	defer downServerReader.Close()
+	defer downServerWriter.Close()
+
+	go func() {
+		err := server.StartDownstreamServer(downServerReader, downClientWriter, &server.DownstreamOptions{
+			RemotePath:   remote,
+			ExcludePaths: syncClient.Options.ExcludePaths,
+			ExitOnClose:  false,
+		})
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	err = syncClient.InitDownstream(downClientReader, downServerWriter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Start upstream server
+	upClientReader, upClientWriter, _ := os.Pipe()
+	upServerReader, upServerWriter, _ := os.Pipe()
+	defer upClientReader.Close()
+	defer upClientWriter.Close()
+	defer upServerReader.Close()
+	defer upServerWriter.Close()
+
+	go func() {
+		err := server.StartUpstreamServer(upServerReader, upClientWriter, &server.UpstreamOptions{
+			UploadPath:  remote,
+			ExludePaths: []string{},
+			ExitOnClose: false,
+		})
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	err = syncClient.InitUpstream(upClientReader, upServerWriter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	syncClient.readyChan = make(chan bool)
+	go syncClient.startUpstream()
+	<-syncClient.readyChan
+
+	// Do initial sync
+	err = syncClient.initialSync(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = waitForRemoteContent(filepath.Join(remote, "big.bin"), localContent, 15*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Files with equal contents should not be uploaded
+	stat, err := os.Stat(filepath.Join(remote, "same.txt"))
+	if err != nil {
+		t.Fatal(err)
+	} else if !stat.ModTime().Equal(remoteMtime) {
+		t.Fatalf("Expected same.txt to be untouched, but mtime changed from %v to %v", remoteMtime, stat.ModTime())
+	}
+
+	// Rewrite a part of the file and append some data
+	time.Sleep(time.Second)
+	copy(localContent[10*1024:], bytes.Repeat([]byte("changed"), 512))
+	localContent = append(localContent, []byte("appended data")...)
+	err = os.WriteFile(filepath.Join(local, "big.bin"), localContent, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = waitForRemoteContent(filepath.Join(remote, "big.bin"), localContent, 15*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Count(out.String(), "Patch File 'big.bin'") != 2 {
+		t.Fatalf("Expected big.bin to be patched twice, but got logs: %s", out.String())
+	}
+}
+
+func waitForRemoteContent(remotePath string, expected []byte, timeout time.Duration) error {
+	var (
+		content []byte
+		err     error
+	)
+
+	for start := time.Now(); time.Since(start) < timeout; time.Sleep(100 * time.Millisecond) {
+		content, err = os.ReadFile(remotePath)
+		if err == nil && bytes.Equal(content, expected) {
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return errors.Errorf("remote file %s has unexpected content (size %d, expected %d)", remotePath, len(content), len(expected))
+}
+
+func TestResumeSync(t *testing.T) {
+	remote, local, outside := initTestDirs(t)
+	stateFile := filepath.Join(outside, "state.json")
+
+	for _, name := range []string{"changed.txt", "removed.txt", "same.txt"} {
+		err := os.WriteFile(filepath.Join(local, name), []byte("initial "+name), 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Do the initial sync and stop the sync afterwards, which should save the state
+	out := &lockedBuffer{}
+	syncClient := startResumeTestSync(t, local, remote, stateFile, out)
+	for _, name := range []string{"changed.txt", "removed.txt", "same.txt"} {
+		err := waitForRemoteContent(filepath.Join(remote, name), []byte("initial "+name), 15*time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for syncClient.upstream.IsInitialSyncing() {
+		time.Sleep(100 * time.Millisecond)
+	}
+	syncClient.Stop(nil)
+
+	_, err := os.Stat(stateFile)
+	if err != nil {
+		t.Fatalf("Expected sync state to be saved: %v", err)
+	}
+
+	// Change the local files while the sync is not running
+	err = os.WriteFile(filepath.Join(local, "changed.txt"), []byte("changed while stopped"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changedMtime := time.Now().Add(time.Hour)
+	err = os.Chtimes(filepath.Join(local, "changed.txt"), changedMtime, changedMtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Remove(filepath.Join(local, "removed.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Resume the sync, which should only reconcile the local changes
+	out = &lockedBuffer{}
+	syncClient = startResumeTestSync(t, local, remote, stateFile, out)
+	defer syncClient.Stop(nil)
+
+	err = waitForRemoteContent(filepath.Join(remote, "changed.txt"), []byte("changed while stopped"), 15*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for start := time.Now(); ; time.Sleep(100 * time.Millisecond) {
+		_, err = os.Stat(filepath.Join(remote, "removed.txt"))
+		if os.IsNotExist(err) {
+			break
+		} else if time.Since(start) > 15*time.Second {
+			t.Fatal("Expected removed.txt to be removed in the container")
+		}
+	}
+
+	logs := out.String()
+	if !strings.Contains(logs, "Resume sync from last session (2 path(s) changed locally)") {
+		t.Fatalf("Expected sync to be resumed, but got logs: %s", logs)
+	} else if strings.Contains(logs, "same.txt") {
+		t.Fatalf("Expected same.txt to be skipped, but got logs: %s", logs)
+	}
+}
+
+func startResumeTestSync(t *testing.T, local, remote, stateFile string, out *lockedBuffer) *Sync {
+	options := getSyncOptions(nil)
+	options.StateFile = stateFile
+	options.Log = log.NewStreamLogger(out, out, logrus.DebugLevel)
+	return startTestSync(t, local, remote, options)
+}
+
+func startTestSync(t *testing.T, local, remote string, options Options) *Sync {
+	syncClient, err := NewSync(context.Background(), local, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	syncClient.onError = make(chan error)
+
+	// Start the downstream server
+	downClientReader, downClientWriter, _ := os.Pipe()
+	downServerReader, downServerWriter, _ := os.Pipe()
+	t.Cleanup(func() {
+		downClientReader.Close()
+		downClientWriter.Close()
+		downServerReader.Close()
+		downServerWriter.Close()
+	})
+
+	go func() {
+		_ = server.StartDownstreamServer(downServerReader, downClientWriter, &server.DownstreamOptions{
+			RemotePath:   remote,
+			ExcludePaths: syncClient.Options.ExcludePaths,
+			ExitOnClose:  false,
+		})
+	}()
+
+	err = syncClient.InitDownstream(downClientReader, downServerWriter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Start upstream server
+	upClientReader, upClientWriter, _ := os.Pipe()
+	upServerReader, upServerWriter, _ := os.Pipe()
+	t.Cleanup(func() {
+		upClientReader.Close()
+		upClientWriter.Close()
+		upServerReader.Close()
+		upServerWriter.Close()
+	})
+
+	go func() {
+		_ = server.StartUpstreamServer(upServerReader, upClientWriter, &server.UpstreamOptions{
+			UploadPath:  remote,
+			ExludePaths: []string{},
+			ExitOnClose: false,
+		})
+	}()
+
+	err = syncClient.InitUpstream(upClientReader, upServerWriter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	syncClient.readyChan = make(chan bool)
+	go syncClient.startUpstream()
+	<-syncClient.readyChan
+
+	err = syncClient.initialSync(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return syncClient
+}
+
+type conflictSyncTestCase struct {
+	strategy latest.ConflictStrategy
+
+	expectedResolution    ConflictResolution
+	expectedLocalContent  string
+	expectedRemoteContent string
+	expectedLocalCopy     bool
+	expectedError         string
+}
+
+func TestConflictSync(t *testing.T) {
+	testCases := []conflictSyncTestCase{
+		{
+			strategy:              latest.ConflictStrategyKeepBoth,
+			expectedResolution:    ConflictResolutionKeptBoth,
+			expectedLocalContent:  "changed in container",
+			expectedRemoteContent: "changed in container",
+			expectedLocalCopy:     true,
+		},
+		{
+			strategy:              latest.ConflictStrategyPreferRemote,
+			expectedResolution:    ConflictResolutionKeptRemote,
+			expectedLocalContent:  "changed in container",
+			expectedRemoteContent: "changed in container",
+		},
+		{
+			strategy:              latest.ConflictStrategyPreferLocal,
+			expectedResolution:    ConflictResolutionKeptLocal,
+			expectedLocalContent:  "changed locally",
+			expectedRemoteContent: "changed locally",
+		},
+		{
+			strategy:              latest.ConflictStrategyFail,
+			expectedResolution:    ConflictResolutionFailed,
+			expectedLocalContent:  "changed locally",
+			expectedRemoteContent: "changed in container",
+			expectedError:         "conflict: '/file.txt' was changed locally and in the container",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(string(testCase.strategy), func(t *testing.T) {
+			testConflictSync(t, testCase)
+		})
+	}
+}
+
+func testConflictSync(t *testing.T, testCase conflictSyncTestCase) {
+	remote, local, outside := initTestDirs(t)
+
+	err := os.WriteFile(filepath.Join(local, "file.txt"), []byte("initial"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conflictsMutex := gosync.Mutex{}
+	conflicts := []*Conflict{}
+	out := &lockedBuffer{}
+	options := getSyncOptions(nil)
+	options.ConflictStrategy = testCase.strategy
+	options.Log = log.NewStreamLogger(out, out, logrus.DebugLevel)
+	options.OnConflict = func(conflict *Conflict) {
+		conflictsMutex.Lock()
+		defer conflictsMutex.Unlock()
+		conflicts = append(conflicts, conflict)
+	}
+
+	syncClient := startTestSync(t, local, remote, options)
+	defer syncClient.Stop(nil)
+
+	err = waitForRemoteContent(filepath.Join(remote, "file.txt"), []byte("initial"), 15*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for syncClient.upstream.IsInitialSyncing() {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Change the file in the container and afterwards locally, so that the upstream sees the conflict
+	err = os.WriteFile(filepath.Join(remote, "file.txt"), []byte("changed in container"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remoteMtime := time.Now().Add(time.Hour)
+	err = os.Chtimes(filepath.Join(remote, "file.txt"), remoteMtime, remoteMtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// write the local file outside and move it, so that the watcher only sees a single version
+	err = os.WriteFile(filepath.Join(outside, "file.txt"), []byte("changed locally"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localMtime := time.Now().Add(2 * time.Hour)
+	err = os.Chtimes(filepath.Join(outside, "file.txt"), localMtime, localMtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Rename(filepath.Join(outside, "file.txt"), filepath.Join(local, "file.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait until the upstream has reported the conflict
+	beforeTimeout := time.Now().Add(15 * time.Second)
+	for {
+		conflictsMutex.Lock()
+		reported := len(conflicts) > 0
+		conflictsMutex.Unlock()
+		if reported {
+			break
+		} else if time.Now().After(beforeTimeout) {
+			t.Fatalf("Timeout waiting for the conflict: %s", out.String())
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if testCase.expectedError != "" {
+		// the sync is stopped and neither side is changed
+		select {
+		case err = <-syncClient.onError:
+			if !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Fatalf("Unexpected sync error %v", err)
+			}
+		case <-time.After(15 * time.Second):
+			t.Fatal("Timeout waiting for the sync to stop")
+		}
+	} else {
+		// the downstream should resolve the conflict without reporting it again
+		go syncClient.startDownstream()
+	}
+
+	if testCase.expectedLocalCopy {
+		err = waitForRemoteContent(filepath.Join(local, "file.txt"+ConflictSuffix), []byte("changed locally"), 15*time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		_, err = os.Stat(filepath.Join(local, "file.txt"+ConflictSuffix))
+		if !os.IsNotExist(err) {
+			t.Fatalf("Expected no local copy of the conflicting file, but got %v", err)
+		}
+	}
+
+	err = waitForRemoteContent(filepath.Join(local, "file.txt"), []byte(testCase.expectedLocalContent), 15*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = waitForRemoteContent(filepath.Join(remote, "file.txt"), []byte(testCase.expectedRemoteContent), 15*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// give the downstream the chance to report the conflict a second time
+	time.Sleep(time.Second)
+	conflictsMutex.Lock()
+	defer conflictsMutex.Unlock()
+	if len(conflicts) != 1 {
+		t.Fatalf("Expected exactly 1 conflict, but got %d: %s", len(conflicts), out.String())
+	} else if conflicts[0].Path != "/file.txt" || conflicts[0].Strategy != testCase.strategy || conflicts[0].Resolution != testCase.expectedResolution {
+		t.Fatalf("Unexpected conflict %#v", conflicts[0])
+	} else if !strings.Contains(out.String(), "Conflict - '/file.txt' was changed locally and in the container, resolved with strategy "+string(testCase.strategy)) {
+		t.Fatalf("Expected conflict to be logged, but got logs: %s", out.String())
+	}
+}
@@ -24,6 +24,10 @@ type Unarchiver struct {
 	syncConfig    *Sync
 	forceOverride bool
 
+	// overridePaths are paths that should be overridden even if the local file is newer,
+	// because a conflict was resolved in favor of the remote file
+	overridePaths map[string]bool
+
 	log log.Logger
 }
 
@@ -32,6 +36,7 @@ func NewUnarchiver(syncConfig *Sync, forceOverride bool, log log.Logger) *Unarch
 	return &Unarchiver{
 		syncConfig:    syncConfig,
 		forceOverride: forceOverride,
+		overridePaths: map[string]bool{},
 		log:           log,
 	}
 }
@@ -83,7 +88,7 @@ func (u *Unarchiver) untarNext(destPath string, tarReader *tar.Reader) (bool, er
 
 	// Check if newer file is there and then don't override?
 	stat, err := os.Stat(outFileName)
-	if err == nil && !u.forceOverride {
+	if err == nil && !u.forceOverride && !u.overridePaths[relativePath] {
 		if stat.ModTime().Unix() > header.FileInfo().ModTime().Unix() {
 			// Update filemap otherwise we download and download again
 			u.syncConfig.fileIndex.fileMap[relativePath] = &FileInformation{
@@ -153,6 +158,7 @@ func (u *Unarchiver) untarNext(destPath string, tarReader *tar.Reader) (bool, er
 	_ = os.Chtimes(outFileName, time.Now(), header.ModTime)
 
 	// Update fileMap so that upstream does not upload the file
+	delete(u.overridePaths, relativePath)
 	u.syncConfig.fileIndex.fileMap[relativePath] = &FileInformation{
 		Name:        relativePath,
 		Mtime:       header.ModTime.Unix(),
@@ -57,6 +57,10 @@ type upstream struct {
 	initialSyncChanges        []string
 	initialSyncCompleted      bool
 	initialSyncTouchOnce      sync.Once
+
+	// statUnsupported is true if the helper is too old to stat files,
+	// which is needed to detect conflicts
+	statUnsupported bool
 }
 
 const (
@@ -675,9 +679,12 @@ func (u *upstream) applyChanges(changes []*FileInformation) error {
 					return nil, errors.Wrap(err, "apply creates")
 				} else if strings.Contains(err.Error(), "closed pipe") || strings.Contains(err.Error(), "transport is closing") || strings.Contains(err.Error(), "broken pipe") {
 					return nil, errors.Wrap(err, "apply creates")
+				} else if _, ok := errors.Cause(err).(*ConflictError); ok {
+					return nil, errors.Wrap(err, "apply creates")
 				}
 
 				u.sync.log.Infof("Upstream - Retry upload because of error: %v", err)
+				u.sync.recordRetry()
 				creates = u.updateUploadChanges(creates)
 				if len(creates) == 0 {
 					break
@@ -696,6 +703,10 @@ func (u *upstream) applyChanges(changes []*FileInformation) error {
 		return nil
 	}
 
+	if len(writtenChanges) > 0 {
+		u.sync.recordUpload(len(writtenChanges))
+	}
+
 	u.sync.log.Infof("Upstream - Successfully processed %d change(s)", changeAmount)
 	changeNames := make([]string, 0, changeAmount)
 	for _, c := range removes {
@@ -764,6 +775,39 @@ func (u *upstream) applyCreates(files []*FileInformation) (map[string]*FileInfor
 		return nil, nil
 	}
 
+	// check if the files were changed in the container as well
+	files, err = u.filterConflicts(files)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve conflicts")
+	} else if len(files) == 0 {
+		return nil, nil
+	}
+
+	// upload changed files as block deltas if we compare by checksum
+	writtenFiles := map[string]*FileInformation{}
+	if u.sync.Options.InitialSyncCompareBy == latest.InitialSyncCompareByChecksum {
+		var patchedFiles []*FileInformation
+		files, patchedFiles, err = u.patchFiles(files)
+		if err != nil {
+			return nil, errors.Wrap(err, "patch files")
+		}
+
+		for _, element := range patchedFiles {
+			if u.sync.Options.Verbose || len(patchedFiles) <= 3 {
+				u.sync.log.Infof("Upstream - Patch File '%s'", u.getRelativeUpstreamPath(element.Name))
+			}
+
+			u.sync.fileIndex.fileMap[element.Name] = element
+			writtenFiles[element.Name] = element
+		}
+		if len(patchedFiles) > 0 {
+			u.sync.log.Infof("Upstream - Patched %d file(s)", len(patchedFiles))
+		}
+		if len(files) == 0 {
+			return writtenFiles, nil
+		}
+	}
+
 	size := int64(0)
 	for _, c := range files {
 		if c.IsDirectory {
@@ -812,9 +856,10 @@ func (u *upstream) applyCreates(files []*FileInformation) (map[string]*FileInfor
 	for _, element := range archiver.WrittenFiles() {
 		u.sync.fileIndex.CreateDirInFileMap(path.Dir(element.Name))
 		u.sync.fileIndex.fileMap[element.Name] = element
+		writtenFiles[element.Name] = element
 	}
 
-	return archiver.WrittenFiles(), nil
+	return writtenFiles, nil
 }
 
 func (u *upstream) filterChanges(files []*FileInformation) ([]*FileInformation, error) {
@@ -934,6 +979,43 @@ func (u *upstream) filterChanges(files []*FileInformation) ([]*FileInformation,
 	return newChanges, nil
 }
 
+// compareChecksums compares the crc32 checksums of the given local files with the ones
+// in the container and returns for each file if the contents are equal
+func (u *upstream) compareChecksums(files []*FileInformation) ([]bool, error) {
+	ctx, cancel := context.WithTimeout(u.sync.ctx, time.Minute*30)
+	defer cancel()
+
+	equal := make([]bool, 0, len(files))
+	batchSize := 1000
+	for i := 0; i < len(files); i += batchSize {
+		batch := make([]*remote.TouchPath, 0, batchSize)
+		for j := i; j < i+batchSize && j < len(files); j++ {
+			batch = append(batch, &remote.TouchPath{
+				Path: files[j].Name,
+			})
+		}
+
+		// ask remote for checksums
+		checksums, err := u.client.Checksums(ctx, &remote.TouchPaths{Paths: batch})
+		if err != nil {
+			return nil, errors.Wrap(err, "hashing remote files")
+		} else if checksums == nil || len(checksums.Checksums) != len(batch) {
+			return nil, fmt.Errorf("unexpected checksum response")
+		}
+
+		for j, remoteChecksum := range checksums.Checksums {
+			localChecksum, err := crc32.Checksum(path.Join(u.sync.LocalPath, files[i+j].Name))
+			if err != nil && !os.IsNotExist(err) {
+				u.sync.log.Infof("Error hashing file %s: %v", files[i+j].Name, err)
+			}
+
+			equal = append(equal, remoteChecksum != 0 && remoteChecksum == localChecksum)
+		}
+	}
+
+	return equal, nil
+}
+
 func (u *upstream) compress(writer io.WriteCloser, files []*FileInformation, ignoreMatcher ignoreparser.IgnoreParser) (*Archiver, error) {
 	defer writer.Close()
 
@@ -974,6 +1056,7 @@ func (u *upstream) uploadArchive(reader io.ReadCloser) error {
 	for {
 		n, err := reader.Read(buf)
 		if n > 0 {
+			u.sync.recordTransfer(int64(n), 0)
 			err := uploadClient.Send(&remote.Chunk{
 				Content: buf[:n],
 			})
@@ -1062,6 +1145,7 @@ func (u *upstream) applyRemoves(files []*FileInformation) error {
 		return errors.Wrap(err, "after deletes")
 	}
 
+	u.sync.recordDeletes(len(files), true)
 	return nil
 }
 
@@ -0,0 +1,245 @@
+package tracing
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// List of values that the trace format can take
+const (
+	FormatChrome = "chrome"
+	FormatOTLP   = "otlp"
+)
+
+// WriteFile writes the recorded spans in the given format to path
+func (r *Recorder) WriteFile(path, format string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "create trace file")
+	}
+	defer file.Close()
+
+	switch format {
+	case "", FormatChrome:
+		return r.WriteChromeTrace(file)
+	case FormatOTLP:
+		return r.WriteOTLP(file)
+	default:
+		return errors.Errorf("unsupported trace format %s, please use either %s or %s", format, FormatChrome, FormatOTLP)
+	}
+}
+
+type chromeTrace struct {
+	TraceEvents     []chromeEvent `json:"traceEvents"`
+	DisplayTimeUnit string        `json:"displayTimeUnit"`
+}
+
+type chromeEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat,omitempty"`
+	Phase     string            `json:"ph"`
+	Timestamp int64             `json:"ts"`
+	Duration  int64             `json:"dur"`
+	PID       int               `json:"pid"`
+	TID       int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+// WriteChromeTrace writes the spans in the chrome trace event format, which can be
+// opened with chrome://tracing or https://ui.perfetto.dev
+func (r *Recorder) WriteChromeTrace(out io.Writer) error {
+	spans := r.Spans()
+	lanes := assignLanes(spans)
+
+	trace := chromeTrace{
+		TraceEvents:     []chromeEvent{},
+		DisplayTimeUnit: "ms",
+	}
+	for i, span := range spans {
+		args := map[string]string{}
+		for k, v := range span.Attributes {
+			args[k] = v
+		}
+		if span.Error != "" {
+			args["error"] = span.Error
+		}
+
+		trace.TraceEvents = append(trace.TraceEvents, chromeEvent{
+			Name:      span.Name,
+			Category:  span.Category,
+			Phase:     "X",
+			Timestamp: span.StartTime.UnixMicro(),
+			Duration:  span.Duration().Microseconds(),
+			PID:       1,
+			TID:       lanes[i],
+			Args:      args,
+		})
+	}
+
+	return json.NewEncoder(out).Encode(trace)
+}
+
+// assignLanes distributes the spans sorted by start time across thread lanes, so that
+// spans on the same lane are always properly nested as required by the chrome trace viewer
+func assignLanes(spans []*Span) []int {
+	result := make([]int, len(spans))
+	lanes := [][]time.Time{}
+	for i, span := range spans {
+		assigned := false
+		for lane := range lanes {
+			// remove all spans from the lane that are already done
+			stack := lanes[lane]
+			for len(stack) > 0 && !stack[len(stack)-1].After(span.StartTime) {
+				stack = stack[:len(stack)-1]
+			}
+			lanes[lane] = stack
+
+			if len(stack) == 0 || !stack[len(stack)-1].Before(span.EndTime) {
+				lanes[lane] = append(stack, span.EndTime)
+				result[i] = lane + 1
+				assigned = true
+				break
+			}
+		}
+		if !assigned {
+			lanes = append(lanes, []time.Time{span.EndTime})
+			result[i] = len(lanes)
+		}
+	}
+
+	return result
+}
+
+type otlpTrace struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// WriteOTLP writes the spans as OTLP-JSON, which can be imported by OpenTelemetry compatible backends
+func (r *Recorder) WriteOTLP(out io.Writer) error {
+	spans := []otlpSpan{}
+	for _, span := range r.Spans() {
+		attributes := []otlpAttribute{
+			{Key: "devspace.category", Value: otlpValue{StringValue: span.Category}},
+		}
+		keys := make([]string, 0, len(span.Attributes))
+		for k := range span.Attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			attributes = append(attributes, otlpAttribute{Key: k, Value: otlpValue{StringValue: span.Attributes[k]}})
+		}
+
+		// status code 1 is ok, 2 is error
+		status := otlpStatus{Code: 1}
+		if span.Error != "" {
+			status = otlpStatus{Code: 2, Message: span.Error}
+		}
+
+		spans = append(spans, otlpSpan{
+			TraceID:           r.traceID,
+			SpanID:            span.ID,
+			ParentSpanID:      span.ParentID,
+			Name:              span.Name,
+			Kind:              1,
+			StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+			Attributes:        attributes,
+			Status:            status,
+		})
+	}
+
+	return json.NewEncoder(out).Encode(&otlpTrace{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpAttribute{
+						{Key: "service.name", Value: otlpValue{StringValue: "devspace"}},
+					},
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: "devspace"},
+						Spans: spans,
+					},
+				},
+			},
+		},
+	})
+}
+
+// PrintSummary prints a table with the n slowest steps of the run
+func (r *Recorder) PrintSummary(logger log.Logger, n int) {
+	slowest := r.Slowest(n)
+	if len(slowest) == 0 {
+		return
+	}
+
+	values := [][]string{}
+	for _, span := range slowest {
+		status := "ok"
+		if span.Error != "" {
+			status = "failed"
+		}
+
+		values = append(values, []string{
+			span.Name,
+			span.Category,
+			span.Duration().Round(time.Millisecond).String(),
+			status,
+		})
+	}
+
+	log.PrintTable(logger, []string{"Step", "Category", "Duration", "Status"}, values)
+}
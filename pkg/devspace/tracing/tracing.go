@@ -0,0 +1,193 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+)
+
+// List of span categories
+const (
+	CategoryConfig     = "config"
+	CategoryDependency = "dependency"
+	CategoryPipeline   = "pipeline"
+	CategoryBuild      = "build"
+	CategoryDeploy     = "deploy"
+)
+
+type spanKey struct{}
+
+// Span is a single timed step of a run
+type Span struct {
+	ID       string
+	ParentID string
+
+	Name     string
+	Category string
+
+	StartTime time.Time
+	EndTime   time.Time
+
+	Attributes map[string]string
+	Error      string
+
+	m        sync.Mutex
+	recorder *Recorder
+}
+
+// SetAttribute adds a key value pair to the span
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.Attributes == nil {
+		s.Attributes = map[string]string{}
+	}
+	s.Attributes[key] = value
+}
+
+// SetError marks the span as failed if err is not nil
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.Error = err.Error()
+}
+
+// End finishes the span and hands it to the recorder
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.m.Lock()
+	if !s.EndTime.IsZero() {
+		s.m.Unlock()
+		return
+	}
+	s.EndTime = time.Now()
+	s.m.Unlock()
+
+	s.recorder.add(s)
+}
+
+// Duration returns how long the span took
+func (s *Span) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
+
+// Recorder collects all finished spans of a run
+type Recorder struct {
+	m       sync.Mutex
+	traceID string
+	spans   []*Span
+}
+
+// NewRecorder creates a new empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		traceID: randomID(16),
+	}
+}
+
+// TraceID returns the id that is shared by all spans of the recorder
+func (r *Recorder) TraceID() string {
+	return r.traceID
+}
+
+// Spans returns the finished spans sorted by their start time
+func (r *Recorder) Spans() []*Span {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	spans := make([]*Span, len(r.spans))
+	copy(spans, r.spans)
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].StartTime.Equal(spans[j].StartTime) {
+			return spans[i].Duration() > spans[j].Duration()
+		}
+
+		return spans[i].StartTime.Before(spans[j].StartTime)
+	})
+	return spans
+}
+
+// Slowest returns the n finished spans that took the longest
+func (r *Recorder) Slowest(n int) []*Span {
+	spans := r.Spans()
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Duration() > spans[j].Duration()
+	})
+	if n >= 0 && len(spans) > n {
+		spans = spans[:n]
+	}
+
+	return spans
+}
+
+func (r *Recorder) add(span *Span) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.spans = append(r.spans, span)
+}
+
+var (
+	recorderMutex   sync.RWMutex
+	defaultRecorder *Recorder
+)
+
+// SetRecorder sets the global recorder, nil disables tracing
+func SetRecorder(recorder *Recorder) {
+	recorderMutex.Lock()
+	defer recorderMutex.Unlock()
+
+	defaultRecorder = recorder
+}
+
+// GetRecorder returns the global recorder or nil if tracing is disabled
+func GetRecorder() *Recorder {
+	recorderMutex.RLock()
+	defer recorderMutex.RUnlock()
+
+	return defaultRecorder
+}
+
+// Start starts a new span as child of the span in ctx. If tracing is disabled the returned
+// span is nil, which is safe to use.
+func Start(ctx context.Context, category, name string) (context.Context, *Span) {
+	recorder := GetRecorder()
+	if recorder == nil {
+		return ctx, nil
+	}
+
+	span := &Span{
+		ID:        randomID(8),
+		Name:      name,
+		Category:  category,
+		StartTime: time.Now(),
+		recorder:  recorder,
+	}
+	if parent, ok := ctx.Value(spanKey{}).(*Span); ok && parent.recorder == recorder {
+		span.ParentID = parent.ID
+	}
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func randomID(size int) string {
+	id := make([]byte, size)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
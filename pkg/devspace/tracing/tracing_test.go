@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestSpansWithoutRecorder(t *testing.T) {
+	SetRecorder(nil)
+	ctx, span := Start(context.Background(), CategoryBuild, "build app")
+	assert.Assert(t, span == nil)
+	assert.Equal(t, ctx, context.Background())
+
+	// nil spans must be safe to use
+	span.SetAttribute("image", "app")
+	span.SetError(nil)
+	span.End()
+}
+
+func TestSpanParents(t *testing.T) {
+	recorder := NewRecorder()
+	SetRecorder(recorder)
+	defer SetRecorder(nil)
+
+	ctx, parent := Start(context.Background(), CategoryPipeline, "deploy")
+	_, child := Start(ctx, CategoryDeploy, "deploy app")
+	child.End()
+	parent.End()
+	parent.End()
+
+	spans := recorder.Spans()
+	assert.Equal(t, len(spans), 2)
+	assert.Equal(t, spans[0].Name, "deploy")
+	assert.Equal(t, spans[0].ParentID, "")
+	assert.Equal(t, spans[1].ParentID, spans[0].ID)
+}
+
+func newSpan(name string, start, end int) *Span {
+	base := time.Unix(1000, 0)
+	return &Span{
+		ID:        name,
+		Name:      name,
+		StartTime: base.Add(time.Duration(start) * time.Millisecond),
+		EndTime:   base.Add(time.Duration(end) * time.Millisecond),
+	}
+}
+
+func TestAssignLanes(t *testing.T) {
+	spans := []*Span{
+		newSpan("pipeline", 0, 100),
+		newSpan("build a", 10, 50),
+		newSpan("build b", 20, 60),
+		newSpan("deploy", 70, 90),
+	}
+
+	// build b overlaps with build a and is not nested in it, so it needs its own lane
+	assert.DeepEqual(t, assignLanes(spans), []int{1, 1, 2, 1})
+}
+
+func TestSlowestAndExport(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.spans = []*Span{
+		newSpan("pipeline", 0, 100),
+		newSpan("build", 10, 50),
+		newSpan("deploy", 60, 90),
+	}
+	recorder.spans[2].Error = "failed"
+
+	slowest := recorder.Slowest(2)
+	assert.Equal(t, len(slowest), 2)
+	assert.Equal(t, slowest[0].Name, "pipeline")
+	assert.Equal(t, slowest[1].Name, "build")
+
+	chrome := &bytes.Buffer{}
+	assert.NilError(t, recorder.WriteChromeTrace(chrome))
+	trace := &chromeTrace{}
+	assert.NilError(t, json.Unmarshal(chrome.Bytes(), trace))
+	assert.Equal(t, len(trace.TraceEvents), 3)
+	assert.Equal(t, trace.TraceEvents[1].Duration, int64(40000))
+	assert.Equal(t, trace.TraceEvents[2].Args["error"], "failed")
+
+	otlp := &bytes.Buffer{}
+	assert.NilError(t, recorder.WriteOTLP(otlp))
+	otlpOut := &otlpTrace{}
+	assert.NilError(t, json.Unmarshal(otlp.Bytes(), otlpOut))
+	spans := otlpOut.ResourceSpans[0].ScopeSpans[0].Spans
+	assert.Equal(t, len(spans), 3)
+	assert.Equal(t, spans[0].TraceID, recorder.TraceID())
+	assert.Equal(t, spans[0].StartTimeUnixNano, "1000000000000")
+	assert.Equal(t, spans[2].Status.Code, 2)
+}
@@ -14,18 +14,19 @@ import (
 	"github.com/loft-sh/devspace/helper/tunnel"
 	"github.com/loft-sh/devspace/helper/util"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/events"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
 
-func ReceiveData(stream remote.Tunnel_InitTunnelClient, closeStream <-chan bool, sessionsOut chan<- *tunnel.Session, port int32, scheme string, log logpkg.Logger) error {
+func ReceiveData(stream remote.Tunnel_InitTunnelClient, closeStream <-chan bool, sessionsOut chan<- *tunnel.Session, address string, scheme string, log logpkg.Logger) error {
 loop:
 	for {
 		m, err := stream.Recv()
 		select {
 		case <-closeStream:
-			log.Debugf("closing listener on %d", port)
+			log.Debugf("closing listener on %s", address)
 			_ = stream.CloseSend()
 			break loop
 		case <-stream.Context().Done():
@@ -54,9 +55,10 @@ loop:
 				log.Debugf("new connection %s", requestID)
 
 				// new session
-				conn, err := net.DialTimeout(strings.ToLower(scheme), fmt.Sprintf("localhost:%d", port), time.Millisecond*500)
+				network, dialAddress := tunnel.SplitAddress(strings.ToLower(scheme), address)
+				conn, err := net.DialTimeout(network, dialAddress, time.Millisecond*500)
 				if err != nil {
-					log.Errorf("failed connecting to localhost on port %d scheme %s: %v", port, scheme, err)
+					log.Errorf("failed connecting to %s scheme %s: %v", address, scheme, err)
 					// close the remote connection
 					resp := &remote.SocketDataRequest{
 						RequestId:   requestID.String(),
@@ -95,12 +97,11 @@ func handleStreamData(m *remote.SocketDataResponse, session *tunnel.Session, log
 	}
 
 	data := m.GetData()
-	log.Debugf("received %d bytes from server", len(data))
-	if len(data) > 0 {
-		session.Lock()
-		_, err := session.Conn.Write(data)
-		session.Unlock()
-		log.Debugf("wrote %d bytes to conn", len(data))
+	datagrams := m.GetDatagrams()
+	log.Debugf("received %d bytes and %d datagrams from server", len(data), len(datagrams))
+	if len(data) > 0 || len(datagrams) > 0 {
+		written, err := session.WriteConn(data, datagrams)
+		log.Debugf("wrote %d bytes to conn", written)
 		if err != nil {
 			log.Warnf("%s: failed writing to socket, closing session: %v", session.ID.String(), err)
 			session.Close()
@@ -114,7 +115,11 @@ func ReadFromSession(session *tunnel.Session, sessionsOut chan<- *tunnel.Session
 	defer log.Debugf("finished reading conn %s", session.ID)
 
 	conn := session.Conn
-	buff := make([]byte, tunnel.BufferSize)
+	bufferSize := tunnel.BufferSize
+	if session.Packet {
+		bufferSize = tunnel.DatagramBufferSize
+	}
+	buff := make([]byte, bufferSize)
 
 loop:
 	for {
@@ -137,14 +142,10 @@ loop:
 			log.Debugf("read %d bytes from conn", br)
 			if br > 0 {
 				session.Lock()
-				_, err = session.Buf.Write(buff[0:br])
+				session.Add(buff[0:br])
 				session.Unlock()
 				log.Debugf("wrote %d bytes to session", br)
 			}
-			if err != nil {
-				log.Errorf("%s: failed writing to session buffer: %v", session.ID, err)
-				break loop
-			}
 
 			sessionsOut <- session
 		}
@@ -162,33 +163,65 @@ func SendData(stream remote.Tunnel_InitTunnelClient, sessions <-chan *tunnel.Ses
 			// read the bytes from the buffer
 			// but allow it to keep growing while we send the response
 			session.Lock()
-			bys := session.Buf.Len()
-			bytes := make([]byte, bys)
-			_, err := session.Buf.Read(bytes)
-			if err != nil {
-				session.Unlock()
-				return fmt.Errorf("failed reading stream from session %v, exiting", err)
-			}
-			log.Debugf("read %d from buffer out of %d available", len(bytes), bys)
+			bytes, datagrams := session.Take()
 			resp := &remote.SocketDataRequest{
 				RequestId:   session.ID.String(),
 				Data:        bytes,
+				Datagrams:   datagrams,
 				ShouldClose: !session.Open,
 			}
 			session.Unlock()
 
-			log.Debugf("sending %d bytes to server", len(bytes))
-			err = stream.Send(resp)
+			log.Debugf("sending %d bytes and %d datagrams to server", len(bytes), len(datagrams))
+			err := stream.Send(resp)
 			if err != nil {
 				return fmt.Errorf("failed sending message to tunnel stream, exiting")
 			}
-			log.Debugf("sent %d bytes to server", len(bytes))
+			log.Debugf("sent %d bytes and %d datagrams to server", len(bytes), len(datagrams))
+		}
+	}
+}
+
+// Scheme returns the tunnel scheme of the port mapping
+func Scheme(portMapping *latest.PortMapping) string {
+	if portMapping.Protocol == latest.PortProtocolUDP {
+		return remote.TunnelScheme_UDP.String()
+	}
+
+	return remote.TunnelScheme_TCP.String()
+}
+
+// formatPorts adds the protocol to the formatted ports if they are not tcp
+func formatPorts(ports, scheme string) string {
+	if scheme == remote.TunnelScheme_TCP.String() {
+		return ports
+	}
+
+	return ports + "/" + strings.ToLower(scheme)
+}
+
+func startPing(ctx context.Context, client remote.TunnelClient, errorsChan chan<- error, closeStream <-chan struct{}, stopChan <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-closeStream:
+			return
+		case <-stopChan:
+			return
+		case <-time.After(time.Second * 20):
+			ctx, cancel := context.WithTimeout(ctx, time.Second*20)
+			_, err := client.Ping(ctx, &remote.Empty{})
+			cancel()
+			if err != nil {
+				errorsChan <- errors.Wrap(err, "ping connection")
+				return
+			}
 		}
 	}
 }
 
 func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.WriteCloser, tunnels []*latest.PortMapping, stopChan chan struct{}, namespace string, name string, log logpkg.Logger) error {
-	scheme := "TCP"
 	closeStreams := make([]chan bool, len(tunnels))
 	defer func() {
 		for _, c := range closeStreams {
@@ -212,26 +245,7 @@ func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.Wr
 	errorsChan := make(chan error, 2*len(tunnels)+1)
 	closeStream := make(chan struct{})
 	defer close(closeStream)
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-closeStream:
-				return
-			case <-stopChan:
-				return
-			case <-time.After(time.Second * 20):
-				ctx, cancel := context.WithTimeout(ctx, time.Second*20)
-				_, err := client.Ping(ctx, &remote.Empty{})
-				cancel()
-				if err != nil {
-					errorsChan <- errors.Wrap(err, "ping connection")
-					return
-				}
-			}
-		}
-	}()
+	go startPing(ctx, client, errorsChan, closeStream, stopChan)
 
 	for i, portMapping := range tunnels {
 		if portMapping.Port == "" {
@@ -245,8 +259,16 @@ func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.Wr
 
 		localPort := mappings[0].Local
 		remotePort := mappings[0].Remote
+		scheme := Scheme(portMapping)
+
+		// forward to the target on the local machine or localhost by default
+		local, address := fmt.Sprintf("%d", localPort), fmt.Sprintf("localhost:%d", localPort)
+		if portMapping.Target != "" {
+			local, address = portMapping.Target, portMapping.Target
+		}
+
 		c := make(chan bool, 1)
-		go func(closeStream chan bool, localPort, remotePort int32) {
+		go func(closeStream chan bool, local, address string, remotePort int32) {
 			tunnelScheme, ok := remote.TunnelScheme_value[scheme]
 			if !ok {
 				errorsChan <- fmt.Errorf("unsupported connection scheme %s", scheme)
@@ -271,7 +293,7 @@ func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.Wr
 
 			sessions := make(chan *tunnel.Session)
 			go func() {
-				err = ReceiveData(stream, closeStream, sessions, localPort, scheme, logFile)
+				err = ReceiveData(stream, closeStream, sessions, address, scheme, logFile)
 				if err != nil {
 					errorsChan <- err
 				}
@@ -284,9 +306,15 @@ func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.Wr
 			}()
 
 			// wait until close
-			log.Donef("Port forwarding started on: %s", ansi.Color(fmt.Sprintf("%d <- %d", localPort, remotePort), "white+b"))
+			log.Donef("Port forwarding started on: %s", ansi.Color(formatPorts(fmt.Sprintf("%s <- %d", local, remotePort), scheme), "white+b"))
+			events.Emit(events.PortForwardOpened, &events.PortForward{
+				Pod:      namespace + "/" + name,
+				Reverse:  true,
+				Protocol: strings.ToLower(scheme),
+				Ports:    []string{fmt.Sprintf("%s:%d", local, remotePort)},
+			})
 			<-closeStream
-		}(c, int32(localPort), int32(remotePort))
+		}(c, local, address, int32(remotePort))
 		closeStreams[i] = c
 	}
 
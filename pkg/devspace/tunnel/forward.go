@@ -0,0 +1,253 @@
+package tunnel
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/loft-sh/devspace/helper/tunnel"
+	"github.com/loft-sh/devspace/helper/util"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/events"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/portforward"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// StartForward forwards local udp ports, local unix sockets and ports with a target to the container
+// through the DevSpace helper. For udp every local peer that sends datagrams gets its own session,
+// which is closed after it was idle for tunnel.IdleTimeout.
+func StartForward(ctx context.Context, reader io.ReadCloser, writer io.WriteCloser, tunnels []*latest.PortMapping, stopChan chan struct{}, namespace string, name string, devName string, log logpkg.Logger) error {
+	// Create client
+	conn, err := util.NewClientConnection(reader, writer)
+	if err != nil {
+		return errors.Wrap(err, "new client connection")
+	}
+
+	client := remote.NewTunnelClient(conn)
+	logFile := logpkg.GetFileLogger("portforwarding")
+
+	errorsChan := make(chan error, 2*len(tunnels)+1)
+	closeStream := make(chan struct{})
+	defer close(closeStream)
+	go startPing(ctx, client, errorsChan, closeStream, stopChan)
+
+	for _, portMapping := range tunnels {
+		request := &remote.SocketDataRequest{
+			Address: portMapping.Target,
+		}
+		local, localName, remoteName := portMapping.LocalSocket, portMapping.LocalSocket, portMapping.Target
+		if portMapping.Port != "" {
+			mappings, err := portforward.ParsePorts([]string{portMapping.Port})
+			if err != nil {
+				return fmt.Errorf("error parsing port %s: %v", portMapping.Port, err)
+			}
+
+			request.Port = int32(mappings[0].Remote)
+			if local == "" {
+				bindAddress := portMapping.BindAddress
+				if bindAddress == "" {
+					bindAddress = "localhost"
+				}
+
+				local = net.JoinHostPort(bindAddress, fmt.Sprintf("%d", mappings[0].Local))
+				localName = fmt.Sprintf("%d", mappings[0].Local)
+			}
+			if remoteName == "" {
+				remoteName = fmt.Sprintf("%d", mappings[0].Remote)
+			}
+		} else if local == "" {
+			return fmt.Errorf("local port cannot be undefined")
+		}
+
+		scheme := Scheme(portMapping)
+		request.Scheme = remote.TunnelScheme(remote.TunnelScheme_value[scheme])
+		stream, err := client.InitForward(ctx)
+		if err != nil {
+			return fmt.Errorf("error sending init forward request: %v", err)
+		}
+
+		err = stream.Send(request)
+		if err != nil {
+			return fmt.Errorf("failed to send initial forward request to server")
+		}
+
+		sessions := make(chan *tunnel.Session)
+		closeChan := make(chan bool)
+		go func() {
+			<-closeStream
+			close(closeChan)
+		}()
+		if portMapping.Protocol == latest.PortProtocolUDP {
+			listener, err := net.ListenPacket("udp", local)
+			if err != nil {
+				return errors.Wrapf(err, "listen on %s", local)
+			}
+			defer listener.Close()
+
+			peers := tunnel.NewPeerSessions(listener)
+			go func() {
+				err := readPackets(listener, peers, sessions, closeStream)
+				if err != nil {
+					errorsChan <- err
+				}
+			}()
+			go peers.CloseIdle(ctx, tunnel.IdleTimeout, sessions)
+		} else {
+			listener, err := listenStream(portMapping.LocalSocket, local)
+			if err != nil {
+				return errors.Wrapf(err, "listen on %s", local)
+			}
+			defer listener.Close()
+
+			go func() {
+				err := acceptConns(listener, sessions, closeStream, logFile)
+				if err != nil {
+					errorsChan <- err
+				}
+			}()
+		}
+		go func() {
+			err := SendData(stream, sessions, closeChan, logFile)
+			if err != nil {
+				errorsChan <- err
+			}
+		}()
+		go func() {
+			err := receiveForwardData(stream, logFile)
+			if err != nil {
+				errorsChan <- err
+			}
+		}()
+
+		log.Donef("Port forwarding started on: %s", ansi.Color(formatPorts(fmt.Sprintf("%s -> %s", localName, remoteName), scheme), "white+b"))
+		events.Emit(events.PortForwardOpened, &events.PortForward{
+			Name:     devName,
+			Pod:      namespace + "/" + name,
+			Protocol: strings.ToLower(scheme),
+			Ports:    []string{localName + ":" + remoteName},
+		})
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errorsChan:
+		return err
+	case <-stopChan:
+		return nil
+	}
+}
+
+// listenStream listens on the local unix socket if it is set and on the tcp address otherwise
+func listenStream(socket, address string) (net.Listener, error) {
+	if socket == "" {
+		return net.Listen("tcp", address)
+	}
+
+	// remove a socket that was left over by a previous run
+	if stat, err := os.Stat(socket); err == nil && stat.Mode()&os.ModeSocket != 0 {
+		_ = os.Remove(socket)
+	}
+
+	return net.Listen("unix", socket)
+}
+
+// acceptConns accepts the connections of the local listener and creates a new session for each
+func acceptConns(listener net.Listener, sessions chan<- *tunnel.Session, closeStream <-chan struct{}, log logpkg.Logger) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-closeStream:
+				return nil
+			default:
+				return errors.Wrap(err, "accept connection")
+			}
+		}
+
+		session, err := tunnel.NewSession(conn)
+		if err != nil {
+			_ = conn.Close()
+			return err
+		}
+
+		go ReadFromSession(session, sessions, log)
+	}
+}
+
+// readPackets reads the datagrams from the local listener and adds them to the session of the sending peer
+func readPackets(listener net.PacketConn, peers *tunnel.PeerSessions, sessions chan<- *tunnel.Session, closeStream <-chan struct{}) error {
+	buff := make([]byte, tunnel.DatagramBufferSize)
+	for {
+		br, peer, err := listener.ReadFrom(buff)
+		if err != nil {
+			select {
+			case <-closeStream:
+				return nil
+			default:
+				return errors.Wrap(err, "read from udp listener")
+			}
+		}
+
+		session, _, err := peers.Get(peer)
+		if err != nil {
+			return err
+		}
+
+		session.Lock()
+		session.Add(buff[0:br])
+		session.Unlock()
+
+		select {
+		case sessions <- session:
+		case <-closeStream:
+			return nil
+		}
+	}
+}
+
+// receiveForwardData writes the datagrams received from the helper back to the local peers
+func receiveForwardData(stream remote.Tunnel_InitForwardClient, log logpkg.Logger) error {
+	for {
+		m, err := stream.Recv()
+		if err != nil {
+			select {
+			case <-stream.Context().Done():
+				return nil
+			default:
+				return fmt.Errorf("error reading from stream: %v", err)
+			}
+		} else if m.HasErr {
+			if m.LogMessage == nil {
+				return fmt.Errorf("remote error: unknown")
+			}
+
+			return fmt.Errorf("helper error: %s", m.LogMessage.Message)
+		}
+
+		requestID, err := uuid.Parse(m.RequestId)
+		if err != nil {
+			log.Errorf("%s; failed parsing session uuid from stream, skipping", m.RequestId)
+			continue
+		}
+
+		session, exists := tunnel.GetSession(requestID)
+		if !exists {
+			continue
+		}
+
+		if m.ShouldClose {
+			session.Lock()
+			session.Open = false
+			session.Unlock()
+		}
+		handleStreamData(m, session, log)
+	}
+}
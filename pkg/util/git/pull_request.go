@@ -0,0 +1,43 @@
+package git
+
+import (
+	"os"
+	"regexp"
+)
+
+var githubPullRequestRefRegEx = regexp.MustCompile(`^refs/pull/([0-9]+)/`)
+
+// pullRequestEnvs are the environment variables of common CI systems that
+// contain the number of the pull request that is built
+var pullRequestEnvs = []string{
+	"DEVSPACE_GIT_PULL_REQUEST",
+	"CHANGE_ID",                            // Jenkins
+	"CI_MERGE_REQUEST_IID",                 // GitLab
+	"BITBUCKET_PR_ID",                      // Bitbucket
+	"SYSTEM_PULLREQUEST_PULLREQUESTNUMBER", // Azure Pipelines
+	"CIRCLE_PR_NUMBER",                     // CircleCI
+	"TRAVIS_PULL_REQUEST",                  // Travis CI
+}
+
+// GetPullRequest returns the number of the pull request that is currently
+// built in CI or an empty string if it cannot be determined
+func GetPullRequest() string {
+	return getPullRequest(os.Getenv)
+}
+
+func getPullRequest(getenv func(string) string) string {
+	for _, env := range pullRequestEnvs {
+		value := getenv(env)
+		if value != "" && value != "false" {
+			return value
+		}
+	}
+
+	// GitHub Actions only exposes the pull request through the ref
+	matches := githubPullRequestRefRegEx.FindStringSubmatch(getenv("GITHUB_REF"))
+	if len(matches) == 2 {
+		return matches[1]
+	}
+
+	return ""
+}
@@ -0,0 +1,40 @@
+package git
+
+import "testing"
+
+func TestGetPullRequest(t *testing.T) {
+	testCases := []struct {
+		env      map[string]string
+		expected string
+	}{
+		{
+			env:      map[string]string{},
+			expected: "",
+		},
+		{
+			env:      map[string]string{"GITHUB_REF": "refs/pull/42/merge"},
+			expected: "42",
+		},
+		{
+			env:      map[string]string{"GITHUB_REF": "refs/heads/main"},
+			expected: "",
+		},
+		{
+			env:      map[string]string{"CI_MERGE_REQUEST_IID": "7"},
+			expected: "7",
+		},
+		{
+			env:      map[string]string{"TRAVIS_PULL_REQUEST": "false"},
+			expected: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		pullRequest := getPullRequest(func(name string) string {
+			return testCase.env[name]
+		})
+		if pullRequest != testCase.expected {
+			t.Fatalf("expected pull request %q for %v, got %q", testCase.expected, testCase.env, pullRequest)
+		}
+	}
+}
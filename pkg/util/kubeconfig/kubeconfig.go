@@ -10,7 +10,17 @@ const AuthCommand = "devspace"
 
 // NewConfig loads a new kube config
 func (l *loader) NewConfig() clientcmd.ClientConfig {
-	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(l.loadingRules(), &clientcmd.ConfigOverrides{})
+}
+
+// loadingRules returns the loading rules for the explicit path or the default ones
+func (l *loader) loadingRules() *clientcmd.ClientConfigLoadingRules {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if l.path != "" {
+		rules.ExplicitPath = l.path
+	}
+
+	return rules
 }
 
 // LoadConfig loads the kube config with the default loading rules
@@ -40,7 +50,7 @@ func (l *loader) GetCurrentContext() (string, error) {
 
 // SaveConfig writes the kube config back to the specified filename
 func (l *loader) SaveConfig(config *api.Config) error {
-	err := clientcmd.ModifyConfig(clientcmd.NewDefaultClientConfigLoadingRules(), *config, false)
+	err := clientcmd.ModifyConfig(l.loadingRules(), *config, false)
 	if err != nil {
 		return err
 	}
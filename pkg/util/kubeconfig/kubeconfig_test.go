@@ -3,6 +3,7 @@ package kubeconfig
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -158,3 +159,39 @@ func TestSaveLoadKubeConfig(t *testing.T) {
 	assert.Equal(t, string(testConfigAsJSON), string(kubeConfigAsJSON), "Readed Config doesn't match written config")
 
 }
+
+func TestLoaderFromPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	err := os.WriteFile(path, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: edge
+  cluster:
+    server: https://edge:6443
+contexts:
+- name: edge
+  context:
+    cluster: edge
+    namespace: edge-ns
+current-context: edge
+`), 0644)
+	assert.NilError(t, err)
+
+	loader := NewLoaderFromPath(path)
+	currentContext, err := loader.GetCurrentContext()
+	assert.NilError(t, err)
+	assert.Equal(t, currentContext, "edge")
+
+	kubeConfig, err := loader.LoadRawConfig()
+	assert.NilError(t, err)
+	assert.Equal(t, kubeConfig.Contexts["edge"].Namespace, "edge-ns")
+
+	// changes are written back to the same file
+	kubeConfig.Contexts["edge"].Namespace = "other-ns"
+	err = loader.SaveConfig(kubeConfig)
+	assert.NilError(t, err)
+
+	kubeConfig, err = NewLoaderFromPath(path).LoadRawConfig()
+	assert.NilError(t, err)
+	assert.Equal(t, kubeConfig.Contexts["edge"].Namespace, "other-ns")
+}
@@ -17,9 +17,18 @@ type Loader interface {
 }
 
 type loader struct {
+	// path is an explicit kube config path that is used instead
+	// of the default loading rules
+	path string
 }
 
 // NewLoader creates a new instance of the interface Loader
 func NewLoader() Loader {
 	return &loader{}
 }
+
+// NewLoaderFromPath creates a new loader that loads the kube config from the given path.
+// If path is empty, the default loading rules are used.
+func NewLoaderFromPath(path string) Loader {
+	return &loader{path: path}
+}
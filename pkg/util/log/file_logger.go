@@ -50,12 +50,12 @@ func GetFileLogger(filename string) Logger {
 			m:      &sync.Mutex{},
 		}
 		newLogger.logger.Formatter = &logrus.JSONFormatter{}
-		newLogger.logger.SetOutput(&lumberjack.Logger{
+		newLogger.logger.SetOutput(NewMaskingWriter(&lumberjack.Logger{
 			Filename:   Logdir + filename + ".log",
 			MaxAge:     12,
 			MaxBackups: 4,
 			MaxSize:    10 * 1024 * 1024,
-		})
+		}))
 
 		newLogger.SetLevel(GetInstance().GetLevel())
 		logs[filename] = newLogger
@@ -99,7 +99,7 @@ func (f *fileLogger) addPrefixes(message string) string {
 		prefix += p
 	}
 
-	return prefix + message
+	return Mask(prefix + message)
 }
 
 func (f *fileLogger) Debug(args ...interface{}) {
@@ -309,7 +309,7 @@ func (f *fileLogger) WriteString(level logrus.Level, message string) {
 		return
 	}
 
-	_, _ = f.logger.Out.Write([]byte(stripEscapeSequences(message)))
+	_, _ = f.logger.Out.Write([]byte(Mask(stripEscapeSequences(message))))
 }
 
 func stripEscapeSequences(str string) string {
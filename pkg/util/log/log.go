@@ -38,6 +38,29 @@ func StartFileLogging() {
 	OverrideRuntimeErrorHandler(false)
 }
 
+// UseErrorStreamOnly makes the global loggers write everything to stderr, which
+// keeps stdout free for machine readable output
+func UseErrorStreamOnly() {
+	streamLogger, ok := baseLog.(*StreamLogger)
+	if !ok {
+		return
+	}
+
+	isDefault := defaultLog == baseLog
+	baseLog = streamLogger.ErrorStreamOnly()
+	if isDefault {
+		defaultLog = baseLog
+	}
+}
+
+// Flush writes the output that is held back by the global logger for masking, which
+// should be called before the process exits
+func Flush() {
+	if streamLogger, ok := baseLog.(*StreamLogger); ok {
+		streamLogger.Flush()
+	}
+}
+
 // GetInstance returns the Logger instance
 func GetInstance() Logger {
 	return defaultLog
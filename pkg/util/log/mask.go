@@ -0,0 +1,219 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaskedValue is written instead of a masked secret value
+const MaskedValue = "******"
+
+// maskingFlushDelay is the time after which held back output is written if no other write follows
+const maskingFlushDelay = 100 * time.Millisecond
+
+// minMaskedValueLength is the minimum length of a masked value, shorter values would
+// mask large parts of the regular log output
+const minMaskedValueLength = 4
+
+// the registry of secret values that are redacted from the output of all loggers
+var (
+	maskedValues   = map[string]bool{}
+	maskedSorted   []string
+	maskedFirst    [256]bool
+	maskedReplacer *strings.Replacer
+	maskedMutex    sync.RWMutex
+)
+
+// AddMaskedValue registers a secret value that is replaced with ****** in the output of all loggers
+func AddMaskedValue(value string) {
+	maskedMutex.Lock()
+	defer maskedMutex.Unlock()
+
+	// log output is written line by line, so we mask the lines of multi line secrets as well
+	added := false
+	for _, v := range append([]string{value}, strings.Split(value, "\n")...) {
+		v = strings.TrimSpace(v)
+		if len(v) < minMaskedValueLength || maskedValues[v] {
+			continue
+		}
+
+		maskedValues[v] = true
+		added = true
+	}
+	if !added {
+		return
+	}
+
+	// replace longer values first, so that values containing other values are masked completely
+	maskedSorted = []string{}
+	for v := range maskedValues {
+		maskedSorted = append(maskedSorted, v)
+	}
+	sort.Slice(maskedSorted, func(i, j int) bool {
+		if len(maskedSorted[i]) != len(maskedSorted[j]) {
+			return len(maskedSorted[i]) > len(maskedSorted[j])
+		}
+
+		return maskedSorted[i] < maskedSorted[j]
+	})
+
+	oldNew := []string{}
+	for _, v := range maskedSorted {
+		oldNew = append(oldNew, v, MaskedValue)
+		maskedFirst[v[0]] = true
+	}
+	maskedReplacer = strings.NewReplacer(oldNew...)
+}
+
+// Mask replaces all registered secret values in the message with ******
+func Mask(message string) string {
+	maskedMutex.RLock()
+	defer maskedMutex.RUnlock()
+
+	if maskedReplacer == nil {
+		return message
+	}
+
+	return maskedReplacer.Replace(message)
+}
+
+// maskPartial masks all registered secret values in data and returns the masked output. If data ends
+// with the beginning of a secret value, that part is returned as pending instead.
+func maskPartial(data []byte) (out []byte, pending []byte) {
+	maskedMutex.RLock()
+	defer maskedMutex.RUnlock()
+
+	if len(maskedSorted) == 0 {
+		return data, nil
+	}
+
+	out = make([]byte, 0, len(data))
+	for i := 0; i < len(data); {
+		if !maskedFirst[data[i]] {
+			out = append(out, data[i])
+			i++
+			continue
+		}
+
+		matched := false
+		for _, v := range maskedSorted {
+			rest := data[i:]
+			if bytes.HasPrefix(rest, []byte(v)) {
+				out = append(out, MaskedValue...)
+				i += len(v)
+				matched = true
+				break
+			} else if len(rest) < len(v) && strings.HasPrefix(v, string(rest)) {
+				// wait for the next write to decide
+				return out, append([]byte{}, rest...)
+			}
+		}
+		if !matched {
+			out = append(out, data[i])
+			i++
+		}
+	}
+
+	return out, nil
+}
+
+// MaskingWriter redacts registered secret values from everything that is written to the underlying
+// writer. Output that could be the beginning of a secret value is held back until the next write,
+// so that secret values which are split across multiple writes are masked as well. Held back output
+// is written after a short delay if no other write follows, on Flush and on Close.
+type MaskingWriter struct {
+	m       sync.Mutex
+	writer  io.Writer
+	pending []byte
+
+	flushDelay time.Duration
+	flushTimer *time.Timer
+}
+
+// NewMaskingWriter creates a new masking writer for the given writer
+func NewMaskingWriter(writer io.Writer) *MaskingWriter {
+	if maskingWriter, ok := writer.(*MaskingWriter); ok {
+		return maskingWriter
+	}
+
+	return &MaskingWriter{
+		writer:     writer,
+		flushDelay: maskingFlushDelay,
+	}
+}
+
+// Write implements io.Writer
+func (w *MaskingWriter) Write(p []byte) (int, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	data := p
+	if len(w.pending) > 0 {
+		data = append(w.pending, p...)
+	}
+
+	out, pending := maskPartial(data)
+	w.pending = pending
+	if len(w.pending) > 0 {
+		w.scheduleFlush()
+	} else if w.flushTimer != nil {
+		w.flushTimer.Stop()
+	}
+	if len(out) > 0 {
+		_, err := w.writer.Write(out)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// scheduleFlush writes the held back output after the flush delay, so that
+// it is not delayed for long if no other write follows
+func (w *MaskingWriter) scheduleFlush() {
+	if w.flushTimer == nil {
+		w.flushTimer = time.AfterFunc(w.flushDelay, func() {
+			_ = w.Flush()
+		})
+		return
+	}
+
+	w.flushTimer.Reset(w.flushDelay)
+}
+
+// Flush writes the output that was held back
+func (w *MaskingWriter) Flush() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.flushTimer != nil {
+		w.flushTimer.Stop()
+	}
+	if len(w.pending) == 0 {
+		return nil
+	}
+
+	pending := Mask(string(w.pending))
+	w.pending = nil
+	_, err := w.writer.Write([]byte(pending))
+	return err
+}
+
+// Close flushes the held back output and closes the underlying writer if possible
+func (w *MaskingWriter) Close() error {
+	err := w.Flush()
+	if err != nil {
+		return err
+	}
+
+	if closer, ok := w.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package log
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gotest.tools/assert"
+)
+
+func TestMask(t *testing.T) {
+	AddMaskedValue("mask-test-password")
+	AddMaskedValue("mask-test-password-with-suffix")
+	AddMaskedValue("abc")
+	AddMaskedValue("-----BEGIN KEY-----\nmask-test-key-line\n-----END KEY-----")
+
+	assert.Equal(t, Mask("login with mask-test-password-with-suffix and mask-test-password"), "login with ****** and ******")
+	assert.Equal(t, Mask("abc is too short to be masked"), "abc is too short to be masked")
+	assert.Equal(t, Mask("key: mask-test-key-line"), "key: ******")
+}
+
+func TestMaskingWriter(t *testing.T) {
+	AddMaskedValue("split-secret-value")
+
+	buffer := &bytes.Buffer{}
+	writer := NewMaskingWriter(buffer)
+	writer.flushDelay = time.Hour
+	for _, chunk := range []string{"token=spl", "it-sec", "ret-value\n", "spl", "it but not a secret\n"} {
+		_, err := writer.Write([]byte(chunk))
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, buffer.String(), "token=******\nsplit but not a secret\n")
+
+	// held back output is written on close
+	_, err := writer.Write([]byte("split-sec"))
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "token=******\nsplit but not a secret\n")
+	assert.NilError(t, writer.Close())
+	assert.Equal(t, buffer.String(), "token=******\nsplit but not a secret\nsplit-sec")
+}
+
+func TestMaskingWriterFlushDelay(t *testing.T) {
+	AddMaskedValue("delayed-secret-value")
+
+	buffer := &syncBuffer{}
+	writer := NewMaskingWriter(buffer)
+	writer.flushDelay = 10 * time.Millisecond
+	_, err := writer.Write([]byte("prompt: delayed-sec"))
+	assert.NilError(t, err)
+
+	// held back output is written if no other write follows
+	waitForOutput(t, buffer, "prompt: delayed-sec")
+	assert.NilError(t, writer.Flush())
+	assert.Equal(t, buffer.String(), "prompt: delayed-sec")
+}
+
+func TestStreamLoggerMasking(t *testing.T) {
+	AddMaskedValue("logger-secret-value")
+
+	buffer := &syncBuffer{}
+	logger := NewStreamLoggerWithFormat(buffer, buffer, logrus.DebugLevel, RawFormat)
+	logger.Debugf("using logger-secret-value")
+	logger.WriteString(logrus.InfoLevel, "password: logger-se")
+	logger.WriteString(logrus.InfoLevel, "cret-value\n")
+	assert.Equal(t, buffer.String(), "using ******\npassword: ******\n")
+
+	// held back output is written on flush and after log messages
+	logger.WriteString(logrus.InfoLevel, "confirm: logger-se")
+	logger.(*StreamLogger).Flush()
+	assert.Equal(t, buffer.String(), "using ******\npassword: ******\nconfirm: logger-se")
+	logger.WriteString(logrus.InfoLevel, "\nlogger-se")
+	logger.Infof("done")
+	assert.Equal(t, buffer.String(), "using ******\npassword: ******\nconfirm: logger-se\nlogger-sedone\n")
+
+	// held back output is written after the flush delay
+	logger.WriteString(logrus.InfoLevel, "logger-se")
+	waitForOutput(t, buffer, "logger-sedone\nlogger-se")
+}
+
+type syncBuffer struct {
+	m      sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.buffer.String()
+}
+
+func waitForOutput(t *testing.T, buffer *syncBuffer, suffix string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !bytes.HasSuffix([]byte(buffer.String()), []byte(suffix)) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected output to end with %q, got %q", suffix, buffer.String())
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -65,8 +65,8 @@ func NewStdoutLogger(stdin io.Reader, stdout, stderr io.Writer, level logrus.Lev
 		level:       level,
 		format:      TextFormat,
 		isTerminal:  isTerminal,
-		stream:      stdout,
-		errorStream: stderr,
+		stream:      NewMaskingWriter(stdout),
+		errorStream: NewMaskingWriter(stderr),
 		survey:      survey.NewSurvey(),
 	}
 }
@@ -77,8 +77,8 @@ func NewStreamLogger(stdout, stderr io.Writer, level logrus.Level) Logger {
 		level:       level,
 		format:      TextFormat,
 		isTerminal:  false,
-		stream:      stdout,
-		errorStream: stderr,
+		stream:      NewMaskingWriter(stdout),
+		errorStream: NewMaskingWriter(stderr),
 	}
 }
 
@@ -88,8 +88,8 @@ func NewStreamLoggerWithFormat(stdout, stderr io.Writer, level logrus.Level, for
 		level:       level,
 		isTerminal:  false,
 		format:      format,
-		stream:      stdout,
-		errorStream: stderr,
+		stream:      NewMaskingWriter(stdout),
+		errorStream: NewMaskingWriter(stderr),
 	}
 }
 
@@ -276,7 +276,7 @@ func (s *StreamLogger) writePrefixes(message string) string {
 
 func (s *StreamLogger) writeMessage(fnType logFunctionType, message string) {
 	fnInformation := fnTypeInformationMap[fnType]
-	message = s.writePrefixes(message)
+	message = Mask(s.writePrefixes(message))
 	for _, s := range s.sinks {
 		if fnInformation.logLevel == logrus.PanicLevel || fnInformation.logLevel == logrus.FatalLevel {
 			s.Print(logrus.ErrorLevel, message)
@@ -305,6 +305,28 @@ func (s *StreamLogger) writeMessage(fnType logFunctionType, message string) {
 		} else if s.format == JSONFormat {
 			s.writeJSON(message, fnInformation.logLevel)
 		}
+
+		// log messages are complete, so nothing needs to be held back for masking
+		flushStream(stream)
+	}
+}
+
+// Flush writes the output that is held back by the masking streams of the logger
+func (s *StreamLogger) Flush() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.flush()
+}
+
+func (s *StreamLogger) flush() {
+	flushStream(s.stream)
+	flushStream(s.errorStream)
+}
+
+func flushStream(stream io.Writer) {
+	if flusher, ok := stream.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
 	}
 }
 
@@ -387,6 +409,7 @@ func (s *StreamLogger) Fatal(args ...interface{}) {
 	msg := fmt.Sprintln(args...)
 
 	s.writeMessage(fatalFn, msg)
+	s.flush()
 	os.Exit(1)
 }
 
@@ -397,6 +420,7 @@ func (s *StreamLogger) Fatalf(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
 
 	s.writeMessage(fatalFn, msg+"\n")
+	s.flush()
 	os.Exit(1)
 }
 
@@ -486,6 +510,7 @@ func (s *StreamLogger) WriteString(level logrus.Level, message string) {
 	s.m.Lock()
 	defer s.m.Unlock()
 
+	message = Mask(message)
 	for _, s := range s.sinks {
 		s.WriteString(level, message)
 	}